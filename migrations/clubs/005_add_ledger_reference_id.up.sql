@@ -0,0 +1,8 @@
+-- Riferimento esterno delle righe ledger (es. listing_id della penale di ritiro del
+-- market). Con reference_id valorizzato lo stesso movimento non puo' essere scritto
+-- due volte per club e reason: e' la chiave di idempotenza di DebitCredits.
+ALTER TABLE ledger ADD COLUMN reference_id TEXT;
+
+CREATE UNIQUE INDEX uq_ledger_club_reason_reference
+    ON ledger (club_id, reason, reference_id)
+    WHERE reference_id IS NOT NULL;
@@ -0,0 +1,22 @@
+-- Lock delle carte (es. carta in vendita sul market).
+-- lock_id e' l'id restituito da LockCard; released_at valorizzato = lock rilasciato.
+-- user_cards.locked resta allineato ai lock attivi nella stessa transazione.
+
+CREATE TABLE card_locks (
+    lock_id      UUID PRIMARY KEY,
+    user_card_id UUID NOT NULL,
+    reason       TEXT NOT NULL,
+
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT now(),
+    released_at  TIMESTAMPTZ,
+
+    CONSTRAINT fk_card_lock_card
+        FOREIGN KEY (user_card_id)
+        REFERENCES user_cards(id)
+        ON DELETE RESTRICT
+);
+
+-- Al massimo un lock attivo per carta.
+CREATE UNIQUE INDEX uq_card_locks_active_card
+    ON card_locks (user_card_id)
+    WHERE released_at IS NULL;
@@ -0,0 +1,24 @@
+-- Trade del market regolati da SettleTrade.
+-- trade_id (listing_id lato market) e' la chiave di idempotenza: la riga viene scritta
+-- nella stessa transazione di crediti, ledger e carte, quindi esiste solo se il
+-- settlement e' stato applicato per intero.
+
+CREATE TABLE settled_trades (
+    trade_id       UUID PRIMARY KEY,
+    buyer_club_id  UUID NOT NULL,
+    seller_club_id UUID NOT NULL,
+    hold_id        UUID NOT NULL,
+    amount         BIGINT NOT NULL CHECK (amount > 0),
+    tax_amount     BIGINT NOT NULL DEFAULT 0 CHECK (tax_amount >= 0),
+
+    created_at     TIMESTAMPTZ NOT NULL DEFAULT now(),
+
+    CONSTRAINT fk_settled_trade_buyer
+        FOREIGN KEY (buyer_club_id)
+        REFERENCES clubs(id)
+        ON DELETE RESTRICT,
+    CONSTRAINT fk_settled_trade_seller
+        FOREIGN KEY (seller_club_id)
+        REFERENCES clubs(id)
+        ON DELETE RESTRICT
+);
@@ -0,0 +1,35 @@
+-- Saldi derivati dal ledger.
+-- clubs.credits resta uno snapshot operativo: deve coincidere con SUM(ledger.amount)
+-- del club. Ogni variazione di credits scrive la riga ledger nella stessa transazione.
+
+-- Saldo di apertura: allinea il ledger ai crediti esistenti prima di questa migration
+-- (club creati o modificati senza righe ledger).
+INSERT INTO ledger (id, club_id, amount, reason, created_at)
+SELECT gen_random_uuid(), c.id, c.credits - COALESCE(l.total, 0), 'opening_balance', now()
+FROM clubs c
+LEFT JOIN (
+    SELECT club_id, SUM(amount) AS total
+    FROM ledger
+    GROUP BY club_id
+) l ON l.club_id = c.id
+WHERE c.credits <> COALESCE(l.total, 0);
+
+-- Correzioni dello snapshot applicate da club-reconcile: credits viene riportato al
+-- saldo del ledger e qui resta traccia del valore precedente.
+CREATE TABLE credit_corrections (
+    id               UUID PRIMARY KEY,
+    club_id          UUID NOT NULL,
+    snapshot_credits BIGINT NOT NULL,
+    ledger_credits   BIGINT NOT NULL,
+    note             TEXT NOT NULL,
+
+    created_at       TIMESTAMPTZ NOT NULL DEFAULT now(),
+
+    CONSTRAINT fk_credit_correction_club
+        FOREIGN KEY (club_id)
+        REFERENCES clubs(id)
+        ON DELETE RESTRICT
+);
+
+CREATE INDEX idx_credit_corrections_club_created_at
+    ON credit_corrections (club_id, created_at);
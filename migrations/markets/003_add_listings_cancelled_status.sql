@@ -0,0 +1,9 @@
+-- Aggiunge lo stato CANCELLED ai listing (ritiro da parte del seller).
+-- Il vincolo viene ricreato: i listing restano comunque mai cancellati fisicamente.
+
+ALTER TABLE listings
+DROP CONSTRAINT listings_status_check;
+
+ALTER TABLE listings
+ADD CONSTRAINT listings_status_check
+CHECK (status IN ('ACTIVE', 'SOLD', 'EXPIRED', 'CANCELLED'));
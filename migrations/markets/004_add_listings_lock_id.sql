@@ -0,0 +1,5 @@
+-- Aggiunge lock_id ai listing per poter rilasciare il lock carta in club-svc.
+-- Necessario quando il listing lascia ACTIVE (scadenza, ritiro o vendita).
+
+ALTER TABLE listings
+ADD COLUMN lock_id TEXT;
@@ -0,0 +1,37 @@
+-- Saga log dei flussi cross-service del market (card lock, credit hold, settle).
+-- Ogni step registra il riferimento in club-svc e la sua compensazione, cosi'
+-- il recovery loop puo' completare o compensare le saga rimaste a meta' dopo un crash.
+
+CREATE TABLE sagas (
+  id          UUID PRIMARY KEY,
+  kind        TEXT NOT NULL,
+  listing_id  UUID NOT NULL,
+  user_id     UUID NOT NULL,
+  status      TEXT NOT NULL,
+  created_at  TIMESTAMPTZ NOT NULL DEFAULT now(),
+  updated_at  TIMESTAMPTZ NOT NULL DEFAULT now(),
+  CONSTRAINT sagas_kind_check
+    CHECK (kind IN ('CREATE_LISTING', 'PLACE_BID', 'BUY_NOW')),
+  CONSTRAINT sagas_status_check
+    CHECK (status IN ('STARTED', 'COMPLETED', 'COMPENSATED'))
+);
+
+CREATE INDEX sagas_started_updated_at_idx
+ON sagas (updated_at)
+WHERE status = 'STARTED';
+
+CREATE TABLE saga_steps (
+  id              UUID PRIMARY KEY,
+  saga_id         UUID NOT NULL REFERENCES sagas (id),
+  step            TEXT NOT NULL,
+  ref_id          TEXT NOT NULL,
+  compensation    TEXT NOT NULL,
+  compensated_at  TIMESTAMPTZ,
+  created_at      TIMESTAMPTZ NOT NULL DEFAULT now(),
+  CONSTRAINT saga_steps_step_check
+    CHECK (step IN ('CARD_LOCK', 'CREDIT_HOLD', 'SETTLE')),
+  CONSTRAINT saga_steps_compensation_check
+    CHECK (compensation IN ('RELEASE_CARD_LOCK', 'RELEASE_CREDIT_HOLD', 'NONE'))
+);
+
+CREATE INDEX saga_steps_saga_id_idx ON saga_steps (saga_id);
@@ -0,0 +1,8 @@
+-- Player della carta in vendita, per la ricerca dei listing per giocatore.
+-- Valorizzato da market-svc alla creazione (club-svc GetMyClub); NULL per i listing precedenti.
+
+ALTER TABLE listings
+ADD COLUMN player_id UUID;
+
+CREATE INDEX listings_player_id_idx ON listings (player_id);
+CREATE INDEX listings_created_at_idx ON listings (created_at);
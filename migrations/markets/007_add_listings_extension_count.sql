@@ -0,0 +1,5 @@
+-- Anti-sniping (soft-close): numero di estensioni di expires_at gia' applicate.
+-- Incrementato nello stesso UPDATE che registra il best_bid.
+
+ALTER TABLE listings
+ADD COLUMN extension_count INT NOT NULL DEFAULT 0;
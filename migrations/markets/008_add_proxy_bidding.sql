@@ -0,0 +1,10 @@
+-- Proxy bidding: tetto massimo del bidder e rilanci automatici.
+-- listings.best_max_bid e' il tetto nascosto del best bidder corrente (NULL per bid normali).
+-- bids.max_bid registra il tetto dichiarato; bids.auto_bid marca i rilanci automatici.
+
+ALTER TABLE listings
+ADD COLUMN best_max_bid BIGINT;
+
+ALTER TABLE bids
+ADD COLUMN max_bid BIGINT,
+ADD COLUMN auto_bid BOOLEAN NOT NULL DEFAULT false;
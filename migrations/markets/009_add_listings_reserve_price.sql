@@ -0,0 +1,5 @@
+-- Prezzo di riserva nascosto: alla scadenza l'asta vende solo se best_bid >= reserve_price.
+-- NULL = nessuna riserva.
+
+ALTER TABLE listings
+ADD COLUMN reserve_price BIGINT;
@@ -0,0 +1,15 @@
+-- Vendite completate (BuyNow o chiusura asta): prezzo lordo, tassa trattenuta al seller e controparti.
+-- Una sola riga per listing: il listing_id e' anche il trade_id passato a SettleTrade.
+
+CREATE TABLE trades (
+  id UUID PRIMARY KEY,
+  listing_id UUID NOT NULL UNIQUE,
+  seller_club_id UUID NOT NULL,
+  buyer_club_id UUID NOT NULL,
+  price BIGINT NOT NULL CHECK (price > 0),
+  tax BIGINT NOT NULL DEFAULT 0 CHECK (tax >= 0 AND tax <= price),
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX trades_seller_club_idx ON trades (seller_club_id, created_at DESC);
+CREATE INDEX trades_buyer_club_idx ON trades (buyer_club_id, created_at DESC);
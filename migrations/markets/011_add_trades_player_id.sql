@@ -0,0 +1,6 @@
+-- Storico prezzi per giocatore: player_id del listing copiato sul trade.
+
+ALTER TABLE trades
+ADD COLUMN player_id UUID;
+
+CREATE INDEX trades_player_id_created_at_idx ON trades (player_id, created_at);
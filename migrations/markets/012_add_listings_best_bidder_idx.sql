@@ -0,0 +1,4 @@
+-- Limite dei bid vincenti per bidder: conteggio dei listing ACTIVE in cui il club e' in testa.
+
+CREATE INDEX listings_active_best_bidder_idx ON listings (best_bidder_club_id)
+WHERE status = 'ACTIVE';
@@ -0,0 +1,11 @@
+-- Range di prezzo per giocatore impostati dagli admin (override del range derivato dai trade).
+-- min_price/max_price NULL = nessun limite su quel lato.
+
+CREATE TABLE player_price_bounds (
+  player_id   UUID PRIMARY KEY,
+  min_price   BIGINT CHECK (min_price > 0),
+  max_price   BIGINT CHECK (max_price > 0),
+  updated_at  TIMESTAMPTZ NOT NULL DEFAULT now(),
+  CONSTRAINT player_price_bounds_range_check
+    CHECK (min_price IS NULL OR max_price IS NULL OR min_price <= max_price)
+);
@@ -0,0 +1,11 @@
+-- Relist: il nuovo listing punta al listing scaduto da cui e' stato rimesso in vendita.
+-- L'indice univoco impedisce di rimettere in vendita due volte lo stesso listing.
+
+ALTER TABLE listings
+ADD COLUMN previous_listing_id UUID;
+
+CREATE UNIQUE INDEX listings_previous_listing_id_key ON listings (previous_listing_id)
+WHERE previous_listing_id IS NOT NULL;
+
+-- Ultimo listing per carta del seller (RelistAllExpired).
+CREATE INDEX listings_seller_card_created_at_idx ON listings (seller_club_id, user_card_id, created_at DESC);
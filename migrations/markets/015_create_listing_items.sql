@@ -0,0 +1,20 @@
+-- Bundle: un listing puo' vendere piu' carte come un unico lotto.
+-- listings.user_card_id resta la prima carta del lotto; tutte le carte (con il
+-- rispettivo lock di club-svc) sono in listing_items.
+
+ALTER TABLE listings
+ADD COLUMN kind TEXT NOT NULL DEFAULT 'SINGLE';
+
+ALTER TABLE listings
+ADD CONSTRAINT listings_kind_check CHECK (kind IN ('SINGLE', 'BUNDLE'));
+
+CREATE TABLE listing_items (
+  listing_id   UUID NOT NULL,
+  position     INT NOT NULL,
+  user_card_id UUID NOT NULL,
+  player_id    UUID,
+  lock_id      TEXT NOT NULL,
+  PRIMARY KEY (listing_id, position)
+);
+
+CREATE INDEX listing_items_user_card_id_idx ON listing_items (user_card_id);
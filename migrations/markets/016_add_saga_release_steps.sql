@@ -0,0 +1,21 @@
+-- Rilasci eseguiti a flusso completato (hold del bidder superato, lock carta e hold
+-- del best bidder di un listing ritirato o venduto): sono step senza compensazione,
+-- completed_at segna il rilascio eseguito e il recovery ripete quelli in sospeso.
+-- CANCEL_LISTING copre il ritiro del listing da parte del seller.
+
+ALTER TABLE saga_steps
+ADD COLUMN completed_at TIMESTAMPTZ;
+
+ALTER TABLE sagas
+DROP CONSTRAINT sagas_kind_check;
+
+ALTER TABLE sagas
+ADD CONSTRAINT sagas_kind_check
+CHECK (kind IN ('CREATE_LISTING', 'PLACE_BID', 'BUY_NOW', 'CANCEL_LISTING'));
+
+ALTER TABLE saga_steps
+DROP CONSTRAINT saga_steps_step_check;
+
+ALTER TABLE saga_steps
+ADD CONSTRAINT saga_steps_step_check
+CHECK (step IN ('CARD_LOCK', 'CREDIT_HOLD', 'SETTLE', 'LOCK_RELEASE', 'HOLD_RELEASE'));
@@ -8,3 +8,9 @@ const ContextUserIDKey contextKey = "user_id"
 
 // UserIDMetadataKey definisce la chiave metadata per l'user_id su gRPC.
 const UserIDMetadataKey = "user_id"
+
+// IdempotencyKeyMetadataKey definisce la chiave metadata per l'idempotency key delle RPC mutative.
+const IdempotencyKeyMetadataKey = "idempotency_key"
+
+// AdminTokenMetadataKey definisce la chiave metadata per il token delle RPC admin.
+const AdminTokenMetadataKey = "admin_token"
@@ -0,0 +1,56 @@
+package redisx
+
+import (
+	"context"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// PubSub pubblica e riceve messaggi su canali Redis, condivisi tra tutte le repliche.
+// La consegna e' at-most-once: chi non e' iscritto al momento del publish perde il messaggio.
+type PubSub struct {
+	client *redis.Client
+}
+
+// NewPubSub usa un client Redis gia' configurato.
+func NewPubSub(client *redis.Client) *PubSub {
+	return &PubSub{client: client}
+}
+
+// Publish invia il payload a tutti gli iscritti del canale.
+func (p *PubSub) Publish(ctx context.Context, channel string, payload []byte) error {
+	return p.client.Publish(ctx, channel, payload).Err()
+}
+
+// Subscribe si iscrive al canale e ritorna i payload ricevuti.
+// Ritorna solo a iscrizione confermata, cosi' i publish successivi non vengono persi.
+// Il canale dei messaggi si chiude dopo la funzione di chiusura o alla chiusura del ctx.
+func (p *PubSub) Subscribe(ctx context.Context, channel string) (<-chan []byte, func() error, error) {
+	sub := p.client.Subscribe(ctx, channel)
+	if _, err := sub.Receive(ctx); err != nil {
+		_ = sub.Close()
+		return nil, nil, err
+	}
+
+	out := make(chan []byte)
+	messages := sub.Channel()
+	go func() {
+		defer close(out)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case msg, ok := <-messages:
+				if !ok {
+					return
+				}
+				select {
+				case out <- []byte(msg.Payload):
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return out, sub.Close, nil
+}
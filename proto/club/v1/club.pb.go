@@ -66,10 +66,12 @@ func (x *GetClubRequest) GetUserId() string {
 }
 
 type GetClubResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Credits       int64                  `protobuf:"varint,1,opt,name=credits,proto3" json:"credits,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// credits e' il saldo totale; available_credits esclude gli hold attivi.
+	Credits          int64 `protobuf:"varint,1,opt,name=credits,proto3" json:"credits,omitempty"`
+	AvailableCredits int64 `protobuf:"varint,2,opt,name=available_credits,json=availableCredits,proto3" json:"available_credits,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
 }
 
 func (x *GetClubResponse) Reset() {
@@ -109,6 +111,13 @@ func (x *GetClubResponse) GetCredits() int64 {
 	return 0
 }
 
+func (x *GetClubResponse) GetAvailableCredits() int64 {
+	if x != nil {
+		return x.AvailableCredits
+	}
+	return 0
+}
+
 type GetMyClubRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	unknownFields protoimpl.UnknownFields
@@ -146,12 +155,14 @@ func (*GetMyClubRequest) Descriptor() ([]byte, []int) {
 }
 
 type GetMyClubResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	ClubId        string                 `protobuf:"bytes,1,opt,name=club_id,json=clubId,proto3" json:"club_id,omitempty"`
-	Credits       int64                  `protobuf:"varint,2,opt,name=credits,proto3" json:"credits,omitempty"`
-	Cards         []*Card                `protobuf:"bytes,3,rep,name=cards,proto3" json:"cards,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	ClubId  string                 `protobuf:"bytes,1,opt,name=club_id,json=clubId,proto3" json:"club_id,omitempty"`
+	Credits int64                  `protobuf:"varint,2,opt,name=credits,proto3" json:"credits,omitempty"`
+	Cards   []*Card                `protobuf:"bytes,3,rep,name=cards,proto3" json:"cards,omitempty"`
+	// Saldo spendibile: credits meno la somma degli hold attivi.
+	AvailableCredits int64 `protobuf:"varint,4,opt,name=available_credits,json=availableCredits,proto3" json:"available_credits,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
 }
 
 func (x *GetMyClubResponse) Reset() {
@@ -205,6 +216,109 @@ func (x *GetMyClubResponse) GetCards() []*Card {
 	return nil
 }
 
+func (x *GetMyClubResponse) GetAvailableCredits() int64 {
+	if x != nil {
+		return x.AvailableCredits
+	}
+	return 0
+}
+
+type GetClubByIDRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ClubId        string                 `protobuf:"bytes,1,opt,name=club_id,json=clubId,proto3" json:"club_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetClubByIDRequest) Reset() {
+	*x = GetClubByIDRequest{}
+	mi := &file_club_v1_club_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetClubByIDRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetClubByIDRequest) ProtoMessage() {}
+
+func (x *GetClubByIDRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_club_v1_club_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetClubByIDRequest.ProtoReflect.Descriptor instead.
+func (*GetClubByIDRequest) Descriptor() ([]byte, []int) {
+	return file_club_v1_club_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetClubByIDRequest) GetClubId() string {
+	if x != nil {
+		return x.ClubId
+	}
+	return ""
+}
+
+type GetClubByIDResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ClubId        string                 `protobuf:"bytes,1,opt,name=club_id,json=clubId,proto3" json:"club_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetClubByIDResponse) Reset() {
+	*x = GetClubByIDResponse{}
+	mi := &file_club_v1_club_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetClubByIDResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetClubByIDResponse) ProtoMessage() {}
+
+func (x *GetClubByIDResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_club_v1_club_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetClubByIDResponse.ProtoReflect.Descriptor instead.
+func (*GetClubByIDResponse) Descriptor() ([]byte, []int) {
+	return file_club_v1_club_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetClubByIDResponse) GetClubId() string {
+	if x != nil {
+		return x.ClubId
+	}
+	return ""
+}
+
+func (x *GetClubByIDResponse) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
 type Card struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -216,7 +330,7 @@ type Card struct {
 
 func (x *Card) Reset() {
 	*x = Card{}
-	mi := &file_club_v1_club_proto_msgTypes[4]
+	mi := &file_club_v1_club_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -228,7 +342,7 @@ func (x *Card) String() string {
 func (*Card) ProtoMessage() {}
 
 func (x *Card) ProtoReflect() protoreflect.Message {
-	mi := &file_club_v1_club_proto_msgTypes[4]
+	mi := &file_club_v1_club_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -241,7 +355,7 @@ func (x *Card) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Card.ProtoReflect.Descriptor instead.
 func (*Card) Descriptor() ([]byte, []int) {
-	return file_club_v1_club_proto_rawDescGZIP(), []int{4}
+	return file_club_v1_club_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *Card) GetId() string {
@@ -266,17 +380,20 @@ func (x *Card) GetLocked() bool {
 }
 
 type LockCardRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	UserCardId    string                 `protobuf:"bytes,2,opt,name=user_card_id,json=userCardId,proto3" json:"user_card_id,omitempty"`
-	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	UserId     string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	UserCardId string                 `protobuf:"bytes,2,opt,name=user_card_id,json=userCardId,proto3" json:"user_card_id,omitempty"`
+	Reason     string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	// lock_id scelto dal chiamante (opzionale): ripetere la richiesta con lo stesso
+	// lock_id ritorna il lock gia' creato invece di fallire.
+	LockId        string `protobuf:"bytes,4,opt,name=lock_id,json=lockId,proto3" json:"lock_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *LockCardRequest) Reset() {
 	*x = LockCardRequest{}
-	mi := &file_club_v1_club_proto_msgTypes[5]
+	mi := &file_club_v1_club_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -288,7 +405,7 @@ func (x *LockCardRequest) String() string {
 func (*LockCardRequest) ProtoMessage() {}
 
 func (x *LockCardRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_club_v1_club_proto_msgTypes[5]
+	mi := &file_club_v1_club_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -301,7 +418,7 @@ func (x *LockCardRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LockCardRequest.ProtoReflect.Descriptor instead.
 func (*LockCardRequest) Descriptor() ([]byte, []int) {
-	return file_club_v1_club_proto_rawDescGZIP(), []int{5}
+	return file_club_v1_club_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *LockCardRequest) GetUserId() string {
@@ -325,6 +442,13 @@ func (x *LockCardRequest) GetReason() string {
 	return ""
 }
 
+func (x *LockCardRequest) GetLockId() string {
+	if x != nil {
+		return x.LockId
+	}
+	return ""
+}
+
 type LockCardResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	LockId        string                 `protobuf:"bytes,1,opt,name=lock_id,json=lockId,proto3" json:"lock_id,omitempty"`
@@ -334,7 +458,7 @@ type LockCardResponse struct {
 
 func (x *LockCardResponse) Reset() {
 	*x = LockCardResponse{}
-	mi := &file_club_v1_club_proto_msgTypes[6]
+	mi := &file_club_v1_club_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -346,7 +470,7 @@ func (x *LockCardResponse) String() string {
 func (*LockCardResponse) ProtoMessage() {}
 
 func (x *LockCardResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_club_v1_club_proto_msgTypes[6]
+	mi := &file_club_v1_club_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -359,7 +483,7 @@ func (x *LockCardResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LockCardResponse.ProtoReflect.Descriptor instead.
 func (*LockCardResponse) Descriptor() ([]byte, []int) {
-	return file_club_v1_club_proto_rawDescGZIP(), []int{6}
+	return file_club_v1_club_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *LockCardResponse) GetLockId() string {
@@ -378,7 +502,7 @@ type ReleaseCardLockRequest struct {
 
 func (x *ReleaseCardLockRequest) Reset() {
 	*x = ReleaseCardLockRequest{}
-	mi := &file_club_v1_club_proto_msgTypes[7]
+	mi := &file_club_v1_club_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -390,7 +514,7 @@ func (x *ReleaseCardLockRequest) String() string {
 func (*ReleaseCardLockRequest) ProtoMessage() {}
 
 func (x *ReleaseCardLockRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_club_v1_club_proto_msgTypes[7]
+	mi := &file_club_v1_club_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -403,7 +527,7 @@ func (x *ReleaseCardLockRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ReleaseCardLockRequest.ProtoReflect.Descriptor instead.
 func (*ReleaseCardLockRequest) Descriptor() ([]byte, []int) {
-	return file_club_v1_club_proto_rawDescGZIP(), []int{7}
+	return file_club_v1_club_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *ReleaseCardLockRequest) GetLockId() string {
@@ -422,7 +546,7 @@ type ReleaseCardLockResponse struct {
 
 func (x *ReleaseCardLockResponse) Reset() {
 	*x = ReleaseCardLockResponse{}
-	mi := &file_club_v1_club_proto_msgTypes[8]
+	mi := &file_club_v1_club_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -434,7 +558,7 @@ func (x *ReleaseCardLockResponse) String() string {
 func (*ReleaseCardLockResponse) ProtoMessage() {}
 
 func (x *ReleaseCardLockResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_club_v1_club_proto_msgTypes[8]
+	mi := &file_club_v1_club_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -447,7 +571,7 @@ func (x *ReleaseCardLockResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ReleaseCardLockResponse.ProtoReflect.Descriptor instead.
 func (*ReleaseCardLockResponse) Descriptor() ([]byte, []int) {
-	return file_club_v1_club_proto_rawDescGZIP(), []int{8}
+	return file_club_v1_club_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *ReleaseCardLockResponse) GetReleased() bool {
@@ -458,17 +582,20 @@ func (x *ReleaseCardLockResponse) GetReleased() bool {
 }
 
 type CreateCreditHoldRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Amount        int64                  `protobuf:"varint,2,opt,name=amount,proto3" json:"amount,omitempty"`
-	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Amount int64                  `protobuf:"varint,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	Reason string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	// hold_id scelto dal chiamante (opzionale): ripetere la richiesta con lo stesso
+	// hold_id ritorna l'hold gia' creato invece di bloccare altri crediti.
+	HoldId        string `protobuf:"bytes,4,opt,name=hold_id,json=holdId,proto3" json:"hold_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *CreateCreditHoldRequest) Reset() {
 	*x = CreateCreditHoldRequest{}
-	mi := &file_club_v1_club_proto_msgTypes[9]
+	mi := &file_club_v1_club_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -480,7 +607,7 @@ func (x *CreateCreditHoldRequest) String() string {
 func (*CreateCreditHoldRequest) ProtoMessage() {}
 
 func (x *CreateCreditHoldRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_club_v1_club_proto_msgTypes[9]
+	mi := &file_club_v1_club_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -493,7 +620,7 @@ func (x *CreateCreditHoldRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateCreditHoldRequest.ProtoReflect.Descriptor instead.
 func (*CreateCreditHoldRequest) Descriptor() ([]byte, []int) {
-	return file_club_v1_club_proto_rawDescGZIP(), []int{9}
+	return file_club_v1_club_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *CreateCreditHoldRequest) GetUserId() string {
@@ -517,6 +644,13 @@ func (x *CreateCreditHoldRequest) GetReason() string {
 	return ""
 }
 
+func (x *CreateCreditHoldRequest) GetHoldId() string {
+	if x != nil {
+		return x.HoldId
+	}
+	return ""
+}
+
 type CreateCreditHoldResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	HoldId        string                 `protobuf:"bytes,1,opt,name=hold_id,json=holdId,proto3" json:"hold_id,omitempty"`
@@ -526,7 +660,7 @@ type CreateCreditHoldResponse struct {
 
 func (x *CreateCreditHoldResponse) Reset() {
 	*x = CreateCreditHoldResponse{}
-	mi := &file_club_v1_club_proto_msgTypes[10]
+	mi := &file_club_v1_club_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -538,7 +672,7 @@ func (x *CreateCreditHoldResponse) String() string {
 func (*CreateCreditHoldResponse) ProtoMessage() {}
 
 func (x *CreateCreditHoldResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_club_v1_club_proto_msgTypes[10]
+	mi := &file_club_v1_club_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -551,7 +685,7 @@ func (x *CreateCreditHoldResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateCreditHoldResponse.ProtoReflect.Descriptor instead.
 func (*CreateCreditHoldResponse) Descriptor() ([]byte, []int) {
-	return file_club_v1_club_proto_rawDescGZIP(), []int{10}
+	return file_club_v1_club_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *CreateCreditHoldResponse) GetHoldId() string {
@@ -570,7 +704,7 @@ type ReleaseCreditHoldRequest struct {
 
 func (x *ReleaseCreditHoldRequest) Reset() {
 	*x = ReleaseCreditHoldRequest{}
-	mi := &file_club_v1_club_proto_msgTypes[11]
+	mi := &file_club_v1_club_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -582,7 +716,7 @@ func (x *ReleaseCreditHoldRequest) String() string {
 func (*ReleaseCreditHoldRequest) ProtoMessage() {}
 
 func (x *ReleaseCreditHoldRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_club_v1_club_proto_msgTypes[11]
+	mi := &file_club_v1_club_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -595,7 +729,7 @@ func (x *ReleaseCreditHoldRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ReleaseCreditHoldRequest.ProtoReflect.Descriptor instead.
 func (*ReleaseCreditHoldRequest) Descriptor() ([]byte, []int) {
-	return file_club_v1_club_proto_rawDescGZIP(), []int{11}
+	return file_club_v1_club_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *ReleaseCreditHoldRequest) GetHoldId() string {
@@ -614,7 +748,7 @@ type ReleaseCreditHoldResponse struct {
 
 func (x *ReleaseCreditHoldResponse) Reset() {
 	*x = ReleaseCreditHoldResponse{}
-	mi := &file_club_v1_club_proto_msgTypes[12]
+	mi := &file_club_v1_club_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -626,7 +760,7 @@ func (x *ReleaseCreditHoldResponse) String() string {
 func (*ReleaseCreditHoldResponse) ProtoMessage() {}
 
 func (x *ReleaseCreditHoldResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_club_v1_club_proto_msgTypes[12]
+	mi := &file_club_v1_club_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -639,7 +773,7 @@ func (x *ReleaseCreditHoldResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ReleaseCreditHoldResponse.ProtoReflect.Descriptor instead.
 func (*ReleaseCreditHoldResponse) Descriptor() ([]byte, []int) {
-	return file_club_v1_club_proto_rawDescGZIP(), []int{12}
+	return file_club_v1_club_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *ReleaseCreditHoldResponse) GetReleased() bool {
@@ -650,18 +784,29 @@ func (x *ReleaseCreditHoldResponse) GetReleased() bool {
 }
 
 type SettleTradeRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	SellerUserId  string                 `protobuf:"bytes,1,opt,name=seller_user_id,json=sellerUserId,proto3" json:"seller_user_id,omitempty"`
-	BuyerUserId   string                 `protobuf:"bytes,2,opt,name=buyer_user_id,json=buyerUserId,proto3" json:"buyer_user_id,omitempty"`
-	UserCardId    string                 `protobuf:"bytes,3,opt,name=user_card_id,json=userCardId,proto3" json:"user_card_id,omitempty"`
-	Amount        int64                  `protobuf:"varint,4,opt,name=amount,proto3" json:"amount,omitempty"`
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	SellerUserId string                 `protobuf:"bytes,1,opt,name=seller_user_id,json=sellerUserId,proto3" json:"seller_user_id,omitempty"`
+	BuyerUserId  string                 `protobuf:"bytes,2,opt,name=buyer_user_id,json=buyerUserId,proto3" json:"buyer_user_id,omitempty"`
+	UserCardId   string                 `protobuf:"bytes,3,opt,name=user_card_id,json=userCardId,proto3" json:"user_card_id,omitempty"`
+	Amount       int64                  `protobuf:"varint,4,opt,name=amount,proto3" json:"amount,omitempty"`
+	HoldId       string                 `protobuf:"bytes,5,opt,name=hold_id,json=holdId,proto3" json:"hold_id,omitempty"`
+	TradeId      string                 `protobuf:"bytes,6,opt,name=trade_id,json=tradeId,proto3" json:"trade_id,omitempty"`
+	CardLockId   string                 `protobuf:"bytes,7,opt,name=card_lock_id,json=cardLockId,proto3" json:"card_lock_id,omitempty"`
+	// Tassa del market trattenuta al seller: amount resta il lordo pagato dal buyer,
+	// seller_net_amount = amount - tax_amount e' quanto viene accreditato al seller.
+	TaxBps          int64 `protobuf:"varint,8,opt,name=tax_bps,json=taxBps,proto3" json:"tax_bps,omitempty"`
+	TaxAmount       int64 `protobuf:"varint,9,opt,name=tax_amount,json=taxAmount,proto3" json:"tax_amount,omitempty"`
+	SellerNetAmount int64 `protobuf:"varint,10,opt,name=seller_net_amount,json=sellerNetAmount,proto3" json:"seller_net_amount,omitempty"`
+	// Bundle: tutte le carte del lotto passano al buyer nella stessa transazione.
+	// Se valorizzato sostituisce user_card_id e card_lock_id.
+	Cards         []*TradeCard `protobuf:"bytes,11,rep,name=cards,proto3" json:"cards,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *SettleTradeRequest) Reset() {
 	*x = SettleTradeRequest{}
-	mi := &file_club_v1_club_proto_msgTypes[13]
+	mi := &file_club_v1_club_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -673,7 +818,7 @@ func (x *SettleTradeRequest) String() string {
 func (*SettleTradeRequest) ProtoMessage() {}
 
 func (x *SettleTradeRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_club_v1_club_proto_msgTypes[13]
+	mi := &file_club_v1_club_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -686,7 +831,7 @@ func (x *SettleTradeRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SettleTradeRequest.ProtoReflect.Descriptor instead.
 func (*SettleTradeRequest) Descriptor() ([]byte, []int) {
-	return file_club_v1_club_proto_rawDescGZIP(), []int{13}
+	return file_club_v1_club_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *SettleTradeRequest) GetSellerUserId() string {
@@ -717,6 +862,108 @@ func (x *SettleTradeRequest) GetAmount() int64 {
 	return 0
 }
 
+func (x *SettleTradeRequest) GetHoldId() string {
+	if x != nil {
+		return x.HoldId
+	}
+	return ""
+}
+
+func (x *SettleTradeRequest) GetTradeId() string {
+	if x != nil {
+		return x.TradeId
+	}
+	return ""
+}
+
+func (x *SettleTradeRequest) GetCardLockId() string {
+	if x != nil {
+		return x.CardLockId
+	}
+	return ""
+}
+
+func (x *SettleTradeRequest) GetTaxBps() int64 {
+	if x != nil {
+		return x.TaxBps
+	}
+	return 0
+}
+
+func (x *SettleTradeRequest) GetTaxAmount() int64 {
+	if x != nil {
+		return x.TaxAmount
+	}
+	return 0
+}
+
+func (x *SettleTradeRequest) GetSellerNetAmount() int64 {
+	if x != nil {
+		return x.SellerNetAmount
+	}
+	return 0
+}
+
+func (x *SettleTradeRequest) GetCards() []*TradeCard {
+	if x != nil {
+		return x.Cards
+	}
+	return nil
+}
+
+// TradeCard e' una carta del lotto con il lock preso alla creazione del listing.
+type TradeCard struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserCardId    string                 `protobuf:"bytes,1,opt,name=user_card_id,json=userCardId,proto3" json:"user_card_id,omitempty"`
+	CardLockId    string                 `protobuf:"bytes,2,opt,name=card_lock_id,json=cardLockId,proto3" json:"card_lock_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TradeCard) Reset() {
+	*x = TradeCard{}
+	mi := &file_club_v1_club_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TradeCard) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TradeCard) ProtoMessage() {}
+
+func (x *TradeCard) ProtoReflect() protoreflect.Message {
+	mi := &file_club_v1_club_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TradeCard.ProtoReflect.Descriptor instead.
+func (*TradeCard) Descriptor() ([]byte, []int) {
+	return file_club_v1_club_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *TradeCard) GetUserCardId() string {
+	if x != nil {
+		return x.UserCardId
+	}
+	return ""
+}
+
+func (x *TradeCard) GetCardLockId() string {
+	if x != nil {
+		return x.CardLockId
+	}
+	return ""
+}
+
 type SettleTradeResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Settled       bool                   `protobuf:"varint,1,opt,name=settled,proto3" json:"settled,omitempty"`
@@ -726,7 +973,7 @@ type SettleTradeResponse struct {
 
 func (x *SettleTradeResponse) Reset() {
 	*x = SettleTradeResponse{}
-	mi := &file_club_v1_club_proto_msgTypes[14]
+	mi := &file_club_v1_club_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -738,7 +985,7 @@ func (x *SettleTradeResponse) String() string {
 func (*SettleTradeResponse) ProtoMessage() {}
 
 func (x *SettleTradeResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_club_v1_club_proto_msgTypes[14]
+	mi := &file_club_v1_club_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -751,7 +998,7 @@ func (x *SettleTradeResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SettleTradeResponse.ProtoReflect.Descriptor instead.
 func (*SettleTradeResponse) Descriptor() ([]byte, []int) {
-	return file_club_v1_club_proto_rawDescGZIP(), []int{14}
+	return file_club_v1_club_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *SettleTradeResponse) GetSettled() bool {
@@ -761,61 +1008,206 @@ func (x *SettleTradeResponse) GetSettled() bool {
 	return false
 }
 
+type DebitCreditsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Amount        int64                  `protobuf:"varint,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	ReferenceId   string                 `protobuf:"bytes,4,opt,name=reference_id,json=referenceId,proto3" json:"reference_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DebitCreditsRequest) Reset() {
+	*x = DebitCreditsRequest{}
+	mi := &file_club_v1_club_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DebitCreditsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DebitCreditsRequest) ProtoMessage() {}
+
+func (x *DebitCreditsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_club_v1_club_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DebitCreditsRequest.ProtoReflect.Descriptor instead.
+func (*DebitCreditsRequest) Descriptor() ([]byte, []int) {
+	return file_club_v1_club_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *DebitCreditsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *DebitCreditsRequest) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *DebitCreditsRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *DebitCreditsRequest) GetReferenceId() string {
+	if x != nil {
+		return x.ReferenceId
+	}
+	return ""
+}
+
+type DebitCreditsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Debited       bool                   `protobuf:"varint,1,opt,name=debited,proto3" json:"debited,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DebitCreditsResponse) Reset() {
+	*x = DebitCreditsResponse{}
+	mi := &file_club_v1_club_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DebitCreditsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DebitCreditsResponse) ProtoMessage() {}
+
+func (x *DebitCreditsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_club_v1_club_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DebitCreditsResponse.ProtoReflect.Descriptor instead.
+func (*DebitCreditsResponse) Descriptor() ([]byte, []int) {
+	return file_club_v1_club_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *DebitCreditsResponse) GetDebited() bool {
+	if x != nil {
+		return x.Debited
+	}
+	return false
+}
+
 var File_club_v1_club_proto protoreflect.FileDescriptor
 
 const file_club_v1_club_proto_rawDesc = "" +
 	"\n" +
 	"\x12club/v1/club.proto\x12\aclub.v1\")\n" +
 	"\x0eGetClubRequest\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\tR\x06userId\"+\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"X\n" +
 	"\x0fGetClubResponse\x12\x18\n" +
-	"\acredits\x18\x01 \x01(\x03R\acredits\"\x12\n" +
-	"\x10GetMyClubRequest\"k\n" +
+	"\acredits\x18\x01 \x01(\x03R\acredits\x12+\n" +
+	"\x11available_credits\x18\x02 \x01(\x03R\x10availableCredits\"\x12\n" +
+	"\x10GetMyClubRequest\"\x98\x01\n" +
 	"\x11GetMyClubResponse\x12\x17\n" +
 	"\aclub_id\x18\x01 \x01(\tR\x06clubId\x12\x18\n" +
 	"\acredits\x18\x02 \x01(\x03R\acredits\x12#\n" +
-	"\x05cards\x18\x03 \x03(\v2\r.club.v1.CardR\x05cards\"K\n" +
+	"\x05cards\x18\x03 \x03(\v2\r.club.v1.CardR\x05cards\x12+\n" +
+	"\x11available_credits\x18\x04 \x01(\x03R\x10availableCredits\"-\n" +
+	"\x12GetClubByIDRequest\x12\x17\n" +
+	"\aclub_id\x18\x01 \x01(\tR\x06clubId\"G\n" +
+	"\x13GetClubByIDResponse\x12\x17\n" +
+	"\aclub_id\x18\x01 \x01(\tR\x06clubId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"K\n" +
 	"\x04Card\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1b\n" +
 	"\tplayer_id\x18\x02 \x01(\tR\bplayerId\x12\x16\n" +
-	"\x06locked\x18\x03 \x01(\bR\x06locked\"d\n" +
+	"\x06locked\x18\x03 \x01(\bR\x06locked\"}\n" +
 	"\x0fLockCardRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\x12 \n" +
 	"\fuser_card_id\x18\x02 \x01(\tR\n" +
 	"userCardId\x12\x16\n" +
-	"\x06reason\x18\x03 \x01(\tR\x06reason\"+\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\x12\x17\n" +
+	"\alock_id\x18\x04 \x01(\tR\x06lockId\"+\n" +
 	"\x10LockCardResponse\x12\x17\n" +
 	"\alock_id\x18\x01 \x01(\tR\x06lockId\"1\n" +
 	"\x16ReleaseCardLockRequest\x12\x17\n" +
 	"\alock_id\x18\x01 \x01(\tR\x06lockId\"5\n" +
 	"\x17ReleaseCardLockResponse\x12\x1a\n" +
-	"\breleased\x18\x01 \x01(\bR\breleased\"b\n" +
+	"\breleased\x18\x01 \x01(\bR\breleased\"{\n" +
 	"\x17CreateCreditHoldRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x16\n" +
 	"\x06amount\x18\x02 \x01(\x03R\x06amount\x12\x16\n" +
-	"\x06reason\x18\x03 \x01(\tR\x06reason\"3\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\x12\x17\n" +
+	"\ahold_id\x18\x04 \x01(\tR\x06holdId\"3\n" +
 	"\x18CreateCreditHoldResponse\x12\x17\n" +
 	"\ahold_id\x18\x01 \x01(\tR\x06holdId\"3\n" +
 	"\x18ReleaseCreditHoldRequest\x12\x17\n" +
 	"\ahold_id\x18\x01 \x01(\tR\x06holdId\"7\n" +
 	"\x19ReleaseCreditHoldResponse\x12\x1a\n" +
-	"\breleased\x18\x01 \x01(\bR\breleased\"\x98\x01\n" +
+	"\breleased\x18\x01 \x01(\bR\breleased\"\xfc\x02\n" +
 	"\x12SettleTradeRequest\x12$\n" +
 	"\x0eseller_user_id\x18\x01 \x01(\tR\fsellerUserId\x12\"\n" +
 	"\rbuyer_user_id\x18\x02 \x01(\tR\vbuyerUserId\x12 \n" +
 	"\fuser_card_id\x18\x03 \x01(\tR\n" +
 	"userCardId\x12\x16\n" +
-	"\x06amount\x18\x04 \x01(\x03R\x06amount\"/\n" +
+	"\x06amount\x18\x04 \x01(\x03R\x06amount\x12\x17\n" +
+	"\ahold_id\x18\x05 \x01(\tR\x06holdId\x12\x19\n" +
+	"\btrade_id\x18\x06 \x01(\tR\atradeId\x12 \n" +
+	"\fcard_lock_id\x18\a \x01(\tR\n" +
+	"cardLockId\x12\x17\n" +
+	"\atax_bps\x18\b \x01(\x03R\x06taxBps\x12\x1d\n" +
+	"\n" +
+	"tax_amount\x18\t \x01(\x03R\ttaxAmount\x12*\n" +
+	"\x11seller_net_amount\x18\n" +
+	" \x01(\x03R\x0fsellerNetAmount\x12(\n" +
+	"\x05cards\x18\v \x03(\v2\x12.club.v1.TradeCardR\x05cards\"O\n" +
+	"\tTradeCard\x12 \n" +
+	"\fuser_card_id\x18\x01 \x01(\tR\n" +
+	"userCardId\x12 \n" +
+	"\fcard_lock_id\x18\x02 \x01(\tR\n" +
+	"cardLockId\"/\n" +
 	"\x13SettleTradeResponse\x12\x18\n" +
-	"\asettled\x18\x01 \x01(\bR\asettled2\xa5\x04\n" +
+	"\asettled\x18\x01 \x01(\bR\asettled\"\x81\x01\n" +
+	"\x13DebitCreditsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x16\n" +
+	"\x06amount\x18\x02 \x01(\x03R\x06amount\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\x12!\n" +
+	"\freference_id\x18\x04 \x01(\tR\vreferenceId\"0\n" +
+	"\x14DebitCreditsResponse\x12\x18\n" +
+	"\adebited\x18\x01 \x01(\bR\adebited2\xbc\x05\n" +
 	"\vClubService\x12<\n" +
 	"\aGetClub\x12\x17.club.v1.GetClubRequest\x1a\x18.club.v1.GetClubResponse\x12B\n" +
-	"\tGetMyClub\x12\x19.club.v1.GetMyClubRequest\x1a\x1a.club.v1.GetMyClubResponse\x12?\n" +
+	"\tGetMyClub\x12\x19.club.v1.GetMyClubRequest\x1a\x1a.club.v1.GetMyClubResponse\x12H\n" +
+	"\vGetClubByID\x12\x1b.club.v1.GetClubByIDRequest\x1a\x1c.club.v1.GetClubByIDResponse\x12?\n" +
 	"\bLockCard\x12\x18.club.v1.LockCardRequest\x1a\x19.club.v1.LockCardResponse\x12T\n" +
 	"\x0fReleaseCardLock\x12\x1f.club.v1.ReleaseCardLockRequest\x1a .club.v1.ReleaseCardLockResponse\x12W\n" +
 	"\x10CreateCreditHold\x12 .club.v1.CreateCreditHoldRequest\x1a!.club.v1.CreateCreditHoldResponse\x12Z\n" +
 	"\x11ReleaseCreditHold\x12!.club.v1.ReleaseCreditHoldRequest\x1a\".club.v1.ReleaseCreditHoldResponse\x12H\n" +
-	"\vSettleTrade\x12\x1b.club.v1.SettleTradeRequest\x1a\x1c.club.v1.SettleTradeResponseBy\n" +
+	"\vSettleTrade\x12\x1b.club.v1.SettleTradeRequest\x1a\x1c.club.v1.SettleTradeResponse\x12K\n" +
+	"\fDebitCredits\x12\x1c.club.v1.DebitCreditsRequest\x1a\x1d.club.v1.DebitCreditsResponseBy\n" +
 	"\vcom.club.v1B\tClubProtoP\x01Z\"UltimateTeamX/proto/club/v1;clubv1\xa2\x02\x03CXX\xaa\x02\aClub.V1\xca\x02\aClub\\V1\xe2\x02\x13Club\\V1\\GPBMetadata\xea\x02\bClub::V1b\x06proto3"
 
 var (
@@ -830,45 +1222,55 @@ func file_club_v1_club_proto_rawDescGZIP() []byte {
 	return file_club_v1_club_proto_rawDescData
 }
 
-var file_club_v1_club_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
+var file_club_v1_club_proto_msgTypes = make([]protoimpl.MessageInfo, 20)
 var file_club_v1_club_proto_goTypes = []any{
 	(*GetClubRequest)(nil),            // 0: club.v1.GetClubRequest
 	(*GetClubResponse)(nil),           // 1: club.v1.GetClubResponse
 	(*GetMyClubRequest)(nil),          // 2: club.v1.GetMyClubRequest
 	(*GetMyClubResponse)(nil),         // 3: club.v1.GetMyClubResponse
-	(*Card)(nil),                      // 4: club.v1.Card
-	(*LockCardRequest)(nil),           // 5: club.v1.LockCardRequest
-	(*LockCardResponse)(nil),          // 6: club.v1.LockCardResponse
-	(*ReleaseCardLockRequest)(nil),    // 7: club.v1.ReleaseCardLockRequest
-	(*ReleaseCardLockResponse)(nil),   // 8: club.v1.ReleaseCardLockResponse
-	(*CreateCreditHoldRequest)(nil),   // 9: club.v1.CreateCreditHoldRequest
-	(*CreateCreditHoldResponse)(nil),  // 10: club.v1.CreateCreditHoldResponse
-	(*ReleaseCreditHoldRequest)(nil),  // 11: club.v1.ReleaseCreditHoldRequest
-	(*ReleaseCreditHoldResponse)(nil), // 12: club.v1.ReleaseCreditHoldResponse
-	(*SettleTradeRequest)(nil),        // 13: club.v1.SettleTradeRequest
-	(*SettleTradeResponse)(nil),       // 14: club.v1.SettleTradeResponse
+	(*GetClubByIDRequest)(nil),        // 4: club.v1.GetClubByIDRequest
+	(*GetClubByIDResponse)(nil),       // 5: club.v1.GetClubByIDResponse
+	(*Card)(nil),                      // 6: club.v1.Card
+	(*LockCardRequest)(nil),           // 7: club.v1.LockCardRequest
+	(*LockCardResponse)(nil),          // 8: club.v1.LockCardResponse
+	(*ReleaseCardLockRequest)(nil),    // 9: club.v1.ReleaseCardLockRequest
+	(*ReleaseCardLockResponse)(nil),   // 10: club.v1.ReleaseCardLockResponse
+	(*CreateCreditHoldRequest)(nil),   // 11: club.v1.CreateCreditHoldRequest
+	(*CreateCreditHoldResponse)(nil),  // 12: club.v1.CreateCreditHoldResponse
+	(*ReleaseCreditHoldRequest)(nil),  // 13: club.v1.ReleaseCreditHoldRequest
+	(*ReleaseCreditHoldResponse)(nil), // 14: club.v1.ReleaseCreditHoldResponse
+	(*SettleTradeRequest)(nil),        // 15: club.v1.SettleTradeRequest
+	(*TradeCard)(nil),                 // 16: club.v1.TradeCard
+	(*SettleTradeResponse)(nil),       // 17: club.v1.SettleTradeResponse
+	(*DebitCreditsRequest)(nil),       // 18: club.v1.DebitCreditsRequest
+	(*DebitCreditsResponse)(nil),      // 19: club.v1.DebitCreditsResponse
 }
 var file_club_v1_club_proto_depIdxs = []int32{
-	4,  // 0: club.v1.GetMyClubResponse.cards:type_name -> club.v1.Card
-	0,  // 1: club.v1.ClubService.GetClub:input_type -> club.v1.GetClubRequest
-	2,  // 2: club.v1.ClubService.GetMyClub:input_type -> club.v1.GetMyClubRequest
-	5,  // 3: club.v1.ClubService.LockCard:input_type -> club.v1.LockCardRequest
-	7,  // 4: club.v1.ClubService.ReleaseCardLock:input_type -> club.v1.ReleaseCardLockRequest
-	9,  // 5: club.v1.ClubService.CreateCreditHold:input_type -> club.v1.CreateCreditHoldRequest
-	11, // 6: club.v1.ClubService.ReleaseCreditHold:input_type -> club.v1.ReleaseCreditHoldRequest
-	13, // 7: club.v1.ClubService.SettleTrade:input_type -> club.v1.SettleTradeRequest
-	1,  // 8: club.v1.ClubService.GetClub:output_type -> club.v1.GetClubResponse
-	3,  // 9: club.v1.ClubService.GetMyClub:output_type -> club.v1.GetMyClubResponse
-	6,  // 10: club.v1.ClubService.LockCard:output_type -> club.v1.LockCardResponse
-	8,  // 11: club.v1.ClubService.ReleaseCardLock:output_type -> club.v1.ReleaseCardLockResponse
-	10, // 12: club.v1.ClubService.CreateCreditHold:output_type -> club.v1.CreateCreditHoldResponse
-	12, // 13: club.v1.ClubService.ReleaseCreditHold:output_type -> club.v1.ReleaseCreditHoldResponse
-	14, // 14: club.v1.ClubService.SettleTrade:output_type -> club.v1.SettleTradeResponse
-	8,  // [8:15] is the sub-list for method output_type
-	1,  // [1:8] is the sub-list for method input_type
-	1,  // [1:1] is the sub-list for extension type_name
-	1,  // [1:1] is the sub-list for extension extendee
-	0,  // [0:1] is the sub-list for field type_name
+	6,  // 0: club.v1.GetMyClubResponse.cards:type_name -> club.v1.Card
+	16, // 1: club.v1.SettleTradeRequest.cards:type_name -> club.v1.TradeCard
+	0,  // 2: club.v1.ClubService.GetClub:input_type -> club.v1.GetClubRequest
+	2,  // 3: club.v1.ClubService.GetMyClub:input_type -> club.v1.GetMyClubRequest
+	4,  // 4: club.v1.ClubService.GetClubByID:input_type -> club.v1.GetClubByIDRequest
+	7,  // 5: club.v1.ClubService.LockCard:input_type -> club.v1.LockCardRequest
+	9,  // 6: club.v1.ClubService.ReleaseCardLock:input_type -> club.v1.ReleaseCardLockRequest
+	11, // 7: club.v1.ClubService.CreateCreditHold:input_type -> club.v1.CreateCreditHoldRequest
+	13, // 8: club.v1.ClubService.ReleaseCreditHold:input_type -> club.v1.ReleaseCreditHoldRequest
+	15, // 9: club.v1.ClubService.SettleTrade:input_type -> club.v1.SettleTradeRequest
+	18, // 10: club.v1.ClubService.DebitCredits:input_type -> club.v1.DebitCreditsRequest
+	1,  // 11: club.v1.ClubService.GetClub:output_type -> club.v1.GetClubResponse
+	3,  // 12: club.v1.ClubService.GetMyClub:output_type -> club.v1.GetMyClubResponse
+	5,  // 13: club.v1.ClubService.GetClubByID:output_type -> club.v1.GetClubByIDResponse
+	8,  // 14: club.v1.ClubService.LockCard:output_type -> club.v1.LockCardResponse
+	10, // 15: club.v1.ClubService.ReleaseCardLock:output_type -> club.v1.ReleaseCardLockResponse
+	12, // 16: club.v1.ClubService.CreateCreditHold:output_type -> club.v1.CreateCreditHoldResponse
+	14, // 17: club.v1.ClubService.ReleaseCreditHold:output_type -> club.v1.ReleaseCreditHoldResponse
+	17, // 18: club.v1.ClubService.SettleTrade:output_type -> club.v1.SettleTradeResponse
+	19, // 19: club.v1.ClubService.DebitCredits:output_type -> club.v1.DebitCreditsResponse
+	11, // [11:20] is the sub-list for method output_type
+	2,  // [2:11] is the sub-list for method input_type
+	2,  // [2:2] is the sub-list for extension type_name
+	2,  // [2:2] is the sub-list for extension extendee
+	0,  // [0:2] is the sub-list for field type_name
 }
 
 func init() { file_club_v1_club_proto_init() }
@@ -882,7 +1284,7 @@ func file_club_v1_club_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_club_v1_club_proto_rawDesc), len(file_club_v1_club_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   15,
+			NumMessages:   20,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -21,11 +21,13 @@ const _ = grpc.SupportPackageIsVersion9
 const (
 	ClubService_GetClub_FullMethodName           = "/club.v1.ClubService/GetClub"
 	ClubService_GetMyClub_FullMethodName         = "/club.v1.ClubService/GetMyClub"
+	ClubService_GetClubByID_FullMethodName       = "/club.v1.ClubService/GetClubByID"
 	ClubService_LockCard_FullMethodName          = "/club.v1.ClubService/LockCard"
 	ClubService_ReleaseCardLock_FullMethodName   = "/club.v1.ClubService/ReleaseCardLock"
 	ClubService_CreateCreditHold_FullMethodName  = "/club.v1.ClubService/CreateCreditHold"
 	ClubService_ReleaseCreditHold_FullMethodName = "/club.v1.ClubService/ReleaseCreditHold"
 	ClubService_SettleTrade_FullMethodName       = "/club.v1.ClubService/SettleTrade"
+	ClubService_DebitCredits_FullMethodName      = "/club.v1.ClubService/DebitCredits"
 )
 
 // ClubServiceClient is the client API for ClubService service.
@@ -34,11 +36,13 @@ const (
 type ClubServiceClient interface {
 	GetClub(ctx context.Context, in *GetClubRequest, opts ...grpc.CallOption) (*GetClubResponse, error)
 	GetMyClub(ctx context.Context, in *GetMyClubRequest, opts ...grpc.CallOption) (*GetMyClubResponse, error)
+	GetClubByID(ctx context.Context, in *GetClubByIDRequest, opts ...grpc.CallOption) (*GetClubByIDResponse, error)
 	LockCard(ctx context.Context, in *LockCardRequest, opts ...grpc.CallOption) (*LockCardResponse, error)
 	ReleaseCardLock(ctx context.Context, in *ReleaseCardLockRequest, opts ...grpc.CallOption) (*ReleaseCardLockResponse, error)
 	CreateCreditHold(ctx context.Context, in *CreateCreditHoldRequest, opts ...grpc.CallOption) (*CreateCreditHoldResponse, error)
 	ReleaseCreditHold(ctx context.Context, in *ReleaseCreditHoldRequest, opts ...grpc.CallOption) (*ReleaseCreditHoldResponse, error)
 	SettleTrade(ctx context.Context, in *SettleTradeRequest, opts ...grpc.CallOption) (*SettleTradeResponse, error)
+	DebitCredits(ctx context.Context, in *DebitCreditsRequest, opts ...grpc.CallOption) (*DebitCreditsResponse, error)
 }
 
 type clubServiceClient struct {
@@ -69,6 +73,16 @@ func (c *clubServiceClient) GetMyClub(ctx context.Context, in *GetMyClubRequest,
 	return out, nil
 }
 
+func (c *clubServiceClient) GetClubByID(ctx context.Context, in *GetClubByIDRequest, opts ...grpc.CallOption) (*GetClubByIDResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetClubByIDResponse)
+	err := c.cc.Invoke(ctx, ClubService_GetClubByID_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *clubServiceClient) LockCard(ctx context.Context, in *LockCardRequest, opts ...grpc.CallOption) (*LockCardResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(LockCardResponse)
@@ -119,17 +133,29 @@ func (c *clubServiceClient) SettleTrade(ctx context.Context, in *SettleTradeRequ
 	return out, nil
 }
 
+func (c *clubServiceClient) DebitCredits(ctx context.Context, in *DebitCreditsRequest, opts ...grpc.CallOption) (*DebitCreditsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DebitCreditsResponse)
+	err := c.cc.Invoke(ctx, ClubService_DebitCredits_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ClubServiceServer is the server API for ClubService service.
 // All implementations must embed UnimplementedClubServiceServer
 // for forward compatibility.
 type ClubServiceServer interface {
 	GetClub(context.Context, *GetClubRequest) (*GetClubResponse, error)
 	GetMyClub(context.Context, *GetMyClubRequest) (*GetMyClubResponse, error)
+	GetClubByID(context.Context, *GetClubByIDRequest) (*GetClubByIDResponse, error)
 	LockCard(context.Context, *LockCardRequest) (*LockCardResponse, error)
 	ReleaseCardLock(context.Context, *ReleaseCardLockRequest) (*ReleaseCardLockResponse, error)
 	CreateCreditHold(context.Context, *CreateCreditHoldRequest) (*CreateCreditHoldResponse, error)
 	ReleaseCreditHold(context.Context, *ReleaseCreditHoldRequest) (*ReleaseCreditHoldResponse, error)
 	SettleTrade(context.Context, *SettleTradeRequest) (*SettleTradeResponse, error)
+	DebitCredits(context.Context, *DebitCreditsRequest) (*DebitCreditsResponse, error)
 	mustEmbedUnimplementedClubServiceServer()
 }
 
@@ -146,6 +172,9 @@ func (UnimplementedClubServiceServer) GetClub(context.Context, *GetClubRequest)
 func (UnimplementedClubServiceServer) GetMyClub(context.Context, *GetMyClubRequest) (*GetMyClubResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetMyClub not implemented")
 }
+func (UnimplementedClubServiceServer) GetClubByID(context.Context, *GetClubByIDRequest) (*GetClubByIDResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetClubByID not implemented")
+}
 func (UnimplementedClubServiceServer) LockCard(context.Context, *LockCardRequest) (*LockCardResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method LockCard not implemented")
 }
@@ -161,6 +190,9 @@ func (UnimplementedClubServiceServer) ReleaseCreditHold(context.Context, *Releas
 func (UnimplementedClubServiceServer) SettleTrade(context.Context, *SettleTradeRequest) (*SettleTradeResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method SettleTrade not implemented")
 }
+func (UnimplementedClubServiceServer) DebitCredits(context.Context, *DebitCreditsRequest) (*DebitCreditsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DebitCredits not implemented")
+}
 func (UnimplementedClubServiceServer) mustEmbedUnimplementedClubServiceServer() {}
 func (UnimplementedClubServiceServer) testEmbeddedByValue()                     {}
 
@@ -218,6 +250,24 @@ func _ClubService_GetMyClub_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ClubService_GetClubByID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetClubByIDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClubServiceServer).GetClubByID(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ClubService_GetClubByID_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClubServiceServer).GetClubByID(ctx, req.(*GetClubByIDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ClubService_LockCard_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(LockCardRequest)
 	if err := dec(in); err != nil {
@@ -308,6 +358,24 @@ func _ClubService_SettleTrade_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ClubService_DebitCredits_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DebitCreditsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClubServiceServer).DebitCredits(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ClubService_DebitCredits_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClubServiceServer).DebitCredits(ctx, req.(*DebitCreditsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ClubService_ServiceDesc is the grpc.ServiceDesc for ClubService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -323,6 +391,10 @@ var ClubService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetMyClub",
 			Handler:    _ClubService_GetMyClub_Handler,
 		},
+		{
+			MethodName: "GetClubByID",
+			Handler:    _ClubService_GetClubByID_Handler,
+		},
 		{
 			MethodName: "LockCard",
 			Handler:    _ClubService_LockCard_Handler,
@@ -343,6 +415,10 @@ var ClubService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "SettleTrade",
 			Handler:    _ClubService_SettleTrade_Handler,
 		},
+		{
+			MethodName: "DebitCredits",
+			Handler:    _ClubService_DebitCredits_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "club/v1/club.proto",
@@ -7,6 +7,7 @@
 package marketv1
 
 import (
+	v1 "UltimateTeamX/proto/common/v1"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	reflect "reflect"
@@ -21,6 +22,286 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// BidStatus e' derivato dallo stato del listing al momento della lettura.
+type BidStatus int32
+
+const (
+	BidStatus_BID_STATUS_UNSPECIFIED BidStatus = 0
+	// Bid migliore su un listing ancora da chiudere.
+	BidStatus_BID_STATUS_WINNING BidStatus = 1
+	// Superato da un'offerta piu' alta su un listing ancora da chiudere.
+	BidStatus_BID_STATUS_OUTBID BidStatus = 2
+	// Listing chiuso senza che questo bid abbia vinto.
+	BidStatus_BID_STATUS_LOST BidStatus = 3
+	// Bid che ha aggiudicato il listing.
+	BidStatus_BID_STATUS_WON BidStatus = 4
+)
+
+// Enum value maps for BidStatus.
+var (
+	BidStatus_name = map[int32]string{
+		0: "BID_STATUS_UNSPECIFIED",
+		1: "BID_STATUS_WINNING",
+		2: "BID_STATUS_OUTBID",
+		3: "BID_STATUS_LOST",
+		4: "BID_STATUS_WON",
+	}
+	BidStatus_value = map[string]int32{
+		"BID_STATUS_UNSPECIFIED": 0,
+		"BID_STATUS_WINNING":     1,
+		"BID_STATUS_OUTBID":      2,
+		"BID_STATUS_LOST":        3,
+		"BID_STATUS_WON":         4,
+	}
+)
+
+func (x BidStatus) Enum() *BidStatus {
+	p := new(BidStatus)
+	*p = x
+	return p
+}
+
+func (x BidStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (BidStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_market_v1_market_proto_enumTypes[0].Descriptor()
+}
+
+func (BidStatus) Type() protoreflect.EnumType {
+	return &file_market_v1_market_proto_enumTypes[0]
+}
+
+func (x BidStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use BidStatus.Descriptor instead.
+func (BidStatus) EnumDescriptor() ([]byte, []int) {
+	return file_market_v1_market_proto_rawDescGZIP(), []int{0}
+}
+
+type ListingEventType int32
+
+const (
+	ListingEventType_LISTING_EVENT_TYPE_UNSPECIFIED ListingEventType = 0
+	ListingEventType_LISTING_EVENT_TYPE_BID         ListingEventType = 1
+	ListingEventType_LISTING_EVENT_TYPE_OUTBID      ListingEventType = 2
+	ListingEventType_LISTING_EVENT_TYPE_SOLD        ListingEventType = 3
+	ListingEventType_LISTING_EVENT_TYPE_EXPIRED     ListingEventType = 4
+	ListingEventType_LISTING_EVENT_TYPE_CANCELLED   ListingEventType = 5
+	// expires_at spostato avanti da un bid negli ultimi secondi (anti-sniping).
+	ListingEventType_LISTING_EVENT_TYPE_EXTENDED ListingEventType = 6
+)
+
+// Enum value maps for ListingEventType.
+var (
+	ListingEventType_name = map[int32]string{
+		0: "LISTING_EVENT_TYPE_UNSPECIFIED",
+		1: "LISTING_EVENT_TYPE_BID",
+		2: "LISTING_EVENT_TYPE_OUTBID",
+		3: "LISTING_EVENT_TYPE_SOLD",
+		4: "LISTING_EVENT_TYPE_EXPIRED",
+		5: "LISTING_EVENT_TYPE_CANCELLED",
+		6: "LISTING_EVENT_TYPE_EXTENDED",
+	}
+	ListingEventType_value = map[string]int32{
+		"LISTING_EVENT_TYPE_UNSPECIFIED": 0,
+		"LISTING_EVENT_TYPE_BID":         1,
+		"LISTING_EVENT_TYPE_OUTBID":      2,
+		"LISTING_EVENT_TYPE_SOLD":        3,
+		"LISTING_EVENT_TYPE_EXPIRED":     4,
+		"LISTING_EVENT_TYPE_CANCELLED":   5,
+		"LISTING_EVENT_TYPE_EXTENDED":    6,
+	}
+)
+
+func (x ListingEventType) Enum() *ListingEventType {
+	p := new(ListingEventType)
+	*p = x
+	return p
+}
+
+func (x ListingEventType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ListingEventType) Descriptor() protoreflect.EnumDescriptor {
+	return file_market_v1_market_proto_enumTypes[1].Descriptor()
+}
+
+func (ListingEventType) Type() protoreflect.EnumType {
+	return &file_market_v1_market_proto_enumTypes[1]
+}
+
+func (x ListingEventType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ListingEventType.Descriptor instead.
+func (ListingEventType) EnumDescriptor() ([]byte, []int) {
+	return file_market_v1_market_proto_rawDescGZIP(), []int{1}
+}
+
+type PriceBoundsSource int32
+
+const (
+	PriceBoundsSource_PRICE_BOUNDS_SOURCE_UNSPECIFIED PriceBoundsSource = 0
+	// Nessun range applicato.
+	PriceBoundsSource_PRICE_BOUNDS_SOURCE_NONE PriceBoundsSource = 1
+	// Override impostato da un admin.
+	PriceBoundsSource_PRICE_BOUNDS_SOURCE_ADMIN PriceBoundsSource = 2
+	// Derivato dalla mediana dei trade recenti.
+	PriceBoundsSource_PRICE_BOUNDS_SOURCE_TRADES PriceBoundsSource = 3
+)
+
+// Enum value maps for PriceBoundsSource.
+var (
+	PriceBoundsSource_name = map[int32]string{
+		0: "PRICE_BOUNDS_SOURCE_UNSPECIFIED",
+		1: "PRICE_BOUNDS_SOURCE_NONE",
+		2: "PRICE_BOUNDS_SOURCE_ADMIN",
+		3: "PRICE_BOUNDS_SOURCE_TRADES",
+	}
+	PriceBoundsSource_value = map[string]int32{
+		"PRICE_BOUNDS_SOURCE_UNSPECIFIED": 0,
+		"PRICE_BOUNDS_SOURCE_NONE":        1,
+		"PRICE_BOUNDS_SOURCE_ADMIN":       2,
+		"PRICE_BOUNDS_SOURCE_TRADES":      3,
+	}
+)
+
+func (x PriceBoundsSource) Enum() *PriceBoundsSource {
+	p := new(PriceBoundsSource)
+	*p = x
+	return p
+}
+
+func (x PriceBoundsSource) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PriceBoundsSource) Descriptor() protoreflect.EnumDescriptor {
+	return file_market_v1_market_proto_enumTypes[2].Descriptor()
+}
+
+func (PriceBoundsSource) Type() protoreflect.EnumType {
+	return &file_market_v1_market_proto_enumTypes[2]
+}
+
+func (x PriceBoundsSource) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PriceBoundsSource.Descriptor instead.
+func (PriceBoundsSource) EnumDescriptor() ([]byte, []int) {
+	return file_market_v1_market_proto_rawDescGZIP(), []int{2}
+}
+
+// PriceField seleziona il prezzo usato da min_price/max_price e dal sort "price".
+type PriceField int32
+
+const (
+	// Prezzo corrente: best_bid se presente, altrimenti start_price.
+	PriceField_PRICE_FIELD_UNSPECIFIED PriceField = 0
+	PriceField_PRICE_FIELD_START       PriceField = 1
+	PriceField_PRICE_FIELD_BUY_NOW     PriceField = 2
+	PriceField_PRICE_FIELD_BEST_BID    PriceField = 3
+)
+
+// Enum value maps for PriceField.
+var (
+	PriceField_name = map[int32]string{
+		0: "PRICE_FIELD_UNSPECIFIED",
+		1: "PRICE_FIELD_START",
+		2: "PRICE_FIELD_BUY_NOW",
+		3: "PRICE_FIELD_BEST_BID",
+	}
+	PriceField_value = map[string]int32{
+		"PRICE_FIELD_UNSPECIFIED": 0,
+		"PRICE_FIELD_START":       1,
+		"PRICE_FIELD_BUY_NOW":     2,
+		"PRICE_FIELD_BEST_BID":    3,
+	}
+)
+
+func (x PriceField) Enum() *PriceField {
+	p := new(PriceField)
+	*p = x
+	return p
+}
+
+func (x PriceField) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PriceField) Descriptor() protoreflect.EnumDescriptor {
+	return file_market_v1_market_proto_enumTypes[3].Descriptor()
+}
+
+func (PriceField) Type() protoreflect.EnumType {
+	return &file_market_v1_market_proto_enumTypes[3]
+}
+
+func (x PriceField) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PriceField.Descriptor instead.
+func (PriceField) EnumDescriptor() ([]byte, []int) {
+	return file_market_v1_market_proto_rawDescGZIP(), []int{3}
+}
+
+type ListingKind int32
+
+const (
+	ListingKind_LISTING_KIND_UNSPECIFIED ListingKind = 0
+	ListingKind_LISTING_KIND_SINGLE      ListingKind = 1
+	ListingKind_LISTING_KIND_BUNDLE      ListingKind = 2
+)
+
+// Enum value maps for ListingKind.
+var (
+	ListingKind_name = map[int32]string{
+		0: "LISTING_KIND_UNSPECIFIED",
+		1: "LISTING_KIND_SINGLE",
+		2: "LISTING_KIND_BUNDLE",
+	}
+	ListingKind_value = map[string]int32{
+		"LISTING_KIND_UNSPECIFIED": 0,
+		"LISTING_KIND_SINGLE":      1,
+		"LISTING_KIND_BUNDLE":      2,
+	}
+)
+
+func (x ListingKind) Enum() *ListingKind {
+	p := new(ListingKind)
+	*p = x
+	return p
+}
+
+func (x ListingKind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ListingKind) Descriptor() protoreflect.EnumDescriptor {
+	return file_market_v1_market_proto_enumTypes[4].Descriptor()
+}
+
+func (ListingKind) Type() protoreflect.EnumType {
+	return &file_market_v1_market_proto_enumTypes[4]
+}
+
+func (x ListingKind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ListingKind.Descriptor instead.
+func (ListingKind) EnumDescriptor() ([]byte, []int) {
+	return file_market_v1_market_proto_rawDescGZIP(), []int{4}
+}
+
 type ListingStatus int32
 
 const (
@@ -60,11 +341,11 @@ func (x ListingStatus) String() string {
 }
 
 func (ListingStatus) Descriptor() protoreflect.EnumDescriptor {
-	return file_market_v1_market_proto_enumTypes[0].Descriptor()
+	return file_market_v1_market_proto_enumTypes[5].Descriptor()
 }
 
 func (ListingStatus) Type() protoreflect.EnumType {
-	return &file_market_v1_market_proto_enumTypes[0]
+	return &file_market_v1_market_proto_enumTypes[5]
 }
 
 func (x ListingStatus) Number() protoreflect.EnumNumber {
@@ -73,7 +354,7 @@ func (x ListingStatus) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use ListingStatus.Descriptor instead.
 func (ListingStatus) EnumDescriptor() ([]byte, []int) {
-	return file_market_v1_market_proto_rawDescGZIP(), []int{0}
+	return file_market_v1_market_proto_rawDescGZIP(), []int{5}
 }
 
 type CreateListingRequest struct {
@@ -83,8 +364,12 @@ type CreateListingRequest struct {
 	StartPrice    int64                  `protobuf:"varint,3,opt,name=start_price,json=startPrice,proto3" json:"start_price,omitempty"`
 	BuyNowPrice   int64                  `protobuf:"varint,4,opt,name=buy_now_price,json=buyNowPrice,proto3" json:"buy_now_price,omitempty"`
 	ExpiresAtUnix int64                  `protobuf:"varint,5,opt,name=expires_at_unix,json=expiresAtUnix,proto3" json:"expires_at_unix,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	// Prezzo di riserva nascosto: l'asta vende solo se best_bid lo raggiunge (0 = nessuna riserva).
+	ReservePrice int64 `protobuf:"varint,6,opt,name=reserve_price,json=reservePrice,proto3" json:"reserve_price,omitempty"`
+	// Bundle: carte vendute come un unico lotto (da 2 a 10, user_card_id vuoto).
+	BundleUserCardIds []string `protobuf:"bytes,7,rep,name=bundle_user_card_ids,json=bundleUserCardIds,proto3" json:"bundle_user_card_ids,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
 }
 
 func (x *CreateListingRequest) Reset() {
@@ -152,6 +437,20 @@ func (x *CreateListingRequest) GetExpiresAtUnix() int64 {
 	return 0
 }
 
+func (x *CreateListingRequest) GetReservePrice() int64 {
+	if x != nil {
+		return x.ReservePrice
+	}
+	return 0
+}
+
+func (x *CreateListingRequest) GetBundleUserCardIds() []string {
+	if x != nil {
+		return x.BundleUserCardIds
+	}
+	return nil
+}
+
 type CreateListingResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	ListingId     string                 `protobuf:"bytes,1,opt,name=listing_id,json=listingId,proto3" json:"listing_id,omitempty"`
@@ -197,10 +496,13 @@ func (x *CreateListingResponse) GetListingId() string {
 }
 
 type PlaceBidRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	ListingId     string                 `protobuf:"bytes,1,opt,name=listing_id,json=listingId,proto3" json:"listing_id,omitempty"`
-	BidderUserId  string                 `protobuf:"bytes,2,opt,name=bidder_user_id,json=bidderUserId,proto3" json:"bidder_user_id,omitempty"`
-	BidAmount     int64                  `protobuf:"varint,3,opt,name=bid_amount,json=bidAmount,proto3" json:"bid_amount,omitempty"`
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	ListingId    string                 `protobuf:"bytes,1,opt,name=listing_id,json=listingId,proto3" json:"listing_id,omitempty"`
+	BidderUserId string                 `protobuf:"bytes,2,opt,name=bidder_user_id,json=bidderUserId,proto3" json:"bidder_user_id,omitempty"`
+	BidAmount    int64                  `protobuf:"varint,3,opt,name=bid_amount,json=bidAmount,proto3" json:"bid_amount,omitempty"`
+	// Proxy bidding: tetto massimo; il market rilancia in automatico fino a questo importo.
+	// Se valorizzato, bid_amount e' opzionale e non puo' superarlo.
+	MaxBid        int64 `protobuf:"varint,4,opt,name=max_bid,json=maxBid,proto3" json:"max_bid,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -256,12 +558,21 @@ func (x *PlaceBidRequest) GetBidAmount() int64 {
 	return 0
 }
 
+func (x *PlaceBidRequest) GetMaxBid() int64 {
+	if x != nil {
+		return x.MaxBid
+	}
+	return 0
+}
+
 type PlaceBidResponse struct {
 	state            protoimpl.MessageState `protogen:"open.v1"`
 	BestBid          int64                  `protobuf:"varint,1,opt,name=best_bid,json=bestBid,proto3" json:"best_bid,omitempty"`
 	BestBidderUserId string                 `protobuf:"bytes,2,opt,name=best_bidder_user_id,json=bestBidderUserId,proto3" json:"best_bidder_user_id,omitempty"`
-	unknownFields    protoimpl.UnknownFields
-	sizeCache        protoimpl.SizeCache
+	// False se il bid e' stato subito superato dal tetto proxy del best bidder.
+	Winning       bool `protobuf:"varint,3,opt,name=winning,proto3" json:"winning,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *PlaceBidResponse) Reset() {
@@ -308,6 +619,13 @@ func (x *PlaceBidResponse) GetBestBidderUserId() string {
 	return ""
 }
 
+func (x *PlaceBidResponse) GetWinning() bool {
+	if x != nil {
+		return x.Winning
+	}
+	return false
+}
+
 type BuyNowRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	ListingId     string                 `protobuf:"bytes,1,opt,name=listing_id,json=listingId,proto3" json:"listing_id,omitempty"`
@@ -459,8 +777,17 @@ type GetListingResponse struct {
 	BestBidderUserId string                 `protobuf:"bytes,7,opt,name=best_bidder_user_id,json=bestBidderUserId,proto3" json:"best_bidder_user_id,omitempty"`
 	ExpiresAtUnix    int64                  `protobuf:"varint,8,opt,name=expires_at_unix,json=expiresAtUnix,proto3" json:"expires_at_unix,omitempty"`
 	Status           ListingStatus          `protobuf:"varint,9,opt,name=status,proto3,enum=market.v1.ListingStatus" json:"status,omitempty"`
-	unknownFields    protoimpl.UnknownFields
-	sizeCache        protoimpl.SizeCache
+	// Estensioni anti-sniping gia' applicate a expires_at.
+	ExtensionCount int32 `protobuf:"varint,10,opt,name=extension_count,json=extensionCount,proto3" json:"extension_count,omitempty"`
+	// Valorizzato solo se l'user_id nelle metadata gRPC e' il seller.
+	ReservePrice int64 `protobuf:"varint,11,opt,name=reserve_price,json=reservePrice,proto3" json:"reserve_price,omitempty"`
+	// Listing scaduto da cui e' stato rimesso in vendita (vuoto se creato con CreateListing).
+	PreviousListingId string      `protobuf:"bytes,12,opt,name=previous_listing_id,json=previousListingId,proto3" json:"previous_listing_id,omitempty"`
+	Kind              ListingKind `protobuf:"varint,13,opt,name=kind,proto3,enum=market.v1.ListingKind" json:"kind,omitempty"`
+	// Carte del listing: una per SINGLE, tutte quelle del lotto per BUNDLE.
+	UserCardIds   []string `protobuf:"bytes,14,rep,name=user_card_ids,json=userCardIds,proto3" json:"user_card_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *GetListingResponse) Reset() {
@@ -556,65 +883,2101 @@ func (x *GetListingResponse) GetStatus() ListingStatus {
 	return ListingStatus_LISTING_STATUS_UNSPECIFIED
 }
 
-var File_market_v1_market_proto protoreflect.FileDescriptor
+func (x *GetListingResponse) GetExtensionCount() int32 {
+	if x != nil {
+		return x.ExtensionCount
+	}
+	return 0
+}
 
-const file_market_v1_market_proto_rawDesc = "" +
-	"\n" +
-	"\x16market/v1/market.proto\x12\tmarket.v1\"\xcb\x01\n" +
-	"\x14CreateListingRequest\x12$\n" +
-	"\x0eseller_user_id\x18\x01 \x01(\tR\fsellerUserId\x12 \n" +
-	"\fuser_card_id\x18\x02 \x01(\tR\n" +
-	"userCardId\x12\x1f\n" +
-	"\vstart_price\x18\x03 \x01(\x03R\n" +
-	"startPrice\x12\"\n" +
-	"\rbuy_now_price\x18\x04 \x01(\x03R\vbuyNowPrice\x12&\n" +
-	"\x0fexpires_at_unix\x18\x05 \x01(\x03R\rexpiresAtUnix\"6\n" +
-	"\x15CreateListingResponse\x12\x1d\n" +
-	"\n" +
-	"listing_id\x18\x01 \x01(\tR\tlistingId\"u\n" +
-	"\x0fPlaceBidRequest\x12\x1d\n" +
-	"\n" +
-	"listing_id\x18\x01 \x01(\tR\tlistingId\x12$\n" +
-	"\x0ebidder_user_id\x18\x02 \x01(\tR\fbidderUserId\x12\x1d\n" +
-	"\n" +
-	"bid_amount\x18\x03 \x01(\x03R\tbidAmount\"\\\n" +
-	"\x10PlaceBidResponse\x12\x19\n" +
-	"\bbest_bid\x18\x01 \x01(\x03R\abestBid\x12-\n" +
-	"\x13best_bidder_user_id\x18\x02 \x01(\tR\x10bestBidderUserId\"R\n" +
-	"\rBuyNowRequest\x12\x1d\n" +
-	"\n" +
-	"listing_id\x18\x01 \x01(\tR\tlistingId\x12\"\n" +
-	"\rbuyer_user_id\x18\x02 \x01(\tR\vbuyerUserId\".\n" +
-	"\x0eBuyNowResponse\x12\x1c\n" +
-	"\tpurchased\x18\x01 \x01(\bR\tpurchased\"2\n" +
-	"\x11GetListingRequest\x12\x1d\n" +
-	"\n" +
-	"listing_id\x18\x01 \x01(\tR\tlistingId\"\xe4\x02\n" +
-	"\x12GetListingResponse\x12\x1d\n" +
-	"\n" +
-	"listing_id\x18\x01 \x01(\tR\tlistingId\x12$\n" +
-	"\x0eseller_user_id\x18\x02 \x01(\tR\fsellerUserId\x12 \n" +
-	"\fuser_card_id\x18\x03 \x01(\tR\n" +
-	"userCardId\x12\x1f\n" +
-	"\vstart_price\x18\x04 \x01(\x03R\n" +
-	"startPrice\x12\"\n" +
-	"\rbuy_now_price\x18\x05 \x01(\x03R\vbuyNowPrice\x12\x19\n" +
-	"\bbest_bid\x18\x06 \x01(\x03R\abestBid\x12-\n" +
-	"\x13best_bidder_user_id\x18\a \x01(\tR\x10bestBidderUserId\x12&\n" +
-	"\x0fexpires_at_unix\x18\b \x01(\x03R\rexpiresAtUnix\x120\n" +
-	"\x06status\x18\t \x01(\x0e2\x18.market.v1.ListingStatusR\x06status*\x9d\x01\n" +
-	"\rListingStatus\x12\x1e\n" +
-	"\x1aLISTING_STATUS_UNSPECIFIED\x10\x00\x12\x19\n" +
-	"\x15LISTING_STATUS_ACTIVE\x10\x01\x12\x1a\n" +
-	"\x16LISTING_STATUS_EXPIRED\x10\x02\x12\x17\n" +
-	"\x13LISTING_STATUS_SOLD\x10\x03\x12\x1c\n" +
-	"\x18LISTING_STATUS_CANCELLED\x10\x042\xb2\x02\n" +
-	"\rMarketService\x12R\n" +
-	"\rCreateListing\x12\x1f.market.v1.CreateListingRequest\x1a .market.v1.CreateListingResponse\x12C\n" +
-	"\bPlaceBid\x12\x1a.market.v1.PlaceBidRequest\x1a\x1b.market.v1.PlaceBidResponse\x12=\n" +
-	"\x06BuyNow\x12\x18.market.v1.BuyNowRequest\x1a\x19.market.v1.BuyNowResponse\x12I\n" +
+func (x *GetListingResponse) GetReservePrice() int64 {
+	if x != nil {
+		return x.ReservePrice
+	}
+	return 0
+}
+
+func (x *GetListingResponse) GetPreviousListingId() string {
+	if x != nil {
+		return x.PreviousListingId
+	}
+	return ""
+}
+
+func (x *GetListingResponse) GetKind() ListingKind {
+	if x != nil {
+		return x.Kind
+	}
+	return ListingKind_LISTING_KIND_UNSPECIFIED
+}
+
+func (x *GetListingResponse) GetUserCardIds() []string {
+	if x != nil {
+		return x.UserCardIds
+	}
+	return nil
+}
+
+type CancelListingRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ListingId     string                 `protobuf:"bytes,1,opt,name=listing_id,json=listingId,proto3" json:"listing_id,omitempty"`
+	SellerUserId  string                 `protobuf:"bytes,2,opt,name=seller_user_id,json=sellerUserId,proto3" json:"seller_user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelListingRequest) Reset() {
+	*x = CancelListingRequest{}
+	mi := &file_market_v1_market_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelListingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelListingRequest) ProtoMessage() {}
+
+func (x *CancelListingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_market_v1_market_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelListingRequest.ProtoReflect.Descriptor instead.
+func (*CancelListingRequest) Descriptor() ([]byte, []int) {
+	return file_market_v1_market_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *CancelListingRequest) GetListingId() string {
+	if x != nil {
+		return x.ListingId
+	}
+	return ""
+}
+
+func (x *CancelListingRequest) GetSellerUserId() string {
+	if x != nil {
+		return x.SellerUserId
+	}
+	return ""
+}
+
+type CancelListingResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Cancelled     bool                   `protobuf:"varint,1,opt,name=cancelled,proto3" json:"cancelled,omitempty"`
+	Penalty       int64                  `protobuf:"varint,2,opt,name=penalty,proto3" json:"penalty,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelListingResponse) Reset() {
+	*x = CancelListingResponse{}
+	mi := &file_market_v1_market_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelListingResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelListingResponse) ProtoMessage() {}
+
+func (x *CancelListingResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_market_v1_market_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelListingResponse.ProtoReflect.Descriptor instead.
+func (*CancelListingResponse) Descriptor() ([]byte, []int) {
+	return file_market_v1_market_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *CancelListingResponse) GetCancelled() bool {
+	if x != nil {
+		return x.Cancelled
+	}
+	return false
+}
+
+func (x *CancelListingResponse) GetPenalty() int64 {
+	if x != nil {
+		return x.Penalty
+	}
+	return 0
+}
+
+type SearchListingsRequest struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Status              ListingStatus          `protobuf:"varint,1,opt,name=status,proto3,enum=market.v1.ListingStatus" json:"status,omitempty"`
+	PriceField          PriceField             `protobuf:"varint,2,opt,name=price_field,json=priceField,proto3,enum=market.v1.PriceField" json:"price_field,omitempty"`
+	MinPrice            int64                  `protobuf:"varint,3,opt,name=min_price,json=minPrice,proto3" json:"min_price,omitempty"`
+	MaxPrice            int64                  `protobuf:"varint,4,opt,name=max_price,json=maxPrice,proto3" json:"max_price,omitempty"`
+	SellerUserId        string                 `protobuf:"bytes,5,opt,name=seller_user_id,json=sellerUserId,proto3" json:"seller_user_id,omitempty"`
+	PlayerId            string                 `protobuf:"bytes,6,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	EndingWithinSeconds int64                  `protobuf:"varint,7,opt,name=ending_within_seconds,json=endingWithinSeconds,proto3" json:"ending_within_seconds,omitempty"`
+	Pagination          *v1.Pagination         `protobuf:"bytes,8,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	// sort.field: expires_at (default), price, created_at.
+	Sort          *v1.Sort `protobuf:"bytes,9,opt,name=sort,proto3" json:"sort,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchListingsRequest) Reset() {
+	*x = SearchListingsRequest{}
+	mi := &file_market_v1_market_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchListingsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchListingsRequest) ProtoMessage() {}
+
+func (x *SearchListingsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_market_v1_market_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchListingsRequest.ProtoReflect.Descriptor instead.
+func (*SearchListingsRequest) Descriptor() ([]byte, []int) {
+	return file_market_v1_market_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *SearchListingsRequest) GetStatus() ListingStatus {
+	if x != nil {
+		return x.Status
+	}
+	return ListingStatus_LISTING_STATUS_UNSPECIFIED
+}
+
+func (x *SearchListingsRequest) GetPriceField() PriceField {
+	if x != nil {
+		return x.PriceField
+	}
+	return PriceField_PRICE_FIELD_UNSPECIFIED
+}
+
+func (x *SearchListingsRequest) GetMinPrice() int64 {
+	if x != nil {
+		return x.MinPrice
+	}
+	return 0
+}
+
+func (x *SearchListingsRequest) GetMaxPrice() int64 {
+	if x != nil {
+		return x.MaxPrice
+	}
+	return 0
+}
+
+func (x *SearchListingsRequest) GetSellerUserId() string {
+	if x != nil {
+		return x.SellerUserId
+	}
+	return ""
+}
+
+func (x *SearchListingsRequest) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+func (x *SearchListingsRequest) GetEndingWithinSeconds() int64 {
+	if x != nil {
+		return x.EndingWithinSeconds
+	}
+	return 0
+}
+
+func (x *SearchListingsRequest) GetPagination() *v1.Pagination {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+func (x *SearchListingsRequest) GetSort() *v1.Sort {
+	if x != nil {
+		return x.Sort
+	}
+	return nil
+}
+
+type SearchListingsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Listings      []*Listing             `protobuf:"bytes,1,rep,name=listings,proto3" json:"listings,omitempty"`
+	TotalCount    int64                  `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	Page          uint32                 `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      uint32                 `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchListingsResponse) Reset() {
+	*x = SearchListingsResponse{}
+	mi := &file_market_v1_market_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchListingsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchListingsResponse) ProtoMessage() {}
+
+func (x *SearchListingsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_market_v1_market_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchListingsResponse.ProtoReflect.Descriptor instead.
+func (*SearchListingsResponse) Descriptor() ([]byte, []int) {
+	return file_market_v1_market_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *SearchListingsResponse) GetListings() []*Listing {
+	if x != nil {
+		return x.Listings
+	}
+	return nil
+}
+
+func (x *SearchListingsResponse) GetTotalCount() int64 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+func (x *SearchListingsResponse) GetPage() uint32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *SearchListingsResponse) GetPageSize() uint32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type Listing struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	ListingId        string                 `protobuf:"bytes,1,opt,name=listing_id,json=listingId,proto3" json:"listing_id,omitempty"`
+	SellerUserId     string                 `protobuf:"bytes,2,opt,name=seller_user_id,json=sellerUserId,proto3" json:"seller_user_id,omitempty"`
+	UserCardId       string                 `protobuf:"bytes,3,opt,name=user_card_id,json=userCardId,proto3" json:"user_card_id,omitempty"`
+	PlayerId         string                 `protobuf:"bytes,4,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	StartPrice       int64                  `protobuf:"varint,5,opt,name=start_price,json=startPrice,proto3" json:"start_price,omitempty"`
+	BuyNowPrice      int64                  `protobuf:"varint,6,opt,name=buy_now_price,json=buyNowPrice,proto3" json:"buy_now_price,omitempty"`
+	BestBid          int64                  `protobuf:"varint,7,opt,name=best_bid,json=bestBid,proto3" json:"best_bid,omitempty"`
+	BestBidderUserId string                 `protobuf:"bytes,8,opt,name=best_bidder_user_id,json=bestBidderUserId,proto3" json:"best_bidder_user_id,omitempty"`
+	ExpiresAtUnix    int64                  `protobuf:"varint,9,opt,name=expires_at_unix,json=expiresAtUnix,proto3" json:"expires_at_unix,omitempty"`
+	CreatedAtUnix    int64                  `protobuf:"varint,10,opt,name=created_at_unix,json=createdAtUnix,proto3" json:"created_at_unix,omitempty"`
+	Status           ListingStatus          `protobuf:"varint,11,opt,name=status,proto3,enum=market.v1.ListingStatus" json:"status,omitempty"`
+	ExtensionCount   int32                  `protobuf:"varint,12,opt,name=extension_count,json=extensionCount,proto3" json:"extension_count,omitempty"`
+	// Per i BUNDLE user_card_id e' la prima carta del lotto e player_id e' vuoto.
+	Kind          ListingKind `protobuf:"varint,13,opt,name=kind,proto3,enum=market.v1.ListingKind" json:"kind,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Listing) Reset() {
+	*x = Listing{}
+	mi := &file_market_v1_market_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Listing) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Listing) ProtoMessage() {}
+
+func (x *Listing) ProtoReflect() protoreflect.Message {
+	mi := &file_market_v1_market_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Listing.ProtoReflect.Descriptor instead.
+func (*Listing) Descriptor() ([]byte, []int) {
+	return file_market_v1_market_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *Listing) GetListingId() string {
+	if x != nil {
+		return x.ListingId
+	}
+	return ""
+}
+
+func (x *Listing) GetSellerUserId() string {
+	if x != nil {
+		return x.SellerUserId
+	}
+	return ""
+}
+
+func (x *Listing) GetUserCardId() string {
+	if x != nil {
+		return x.UserCardId
+	}
+	return ""
+}
+
+func (x *Listing) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+func (x *Listing) GetStartPrice() int64 {
+	if x != nil {
+		return x.StartPrice
+	}
+	return 0
+}
+
+func (x *Listing) GetBuyNowPrice() int64 {
+	if x != nil {
+		return x.BuyNowPrice
+	}
+	return 0
+}
+
+func (x *Listing) GetBestBid() int64 {
+	if x != nil {
+		return x.BestBid
+	}
+	return 0
+}
+
+func (x *Listing) GetBestBidderUserId() string {
+	if x != nil {
+		return x.BestBidderUserId
+	}
+	return ""
+}
+
+func (x *Listing) GetExpiresAtUnix() int64 {
+	if x != nil {
+		return x.ExpiresAtUnix
+	}
+	return 0
+}
+
+func (x *Listing) GetCreatedAtUnix() int64 {
+	if x != nil {
+		return x.CreatedAtUnix
+	}
+	return 0
+}
+
+func (x *Listing) GetStatus() ListingStatus {
+	if x != nil {
+		return x.Status
+	}
+	return ListingStatus_LISTING_STATUS_UNSPECIFIED
+}
+
+func (x *Listing) GetExtensionCount() int32 {
+	if x != nil {
+		return x.ExtensionCount
+	}
+	return 0
+}
+
+func (x *Listing) GetKind() ListingKind {
+	if x != nil {
+		return x.Kind
+	}
+	return ListingKind_LISTING_KIND_UNSPECIFIED
+}
+
+type ListBidsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ListingId     string                 `protobuf:"bytes,1,opt,name=listing_id,json=listingId,proto3" json:"listing_id,omitempty"`
+	Pagination    *v1.Pagination         `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBidsRequest) Reset() {
+	*x = ListBidsRequest{}
+	mi := &file_market_v1_market_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBidsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBidsRequest) ProtoMessage() {}
+
+func (x *ListBidsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_market_v1_market_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBidsRequest.ProtoReflect.Descriptor instead.
+func (*ListBidsRequest) Descriptor() ([]byte, []int) {
+	return file_market_v1_market_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ListBidsRequest) GetListingId() string {
+	if x != nil {
+		return x.ListingId
+	}
+	return ""
+}
+
+func (x *ListBidsRequest) GetPagination() *v1.Pagination {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+type ListBidsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Bids          []*Bid                 `protobuf:"bytes,1,rep,name=bids,proto3" json:"bids,omitempty"`
+	TotalCount    int64                  `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	Page          uint32                 `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      uint32                 `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBidsResponse) Reset() {
+	*x = ListBidsResponse{}
+	mi := &file_market_v1_market_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBidsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBidsResponse) ProtoMessage() {}
+
+func (x *ListBidsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_market_v1_market_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBidsResponse.ProtoReflect.Descriptor instead.
+func (*ListBidsResponse) Descriptor() ([]byte, []int) {
+	return file_market_v1_market_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ListBidsResponse) GetBids() []*Bid {
+	if x != nil {
+		return x.Bids
+	}
+	return nil
+}
+
+func (x *ListBidsResponse) GetTotalCount() int64 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+func (x *ListBidsResponse) GetPage() uint32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListBidsResponse) GetPageSize() uint32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type ListMyBidsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BidderUserId  string                 `protobuf:"bytes,1,opt,name=bidder_user_id,json=bidderUserId,proto3" json:"bidder_user_id,omitempty"`
+	Pagination    *v1.Pagination         `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMyBidsRequest) Reset() {
+	*x = ListMyBidsRequest{}
+	mi := &file_market_v1_market_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMyBidsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMyBidsRequest) ProtoMessage() {}
+
+func (x *ListMyBidsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_market_v1_market_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMyBidsRequest.ProtoReflect.Descriptor instead.
+func (*ListMyBidsRequest) Descriptor() ([]byte, []int) {
+	return file_market_v1_market_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ListMyBidsRequest) GetBidderUserId() string {
+	if x != nil {
+		return x.BidderUserId
+	}
+	return ""
+}
+
+func (x *ListMyBidsRequest) GetPagination() *v1.Pagination {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+type ListMyBidsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Bids          []*Bid                 `protobuf:"bytes,1,rep,name=bids,proto3" json:"bids,omitempty"`
+	TotalCount    int64                  `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	Page          uint32                 `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      uint32                 `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMyBidsResponse) Reset() {
+	*x = ListMyBidsResponse{}
+	mi := &file_market_v1_market_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMyBidsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMyBidsResponse) ProtoMessage() {}
+
+func (x *ListMyBidsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_market_v1_market_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMyBidsResponse.ProtoReflect.Descriptor instead.
+func (*ListMyBidsResponse) Descriptor() ([]byte, []int) {
+	return file_market_v1_market_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ListMyBidsResponse) GetBids() []*Bid {
+	if x != nil {
+		return x.Bids
+	}
+	return nil
+}
+
+func (x *ListMyBidsResponse) GetTotalCount() int64 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+func (x *ListMyBidsResponse) GetPage() uint32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListMyBidsResponse) GetPageSize() uint32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type Bid struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BidId         string                 `protobuf:"bytes,1,opt,name=bid_id,json=bidId,proto3" json:"bid_id,omitempty"`
+	ListingId     string                 `protobuf:"bytes,2,opt,name=listing_id,json=listingId,proto3" json:"listing_id,omitempty"`
+	BidderUserId  string                 `protobuf:"bytes,3,opt,name=bidder_user_id,json=bidderUserId,proto3" json:"bidder_user_id,omitempty"`
+	Amount        int64                  `protobuf:"varint,4,opt,name=amount,proto3" json:"amount,omitempty"`
+	CreatedAtUnix int64                  `protobuf:"varint,5,opt,name=created_at_unix,json=createdAtUnix,proto3" json:"created_at_unix,omitempty"`
+	Status        BidStatus              `protobuf:"varint,6,opt,name=status,proto3,enum=market.v1.BidStatus" json:"status,omitempty"`
+	// Rilancio automatico del proxy bidding.
+	Auto          bool `protobuf:"varint,7,opt,name=auto,proto3" json:"auto,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Bid) Reset() {
+	*x = Bid{}
+	mi := &file_market_v1_market_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Bid) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Bid) ProtoMessage() {}
+
+func (x *Bid) ProtoReflect() protoreflect.Message {
+	mi := &file_market_v1_market_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Bid.ProtoReflect.Descriptor instead.
+func (*Bid) Descriptor() ([]byte, []int) {
+	return file_market_v1_market_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *Bid) GetBidId() string {
+	if x != nil {
+		return x.BidId
+	}
+	return ""
+}
+
+func (x *Bid) GetListingId() string {
+	if x != nil {
+		return x.ListingId
+	}
+	return ""
+}
+
+func (x *Bid) GetBidderUserId() string {
+	if x != nil {
+		return x.BidderUserId
+	}
+	return ""
+}
+
+func (x *Bid) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *Bid) GetCreatedAtUnix() int64 {
+	if x != nil {
+		return x.CreatedAtUnix
+	}
+	return 0
+}
+
+func (x *Bid) GetStatus() BidStatus {
+	if x != nil {
+		return x.Status
+	}
+	return BidStatus_BID_STATUS_UNSPECIFIED
+}
+
+func (x *Bid) GetAuto() bool {
+	if x != nil {
+		return x.Auto
+	}
+	return false
+}
+
+type WatchListingRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ListingId     string                 `protobuf:"bytes,1,opt,name=listing_id,json=listingId,proto3" json:"listing_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchListingRequest) Reset() {
+	*x = WatchListingRequest{}
+	mi := &file_market_v1_market_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchListingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchListingRequest) ProtoMessage() {}
+
+func (x *WatchListingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_market_v1_market_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchListingRequest.ProtoReflect.Descriptor instead.
+func (*WatchListingRequest) Descriptor() ([]byte, []int) {
+	return file_market_v1_market_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *WatchListingRequest) GetListingId() string {
+	if x != nil {
+		return x.ListingId
+	}
+	return ""
+}
+
+type ListingEvent struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	ListingId string                 `protobuf:"bytes,1,opt,name=listing_id,json=listingId,proto3" json:"listing_id,omitempty"`
+	Type      ListingEventType       `protobuf:"varint,2,opt,name=type,proto3,enum=market.v1.ListingEventType" json:"type,omitempty"`
+	// Importo del bid o prezzo di vendita.
+	Amount int64 `protobuf:"varint,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	// Bidder (BID), bidder superato (OUTBID) o buyer (SOLD).
+	UserId         string `protobuf:"bytes,4,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ExpiresAtUnix  int64  `protobuf:"varint,5,opt,name=expires_at_unix,json=expiresAtUnix,proto3" json:"expires_at_unix,omitempty"`
+	OccurredAtUnix int64  `protobuf:"varint,6,opt,name=occurred_at_unix,json=occurredAtUnix,proto3" json:"occurred_at_unix,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ListingEvent) Reset() {
+	*x = ListingEvent{}
+	mi := &file_market_v1_market_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListingEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListingEvent) ProtoMessage() {}
+
+func (x *ListingEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_market_v1_market_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListingEvent.ProtoReflect.Descriptor instead.
+func (*ListingEvent) Descriptor() ([]byte, []int) {
+	return file_market_v1_market_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ListingEvent) GetListingId() string {
+	if x != nil {
+		return x.ListingId
+	}
+	return ""
+}
+
+func (x *ListingEvent) GetType() ListingEventType {
+	if x != nil {
+		return x.Type
+	}
+	return ListingEventType_LISTING_EVENT_TYPE_UNSPECIFIED
+}
+
+func (x *ListingEvent) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *ListingEvent) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ListingEvent) GetExpiresAtUnix() int64 {
+	if x != nil {
+		return x.ExpiresAtUnix
+	}
+	return 0
+}
+
+func (x *ListingEvent) GetOccurredAtUnix() int64 {
+	if x != nil {
+		return x.OccurredAtUnix
+	}
+	return 0
+}
+
+type GetPriceHistoryRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	PlayerId string                 `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	// Finestra di storico a ritroso da ora (default 7 giorni, massimo 90).
+	WindowSeconds int64 `protobuf:"varint,2,opt,name=window_seconds,json=windowSeconds,proto3" json:"window_seconds,omitempty"`
+	// Ampiezza dei bucket (default 1 giorno, minimo 60 secondi).
+	BucketSeconds int64 `protobuf:"varint,3,opt,name=bucket_seconds,json=bucketSeconds,proto3" json:"bucket_seconds,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPriceHistoryRequest) Reset() {
+	*x = GetPriceHistoryRequest{}
+	mi := &file_market_v1_market_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPriceHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPriceHistoryRequest) ProtoMessage() {}
+
+func (x *GetPriceHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_market_v1_market_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPriceHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetPriceHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_market_v1_market_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *GetPriceHistoryRequest) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+func (x *GetPriceHistoryRequest) GetWindowSeconds() int64 {
+	if x != nil {
+		return x.WindowSeconds
+	}
+	return 0
+}
+
+func (x *GetPriceHistoryRequest) GetBucketSeconds() int64 {
+	if x != nil {
+		return x.BucketSeconds
+	}
+	return 0
+}
+
+type GetPriceHistoryResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Solo bucket con almeno un trade, dal piu' vecchio.
+	Buckets       []*PriceBucket `protobuf:"bytes,1,rep,name=buckets,proto3" json:"buckets,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPriceHistoryResponse) Reset() {
+	*x = GetPriceHistoryResponse{}
+	mi := &file_market_v1_market_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPriceHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPriceHistoryResponse) ProtoMessage() {}
+
+func (x *GetPriceHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_market_v1_market_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPriceHistoryResponse.ProtoReflect.Descriptor instead.
+func (*GetPriceHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_market_v1_market_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *GetPriceHistoryResponse) GetBuckets() []*PriceBucket {
+	if x != nil {
+		return x.Buckets
+	}
+	return nil
+}
+
+// PriceBucket aggrega i trade completati in un intervallo [start_unix, start_unix + bucket_seconds).
+type PriceBucket struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	StartUnix   int64                  `protobuf:"varint,1,opt,name=start_unix,json=startUnix,proto3" json:"start_unix,omitempty"`
+	OpenPrice   int64                  `protobuf:"varint,2,opt,name=open_price,json=openPrice,proto3" json:"open_price,omitempty"`
+	ClosePrice  int64                  `protobuf:"varint,3,opt,name=close_price,json=closePrice,proto3" json:"close_price,omitempty"`
+	MinPrice    int64                  `protobuf:"varint,4,opt,name=min_price,json=minPrice,proto3" json:"min_price,omitempty"`
+	MedianPrice int64                  `protobuf:"varint,5,opt,name=median_price,json=medianPrice,proto3" json:"median_price,omitempty"`
+	MaxPrice    int64                  `protobuf:"varint,6,opt,name=max_price,json=maxPrice,proto3" json:"max_price,omitempty"`
+	// Numero di trade nel bucket.
+	Volume        int64 `protobuf:"varint,7,opt,name=volume,proto3" json:"volume,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PriceBucket) Reset() {
+	*x = PriceBucket{}
+	mi := &file_market_v1_market_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PriceBucket) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PriceBucket) ProtoMessage() {}
+
+func (x *PriceBucket) ProtoReflect() protoreflect.Message {
+	mi := &file_market_v1_market_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PriceBucket.ProtoReflect.Descriptor instead.
+func (*PriceBucket) Descriptor() ([]byte, []int) {
+	return file_market_v1_market_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *PriceBucket) GetStartUnix() int64 {
+	if x != nil {
+		return x.StartUnix
+	}
+	return 0
+}
+
+func (x *PriceBucket) GetOpenPrice() int64 {
+	if x != nil {
+		return x.OpenPrice
+	}
+	return 0
+}
+
+func (x *PriceBucket) GetClosePrice() int64 {
+	if x != nil {
+		return x.ClosePrice
+	}
+	return 0
+}
+
+func (x *PriceBucket) GetMinPrice() int64 {
+	if x != nil {
+		return x.MinPrice
+	}
+	return 0
+}
+
+func (x *PriceBucket) GetMedianPrice() int64 {
+	if x != nil {
+		return x.MedianPrice
+	}
+	return 0
+}
+
+func (x *PriceBucket) GetMaxPrice() int64 {
+	if x != nil {
+		return x.MaxPrice
+	}
+	return 0
+}
+
+func (x *PriceBucket) GetVolume() int64 {
+	if x != nil {
+		return x.Volume
+	}
+	return 0
+}
+
+type GetLowestBinRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PlayerId      string                 `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLowestBinRequest) Reset() {
+	*x = GetLowestBinRequest{}
+	mi := &file_market_v1_market_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLowestBinRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLowestBinRequest) ProtoMessage() {}
+
+func (x *GetLowestBinRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_market_v1_market_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLowestBinRequest.ProtoReflect.Descriptor instead.
+func (*GetLowestBinRequest) Descriptor() ([]byte, []int) {
+	return file_market_v1_market_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *GetLowestBinRequest) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+// GetLowestBinResponse riporta il listing ACTIVE con il buy_now_price piu' basso.
+type GetLowestBinResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Found         bool                   `protobuf:"varint,1,opt,name=found,proto3" json:"found,omitempty"`
+	ListingId     string                 `protobuf:"bytes,2,opt,name=listing_id,json=listingId,proto3" json:"listing_id,omitempty"`
+	BuyNowPrice   int64                  `protobuf:"varint,3,opt,name=buy_now_price,json=buyNowPrice,proto3" json:"buy_now_price,omitempty"`
+	ExpiresAtUnix int64                  `protobuf:"varint,4,opt,name=expires_at_unix,json=expiresAtUnix,proto3" json:"expires_at_unix,omitempty"`
+	// Listing ACTIVE del giocatore con buy_now_price.
+	ActiveCount   int64 `protobuf:"varint,5,opt,name=active_count,json=activeCount,proto3" json:"active_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLowestBinResponse) Reset() {
+	*x = GetLowestBinResponse{}
+	mi := &file_market_v1_market_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLowestBinResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLowestBinResponse) ProtoMessage() {}
+
+func (x *GetLowestBinResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_market_v1_market_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLowestBinResponse.ProtoReflect.Descriptor instead.
+func (*GetLowestBinResponse) Descriptor() ([]byte, []int) {
+	return file_market_v1_market_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *GetLowestBinResponse) GetFound() bool {
+	if x != nil {
+		return x.Found
+	}
+	return false
+}
+
+func (x *GetLowestBinResponse) GetListingId() string {
+	if x != nil {
+		return x.ListingId
+	}
+	return ""
+}
+
+func (x *GetLowestBinResponse) GetBuyNowPrice() int64 {
+	if x != nil {
+		return x.BuyNowPrice
+	}
+	return 0
+}
+
+func (x *GetLowestBinResponse) GetExpiresAtUnix() int64 {
+	if x != nil {
+		return x.ExpiresAtUnix
+	}
+	return 0
+}
+
+func (x *GetLowestBinResponse) GetActiveCount() int64 {
+	if x != nil {
+		return x.ActiveCount
+	}
+	return 0
+}
+
+type GetPlayerPriceBoundsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PlayerId      string                 `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPlayerPriceBoundsRequest) Reset() {
+	*x = GetPlayerPriceBoundsRequest{}
+	mi := &file_market_v1_market_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPlayerPriceBoundsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPlayerPriceBoundsRequest) ProtoMessage() {}
+
+func (x *GetPlayerPriceBoundsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_market_v1_market_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPlayerPriceBoundsRequest.ProtoReflect.Descriptor instead.
+func (*GetPlayerPriceBoundsRequest) Descriptor() ([]byte, []int) {
+	return file_market_v1_market_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *GetPlayerPriceBoundsRequest) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+type SetPlayerPriceBoundsRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	PlayerId string                 `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	// 0 = nessun limite su quel lato.
+	MinPrice      int64 `protobuf:"varint,2,opt,name=min_price,json=minPrice,proto3" json:"min_price,omitempty"`
+	MaxPrice      int64 `protobuf:"varint,3,opt,name=max_price,json=maxPrice,proto3" json:"max_price,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetPlayerPriceBoundsRequest) Reset() {
+	*x = SetPlayerPriceBoundsRequest{}
+	mi := &file_market_v1_market_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetPlayerPriceBoundsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetPlayerPriceBoundsRequest) ProtoMessage() {}
+
+func (x *SetPlayerPriceBoundsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_market_v1_market_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetPlayerPriceBoundsRequest.ProtoReflect.Descriptor instead.
+func (*SetPlayerPriceBoundsRequest) Descriptor() ([]byte, []int) {
+	return file_market_v1_market_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *SetPlayerPriceBoundsRequest) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+func (x *SetPlayerPriceBoundsRequest) GetMinPrice() int64 {
+	if x != nil {
+		return x.MinPrice
+	}
+	return 0
+}
+
+func (x *SetPlayerPriceBoundsRequest) GetMaxPrice() int64 {
+	if x != nil {
+		return x.MaxPrice
+	}
+	return 0
+}
+
+type ClearPlayerPriceBoundsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PlayerId      string                 `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ClearPlayerPriceBoundsRequest) Reset() {
+	*x = ClearPlayerPriceBoundsRequest{}
+	mi := &file_market_v1_market_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClearPlayerPriceBoundsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClearPlayerPriceBoundsRequest) ProtoMessage() {}
+
+func (x *ClearPlayerPriceBoundsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_market_v1_market_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClearPlayerPriceBoundsRequest.ProtoReflect.Descriptor instead.
+func (*ClearPlayerPriceBoundsRequest) Descriptor() ([]byte, []int) {
+	return file_market_v1_market_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *ClearPlayerPriceBoundsRequest) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+type ClearPlayerPriceBoundsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Cleared       bool                   `protobuf:"varint,1,opt,name=cleared,proto3" json:"cleared,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ClearPlayerPriceBoundsResponse) Reset() {
+	*x = ClearPlayerPriceBoundsResponse{}
+	mi := &file_market_v1_market_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClearPlayerPriceBoundsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClearPlayerPriceBoundsResponse) ProtoMessage() {}
+
+func (x *ClearPlayerPriceBoundsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_market_v1_market_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClearPlayerPriceBoundsResponse.ProtoReflect.Descriptor instead.
+func (*ClearPlayerPriceBoundsResponse) Descriptor() ([]byte, []int) {
+	return file_market_v1_market_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ClearPlayerPriceBoundsResponse) GetCleared() bool {
+	if x != nil {
+		return x.Cleared
+	}
+	return false
+}
+
+// PlayerPriceBounds e' il range di prezzo applicato ai listing del giocatore.
+type PlayerPriceBounds struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	PlayerId string                 `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	// 0 = nessun limite su quel lato.
+	MinPrice int64             `protobuf:"varint,2,opt,name=min_price,json=minPrice,proto3" json:"min_price,omitempty"`
+	MaxPrice int64             `protobuf:"varint,3,opt,name=max_price,json=maxPrice,proto3" json:"max_price,omitempty"`
+	Source   PriceBoundsSource `protobuf:"varint,4,opt,name=source,proto3,enum=market.v1.PriceBoundsSource" json:"source,omitempty"`
+	// Trade usati per derivare il range (solo per source TRADES).
+	SampleSize    int64 `protobuf:"varint,5,opt,name=sample_size,json=sampleSize,proto3" json:"sample_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PlayerPriceBounds) Reset() {
+	*x = PlayerPriceBounds{}
+	mi := &file_market_v1_market_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlayerPriceBounds) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlayerPriceBounds) ProtoMessage() {}
+
+func (x *PlayerPriceBounds) ProtoReflect() protoreflect.Message {
+	mi := &file_market_v1_market_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlayerPriceBounds.ProtoReflect.Descriptor instead.
+func (*PlayerPriceBounds) Descriptor() ([]byte, []int) {
+	return file_market_v1_market_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *PlayerPriceBounds) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+func (x *PlayerPriceBounds) GetMinPrice() int64 {
+	if x != nil {
+		return x.MinPrice
+	}
+	return 0
+}
+
+func (x *PlayerPriceBounds) GetMaxPrice() int64 {
+	if x != nil {
+		return x.MaxPrice
+	}
+	return 0
+}
+
+func (x *PlayerPriceBounds) GetSource() PriceBoundsSource {
+	if x != nil {
+		return x.Source
+	}
+	return PriceBoundsSource_PRICE_BOUNDS_SOURCE_UNSPECIFIED
+}
+
+func (x *PlayerPriceBounds) GetSampleSize() int64 {
+	if x != nil {
+		return x.SampleSize
+	}
+	return 0
+}
+
+// RelistListing rimette in vendita un listing scaduto senza vendita.
+// I campi a 0 riusano i valori del listing precedente.
+type RelistListingRequest struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	ListingId    string                 `protobuf:"bytes,1,opt,name=listing_id,json=listingId,proto3" json:"listing_id,omitempty"`
+	SellerUserId string                 `protobuf:"bytes,2,opt,name=seller_user_id,json=sellerUserId,proto3" json:"seller_user_id,omitempty"`
+	StartPrice   int64                  `protobuf:"varint,3,opt,name=start_price,json=startPrice,proto3" json:"start_price,omitempty"`
+	BuyNowPrice  int64                  `protobuf:"varint,4,opt,name=buy_now_price,json=buyNowPrice,proto3" json:"buy_now_price,omitempty"`
+	ReservePrice int64                  `protobuf:"varint,5,opt,name=reserve_price,json=reservePrice,proto3" json:"reserve_price,omitempty"`
+	// Durata del nuovo listing (0 = stessa durata del precedente).
+	DurationSeconds int64 `protobuf:"varint,6,opt,name=duration_seconds,json=durationSeconds,proto3" json:"duration_seconds,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *RelistListingRequest) Reset() {
+	*x = RelistListingRequest{}
+	mi := &file_market_v1_market_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RelistListingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RelistListingRequest) ProtoMessage() {}
+
+func (x *RelistListingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_market_v1_market_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RelistListingRequest.ProtoReflect.Descriptor instead.
+func (*RelistListingRequest) Descriptor() ([]byte, []int) {
+	return file_market_v1_market_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *RelistListingRequest) GetListingId() string {
+	if x != nil {
+		return x.ListingId
+	}
+	return ""
+}
+
+func (x *RelistListingRequest) GetSellerUserId() string {
+	if x != nil {
+		return x.SellerUserId
+	}
+	return ""
+}
+
+func (x *RelistListingRequest) GetStartPrice() int64 {
+	if x != nil {
+		return x.StartPrice
+	}
+	return 0
+}
+
+func (x *RelistListingRequest) GetBuyNowPrice() int64 {
+	if x != nil {
+		return x.BuyNowPrice
+	}
+	return 0
+}
+
+func (x *RelistListingRequest) GetReservePrice() int64 {
+	if x != nil {
+		return x.ReservePrice
+	}
+	return 0
+}
+
+func (x *RelistListingRequest) GetDurationSeconds() int64 {
+	if x != nil {
+		return x.DurationSeconds
+	}
+	return 0
+}
+
+type RelistListingResponse struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	ListingId         string                 `protobuf:"bytes,1,opt,name=listing_id,json=listingId,proto3" json:"listing_id,omitempty"`
+	PreviousListingId string                 `protobuf:"bytes,2,opt,name=previous_listing_id,json=previousListingId,proto3" json:"previous_listing_id,omitempty"`
+	ExpiresAtUnix     int64                  `protobuf:"varint,3,opt,name=expires_at_unix,json=expiresAtUnix,proto3" json:"expires_at_unix,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *RelistListingResponse) Reset() {
+	*x = RelistListingResponse{}
+	mi := &file_market_v1_market_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RelistListingResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RelistListingResponse) ProtoMessage() {}
+
+func (x *RelistListingResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_market_v1_market_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RelistListingResponse.ProtoReflect.Descriptor instead.
+func (*RelistListingResponse) Descriptor() ([]byte, []int) {
+	return file_market_v1_market_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *RelistListingResponse) GetListingId() string {
+	if x != nil {
+		return x.ListingId
+	}
+	return ""
+}
+
+func (x *RelistListingResponse) GetPreviousListingId() string {
+	if x != nil {
+		return x.PreviousListingId
+	}
+	return ""
+}
+
+func (x *RelistListingResponse) GetExpiresAtUnix() int64 {
+	if x != nil {
+		return x.ExpiresAtUnix
+	}
+	return 0
+}
+
+// RelistAllExpired rimette in vendita ai prezzi precedenti i listing scaduti del club.
+type RelistAllExpiredRequest struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	SellerUserId string                 `protobuf:"bytes,1,opt,name=seller_user_id,json=sellerUserId,proto3" json:"seller_user_id,omitempty"`
+	// Durata dei nuovi listing (0 = stessa durata di ciascun precedente).
+	DurationSeconds int64 `protobuf:"varint,2,opt,name=duration_seconds,json=durationSeconds,proto3" json:"duration_seconds,omitempty"`
+	// Massimo di listing processati (default 50, massimo 100).
+	Limit         int32 `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RelistAllExpiredRequest) Reset() {
+	*x = RelistAllExpiredRequest{}
+	mi := &file_market_v1_market_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RelistAllExpiredRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RelistAllExpiredRequest) ProtoMessage() {}
+
+func (x *RelistAllExpiredRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_market_v1_market_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RelistAllExpiredRequest.ProtoReflect.Descriptor instead.
+func (*RelistAllExpiredRequest) Descriptor() ([]byte, []int) {
+	return file_market_v1_market_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *RelistAllExpiredRequest) GetSellerUserId() string {
+	if x != nil {
+		return x.SellerUserId
+	}
+	return ""
+}
+
+func (x *RelistAllExpiredRequest) GetDurationSeconds() int64 {
+	if x != nil {
+		return x.DurationSeconds
+	}
+	return 0
+}
+
+func (x *RelistAllExpiredRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type RelistAllExpiredResponse struct {
+	state         protoimpl.MessageState   `protogen:"open.v1"`
+	Relisted      []*RelistListingResponse `protobuf:"bytes,1,rep,name=relisted,proto3" json:"relisted,omitempty"`
+	Failed        []*RelistFailure         `protobuf:"bytes,2,rep,name=failed,proto3" json:"failed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RelistAllExpiredResponse) Reset() {
+	*x = RelistAllExpiredResponse{}
+	mi := &file_market_v1_market_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RelistAllExpiredResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RelistAllExpiredResponse) ProtoMessage() {}
+
+func (x *RelistAllExpiredResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_market_v1_market_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RelistAllExpiredResponse.ProtoReflect.Descriptor instead.
+func (*RelistAllExpiredResponse) Descriptor() ([]byte, []int) {
+	return file_market_v1_market_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *RelistAllExpiredResponse) GetRelisted() []*RelistListingResponse {
+	if x != nil {
+		return x.Relisted
+	}
+	return nil
+}
+
+func (x *RelistAllExpiredResponse) GetFailed() []*RelistFailure {
+	if x != nil {
+		return x.Failed
+	}
+	return nil
+}
+
+type RelistFailure struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	PreviousListingId string                 `protobuf:"bytes,1,opt,name=previous_listing_id,json=previousListingId,proto3" json:"previous_listing_id,omitempty"`
+	Reason            string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *RelistFailure) Reset() {
+	*x = RelistFailure{}
+	mi := &file_market_v1_market_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RelistFailure) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RelistFailure) ProtoMessage() {}
+
+func (x *RelistFailure) ProtoReflect() protoreflect.Message {
+	mi := &file_market_v1_market_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RelistFailure.ProtoReflect.Descriptor instead.
+func (*RelistFailure) Descriptor() ([]byte, []int) {
+	return file_market_v1_market_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *RelistFailure) GetPreviousListingId() string {
+	if x != nil {
+		return x.PreviousListingId
+	}
+	return ""
+}
+
+func (x *RelistFailure) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+var File_market_v1_market_proto protoreflect.FileDescriptor
+
+const file_market_v1_market_proto_rawDesc = "" +
+	"\n" +
+	"\x16market/v1/market.proto\x12\tmarket.v1\x1a\x16common/v1/common.proto\"\xa1\x02\n" +
+	"\x14CreateListingRequest\x12$\n" +
+	"\x0eseller_user_id\x18\x01 \x01(\tR\fsellerUserId\x12 \n" +
+	"\fuser_card_id\x18\x02 \x01(\tR\n" +
+	"userCardId\x12\x1f\n" +
+	"\vstart_price\x18\x03 \x01(\x03R\n" +
+	"startPrice\x12\"\n" +
+	"\rbuy_now_price\x18\x04 \x01(\x03R\vbuyNowPrice\x12&\n" +
+	"\x0fexpires_at_unix\x18\x05 \x01(\x03R\rexpiresAtUnix\x12#\n" +
+	"\rreserve_price\x18\x06 \x01(\x03R\freservePrice\x12/\n" +
+	"\x14bundle_user_card_ids\x18\a \x03(\tR\x11bundleUserCardIds\"6\n" +
+	"\x15CreateListingResponse\x12\x1d\n" +
+	"\n" +
+	"listing_id\x18\x01 \x01(\tR\tlistingId\"\x8e\x01\n" +
+	"\x0fPlaceBidRequest\x12\x1d\n" +
+	"\n" +
+	"listing_id\x18\x01 \x01(\tR\tlistingId\x12$\n" +
+	"\x0ebidder_user_id\x18\x02 \x01(\tR\fbidderUserId\x12\x1d\n" +
+	"\n" +
+	"bid_amount\x18\x03 \x01(\x03R\tbidAmount\x12\x17\n" +
+	"\amax_bid\x18\x04 \x01(\x03R\x06maxBid\"v\n" +
+	"\x10PlaceBidResponse\x12\x19\n" +
+	"\bbest_bid\x18\x01 \x01(\x03R\abestBid\x12-\n" +
+	"\x13best_bidder_user_id\x18\x02 \x01(\tR\x10bestBidderUserId\x12\x18\n" +
+	"\awinning\x18\x03 \x01(\bR\awinning\"R\n" +
+	"\rBuyNowRequest\x12\x1d\n" +
+	"\n" +
+	"listing_id\x18\x01 \x01(\tR\tlistingId\x12\"\n" +
+	"\rbuyer_user_id\x18\x02 \x01(\tR\vbuyerUserId\".\n" +
+	"\x0eBuyNowResponse\x12\x1c\n" +
+	"\tpurchased\x18\x01 \x01(\bR\tpurchased\"H\n" +
+	"\x11GetListingRequest\x12\x1d\n" +
+	"\n" +
+	"listing_id\x18\x01 \x01(\tR\tlistingIdJ\x04\b\x02\x10\x03R\x0eviewer_user_id\"\xb2\x04\n" +
+	"\x12GetListingResponse\x12\x1d\n" +
+	"\n" +
+	"listing_id\x18\x01 \x01(\tR\tlistingId\x12$\n" +
+	"\x0eseller_user_id\x18\x02 \x01(\tR\fsellerUserId\x12 \n" +
+	"\fuser_card_id\x18\x03 \x01(\tR\n" +
+	"userCardId\x12\x1f\n" +
+	"\vstart_price\x18\x04 \x01(\x03R\n" +
+	"startPrice\x12\"\n" +
+	"\rbuy_now_price\x18\x05 \x01(\x03R\vbuyNowPrice\x12\x19\n" +
+	"\bbest_bid\x18\x06 \x01(\x03R\abestBid\x12-\n" +
+	"\x13best_bidder_user_id\x18\a \x01(\tR\x10bestBidderUserId\x12&\n" +
+	"\x0fexpires_at_unix\x18\b \x01(\x03R\rexpiresAtUnix\x120\n" +
+	"\x06status\x18\t \x01(\x0e2\x18.market.v1.ListingStatusR\x06status\x12'\n" +
+	"\x0fextension_count\x18\n" +
+	" \x01(\x05R\x0eextensionCount\x12#\n" +
+	"\rreserve_price\x18\v \x01(\x03R\freservePrice\x12.\n" +
+	"\x13previous_listing_id\x18\f \x01(\tR\x11previousListingId\x12*\n" +
+	"\x04kind\x18\r \x01(\x0e2\x16.market.v1.ListingKindR\x04kind\x12\"\n" +
+	"\ruser_card_ids\x18\x0e \x03(\tR\vuserCardIds\"[\n" +
+	"\x14CancelListingRequest\x12\x1d\n" +
+	"\n" +
+	"listing_id\x18\x01 \x01(\tR\tlistingId\x12$\n" +
+	"\x0eseller_user_id\x18\x02 \x01(\tR\fsellerUserId\"O\n" +
+	"\x15CancelListingResponse\x12\x1c\n" +
+	"\tcancelled\x18\x01 \x01(\bR\tcancelled\x12\x18\n" +
+	"\apenalty\x18\x02 \x01(\x03R\apenalty\"\x8e\x03\n" +
+	"\x15SearchListingsRequest\x120\n" +
+	"\x06status\x18\x01 \x01(\x0e2\x18.market.v1.ListingStatusR\x06status\x126\n" +
+	"\vprice_field\x18\x02 \x01(\x0e2\x15.market.v1.PriceFieldR\n" +
+	"priceField\x12\x1b\n" +
+	"\tmin_price\x18\x03 \x01(\x03R\bminPrice\x12\x1b\n" +
+	"\tmax_price\x18\x04 \x01(\x03R\bmaxPrice\x12$\n" +
+	"\x0eseller_user_id\x18\x05 \x01(\tR\fsellerUserId\x12\x1b\n" +
+	"\tplayer_id\x18\x06 \x01(\tR\bplayerId\x122\n" +
+	"\x15ending_within_seconds\x18\a \x01(\x03R\x13endingWithinSeconds\x125\n" +
+	"\n" +
+	"pagination\x18\b \x01(\v2\x15.common.v1.PaginationR\n" +
+	"pagination\x12#\n" +
+	"\x04sort\x18\t \x01(\v2\x0f.common.v1.SortR\x04sort\"\x9a\x01\n" +
+	"\x16SearchListingsResponse\x12.\n" +
+	"\blistings\x18\x01 \x03(\v2\x12.market.v1.ListingR\blistings\x12\x1f\n" +
+	"\vtotal_count\x18\x02 \x01(\x03R\n" +
+	"totalCount\x12\x12\n" +
+	"\x04page\x18\x03 \x01(\rR\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x04 \x01(\rR\bpageSize\"\xf3\x03\n" +
+	"\aListing\x12\x1d\n" +
+	"\n" +
+	"listing_id\x18\x01 \x01(\tR\tlistingId\x12$\n" +
+	"\x0eseller_user_id\x18\x02 \x01(\tR\fsellerUserId\x12 \n" +
+	"\fuser_card_id\x18\x03 \x01(\tR\n" +
+	"userCardId\x12\x1b\n" +
+	"\tplayer_id\x18\x04 \x01(\tR\bplayerId\x12\x1f\n" +
+	"\vstart_price\x18\x05 \x01(\x03R\n" +
+	"startPrice\x12\"\n" +
+	"\rbuy_now_price\x18\x06 \x01(\x03R\vbuyNowPrice\x12\x19\n" +
+	"\bbest_bid\x18\a \x01(\x03R\abestBid\x12-\n" +
+	"\x13best_bidder_user_id\x18\b \x01(\tR\x10bestBidderUserId\x12&\n" +
+	"\x0fexpires_at_unix\x18\t \x01(\x03R\rexpiresAtUnix\x12&\n" +
+	"\x0fcreated_at_unix\x18\n" +
+	" \x01(\x03R\rcreatedAtUnix\x120\n" +
+	"\x06status\x18\v \x01(\x0e2\x18.market.v1.ListingStatusR\x06status\x12'\n" +
+	"\x0fextension_count\x18\f \x01(\x05R\x0eextensionCount\x12*\n" +
+	"\x04kind\x18\r \x01(\x0e2\x16.market.v1.ListingKindR\x04kind\"g\n" +
+	"\x0fListBidsRequest\x12\x1d\n" +
+	"\n" +
+	"listing_id\x18\x01 \x01(\tR\tlistingId\x125\n" +
+	"\n" +
+	"pagination\x18\x02 \x01(\v2\x15.common.v1.PaginationR\n" +
+	"pagination\"\x88\x01\n" +
+	"\x10ListBidsResponse\x12\"\n" +
+	"\x04bids\x18\x01 \x03(\v2\x0e.market.v1.BidR\x04bids\x12\x1f\n" +
+	"\vtotal_count\x18\x02 \x01(\x03R\n" +
+	"totalCount\x12\x12\n" +
+	"\x04page\x18\x03 \x01(\rR\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x04 \x01(\rR\bpageSize\"p\n" +
+	"\x11ListMyBidsRequest\x12$\n" +
+	"\x0ebidder_user_id\x18\x01 \x01(\tR\fbidderUserId\x125\n" +
+	"\n" +
+	"pagination\x18\x02 \x01(\v2\x15.common.v1.PaginationR\n" +
+	"pagination\"\x8a\x01\n" +
+	"\x12ListMyBidsResponse\x12\"\n" +
+	"\x04bids\x18\x01 \x03(\v2\x0e.market.v1.BidR\x04bids\x12\x1f\n" +
+	"\vtotal_count\x18\x02 \x01(\x03R\n" +
+	"totalCount\x12\x12\n" +
+	"\x04page\x18\x03 \x01(\rR\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x04 \x01(\rR\bpageSize\"\xe3\x01\n" +
+	"\x03Bid\x12\x15\n" +
+	"\x06bid_id\x18\x01 \x01(\tR\x05bidId\x12\x1d\n" +
+	"\n" +
+	"listing_id\x18\x02 \x01(\tR\tlistingId\x12$\n" +
+	"\x0ebidder_user_id\x18\x03 \x01(\tR\fbidderUserId\x12\x16\n" +
+	"\x06amount\x18\x04 \x01(\x03R\x06amount\x12&\n" +
+	"\x0fcreated_at_unix\x18\x05 \x01(\x03R\rcreatedAtUnix\x12,\n" +
+	"\x06status\x18\x06 \x01(\x0e2\x14.market.v1.BidStatusR\x06status\x12\x12\n" +
+	"\x04auto\x18\a \x01(\bR\x04auto\"4\n" +
+	"\x13WatchListingRequest\x12\x1d\n" +
+	"\n" +
+	"listing_id\x18\x01 \x01(\tR\tlistingId\"\xe1\x01\n" +
+	"\fListingEvent\x12\x1d\n" +
+	"\n" +
+	"listing_id\x18\x01 \x01(\tR\tlistingId\x12/\n" +
+	"\x04type\x18\x02 \x01(\x0e2\x1b.market.v1.ListingEventTypeR\x04type\x12\x16\n" +
+	"\x06amount\x18\x03 \x01(\x03R\x06amount\x12\x17\n" +
+	"\auser_id\x18\x04 \x01(\tR\x06userId\x12&\n" +
+	"\x0fexpires_at_unix\x18\x05 \x01(\x03R\rexpiresAtUnix\x12(\n" +
+	"\x10occurred_at_unix\x18\x06 \x01(\x03R\x0eoccurredAtUnix\"\x83\x01\n" +
+	"\x16GetPriceHistoryRequest\x12\x1b\n" +
+	"\tplayer_id\x18\x01 \x01(\tR\bplayerId\x12%\n" +
+	"\x0ewindow_seconds\x18\x02 \x01(\x03R\rwindowSeconds\x12%\n" +
+	"\x0ebucket_seconds\x18\x03 \x01(\x03R\rbucketSeconds\"K\n" +
+	"\x17GetPriceHistoryResponse\x120\n" +
+	"\abuckets\x18\x01 \x03(\v2\x16.market.v1.PriceBucketR\abuckets\"\xe1\x01\n" +
+	"\vPriceBucket\x12\x1d\n" +
+	"\n" +
+	"start_unix\x18\x01 \x01(\x03R\tstartUnix\x12\x1d\n" +
+	"\n" +
+	"open_price\x18\x02 \x01(\x03R\topenPrice\x12\x1f\n" +
+	"\vclose_price\x18\x03 \x01(\x03R\n" +
+	"closePrice\x12\x1b\n" +
+	"\tmin_price\x18\x04 \x01(\x03R\bminPrice\x12!\n" +
+	"\fmedian_price\x18\x05 \x01(\x03R\vmedianPrice\x12\x1b\n" +
+	"\tmax_price\x18\x06 \x01(\x03R\bmaxPrice\x12\x16\n" +
+	"\x06volume\x18\a \x01(\x03R\x06volume\"2\n" +
+	"\x13GetLowestBinRequest\x12\x1b\n" +
+	"\tplayer_id\x18\x01 \x01(\tR\bplayerId\"\xba\x01\n" +
+	"\x14GetLowestBinResponse\x12\x14\n" +
+	"\x05found\x18\x01 \x01(\bR\x05found\x12\x1d\n" +
+	"\n" +
+	"listing_id\x18\x02 \x01(\tR\tlistingId\x12\"\n" +
+	"\rbuy_now_price\x18\x03 \x01(\x03R\vbuyNowPrice\x12&\n" +
+	"\x0fexpires_at_unix\x18\x04 \x01(\x03R\rexpiresAtUnix\x12!\n" +
+	"\factive_count\x18\x05 \x01(\x03R\vactiveCount\":\n" +
+	"\x1bGetPlayerPriceBoundsRequest\x12\x1b\n" +
+	"\tplayer_id\x18\x01 \x01(\tR\bplayerId\"t\n" +
+	"\x1bSetPlayerPriceBoundsRequest\x12\x1b\n" +
+	"\tplayer_id\x18\x01 \x01(\tR\bplayerId\x12\x1b\n" +
+	"\tmin_price\x18\x02 \x01(\x03R\bminPrice\x12\x1b\n" +
+	"\tmax_price\x18\x03 \x01(\x03R\bmaxPrice\"<\n" +
+	"\x1dClearPlayerPriceBoundsRequest\x12\x1b\n" +
+	"\tplayer_id\x18\x01 \x01(\tR\bplayerId\":\n" +
+	"\x1eClearPlayerPriceBoundsResponse\x12\x18\n" +
+	"\acleared\x18\x01 \x01(\bR\acleared\"\xc1\x01\n" +
+	"\x11PlayerPriceBounds\x12\x1b\n" +
+	"\tplayer_id\x18\x01 \x01(\tR\bplayerId\x12\x1b\n" +
+	"\tmin_price\x18\x02 \x01(\x03R\bminPrice\x12\x1b\n" +
+	"\tmax_price\x18\x03 \x01(\x03R\bmaxPrice\x124\n" +
+	"\x06source\x18\x04 \x01(\x0e2\x1c.market.v1.PriceBoundsSourceR\x06source\x12\x1f\n" +
+	"\vsample_size\x18\x05 \x01(\x03R\n" +
+	"sampleSize\"\xf0\x01\n" +
+	"\x14RelistListingRequest\x12\x1d\n" +
+	"\n" +
+	"listing_id\x18\x01 \x01(\tR\tlistingId\x12$\n" +
+	"\x0eseller_user_id\x18\x02 \x01(\tR\fsellerUserId\x12\x1f\n" +
+	"\vstart_price\x18\x03 \x01(\x03R\n" +
+	"startPrice\x12\"\n" +
+	"\rbuy_now_price\x18\x04 \x01(\x03R\vbuyNowPrice\x12#\n" +
+	"\rreserve_price\x18\x05 \x01(\x03R\freservePrice\x12)\n" +
+	"\x10duration_seconds\x18\x06 \x01(\x03R\x0fdurationSeconds\"\x8e\x01\n" +
+	"\x15RelistListingResponse\x12\x1d\n" +
+	"\n" +
+	"listing_id\x18\x01 \x01(\tR\tlistingId\x12.\n" +
+	"\x13previous_listing_id\x18\x02 \x01(\tR\x11previousListingId\x12&\n" +
+	"\x0fexpires_at_unix\x18\x03 \x01(\x03R\rexpiresAtUnix\"\x80\x01\n" +
+	"\x17RelistAllExpiredRequest\x12$\n" +
+	"\x0eseller_user_id\x18\x01 \x01(\tR\fsellerUserId\x12)\n" +
+	"\x10duration_seconds\x18\x02 \x01(\x03R\x0fdurationSeconds\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\"\x8a\x01\n" +
+	"\x18RelistAllExpiredResponse\x12<\n" +
+	"\brelisted\x18\x01 \x03(\v2 .market.v1.RelistListingResponseR\brelisted\x120\n" +
+	"\x06failed\x18\x02 \x03(\v2\x18.market.v1.RelistFailureR\x06failed\"W\n" +
+	"\rRelistFailure\x12.\n" +
+	"\x13previous_listing_id\x18\x01 \x01(\tR\x11previousListingId\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason*\x7f\n" +
+	"\tBidStatus\x12\x1a\n" +
+	"\x16BID_STATUS_UNSPECIFIED\x10\x00\x12\x16\n" +
+	"\x12BID_STATUS_WINNING\x10\x01\x12\x15\n" +
+	"\x11BID_STATUS_OUTBID\x10\x02\x12\x13\n" +
+	"\x0fBID_STATUS_LOST\x10\x03\x12\x12\n" +
+	"\x0eBID_STATUS_WON\x10\x04*\xf1\x01\n" +
+	"\x10ListingEventType\x12\"\n" +
+	"\x1eLISTING_EVENT_TYPE_UNSPECIFIED\x10\x00\x12\x1a\n" +
+	"\x16LISTING_EVENT_TYPE_BID\x10\x01\x12\x1d\n" +
+	"\x19LISTING_EVENT_TYPE_OUTBID\x10\x02\x12\x1b\n" +
+	"\x17LISTING_EVENT_TYPE_SOLD\x10\x03\x12\x1e\n" +
+	"\x1aLISTING_EVENT_TYPE_EXPIRED\x10\x04\x12 \n" +
+	"\x1cLISTING_EVENT_TYPE_CANCELLED\x10\x05\x12\x1f\n" +
+	"\x1bLISTING_EVENT_TYPE_EXTENDED\x10\x06*\x95\x01\n" +
+	"\x11PriceBoundsSource\x12#\n" +
+	"\x1fPRICE_BOUNDS_SOURCE_UNSPECIFIED\x10\x00\x12\x1c\n" +
+	"\x18PRICE_BOUNDS_SOURCE_NONE\x10\x01\x12\x1d\n" +
+	"\x19PRICE_BOUNDS_SOURCE_ADMIN\x10\x02\x12\x1e\n" +
+	"\x1aPRICE_BOUNDS_SOURCE_TRADES\x10\x03*s\n" +
+	"\n" +
+	"PriceField\x12\x1b\n" +
+	"\x17PRICE_FIELD_UNSPECIFIED\x10\x00\x12\x15\n" +
+	"\x11PRICE_FIELD_START\x10\x01\x12\x17\n" +
+	"\x13PRICE_FIELD_BUY_NOW\x10\x02\x12\x18\n" +
+	"\x14PRICE_FIELD_BEST_BID\x10\x03*]\n" +
+	"\vListingKind\x12\x1c\n" +
+	"\x18LISTING_KIND_UNSPECIFIED\x10\x00\x12\x17\n" +
+	"\x13LISTING_KIND_SINGLE\x10\x01\x12\x17\n" +
+	"\x13LISTING_KIND_BUNDLE\x10\x02*\x9d\x01\n" +
+	"\rListingStatus\x12\x1e\n" +
+	"\x1aLISTING_STATUS_UNSPECIFIED\x10\x00\x12\x19\n" +
+	"\x15LISTING_STATUS_ACTIVE\x10\x01\x12\x1a\n" +
+	"\x16LISTING_STATUS_EXPIRED\x10\x02\x12\x17\n" +
+	"\x13LISTING_STATUS_SOLD\x10\x03\x12\x1c\n" +
+	"\x18LISTING_STATUS_CANCELLED\x10\x042\xbf\n" +
+	"\n" +
+	"\rMarketService\x12R\n" +
+	"\rCreateListing\x12\x1f.market.v1.CreateListingRequest\x1a .market.v1.CreateListingResponse\x12C\n" +
+	"\bPlaceBid\x12\x1a.market.v1.PlaceBidRequest\x1a\x1b.market.v1.PlaceBidResponse\x12=\n" +
+	"\x06BuyNow\x12\x18.market.v1.BuyNowRequest\x1a\x19.market.v1.BuyNowResponse\x12I\n" +
+	"\n" +
+	"GetListing\x12\x1c.market.v1.GetListingRequest\x1a\x1d.market.v1.GetListingResponse\x12R\n" +
+	"\rCancelListing\x12\x1f.market.v1.CancelListingRequest\x1a .market.v1.CancelListingResponse\x12R\n" +
+	"\rRelistListing\x12\x1f.market.v1.RelistListingRequest\x1a .market.v1.RelistListingResponse\x12[\n" +
+	"\x10RelistAllExpired\x12\".market.v1.RelistAllExpiredRequest\x1a#.market.v1.RelistAllExpiredResponse\x12U\n" +
+	"\x0eSearchListings\x12 .market.v1.SearchListingsRequest\x1a!.market.v1.SearchListingsResponse\x12C\n" +
+	"\bListBids\x12\x1a.market.v1.ListBidsRequest\x1a\x1b.market.v1.ListBidsResponse\x12I\n" +
 	"\n" +
-	"GetListing\x12\x1c.market.v1.GetListingRequest\x1a\x1d.market.v1.GetListingResponseB\x89\x01\n" +
+	"ListMyBids\x12\x1c.market.v1.ListMyBidsRequest\x1a\x1d.market.v1.ListMyBidsResponse\x12I\n" +
+	"\fWatchListing\x12\x1e.market.v1.WatchListingRequest\x1a\x17.market.v1.ListingEvent0\x01\x12X\n" +
+	"\x0fGetPriceHistory\x12!.market.v1.GetPriceHistoryRequest\x1a\".market.v1.GetPriceHistoryResponse\x12O\n" +
+	"\fGetLowestBin\x12\x1e.market.v1.GetLowestBinRequest\x1a\x1f.market.v1.GetLowestBinResponse\x12\\\n" +
+	"\x14GetPlayerPriceBounds\x12&.market.v1.GetPlayerPriceBoundsRequest\x1a\x1c.market.v1.PlayerPriceBounds\x12\\\n" +
+	"\x14SetPlayerPriceBounds\x12&.market.v1.SetPlayerPriceBoundsRequest\x1a\x1c.market.v1.PlayerPriceBounds\x12m\n" +
+	"\x16ClearPlayerPriceBounds\x12(.market.v1.ClearPlayerPriceBoundsRequest\x1a).market.v1.ClearPlayerPriceBoundsResponseB\x89\x01\n" +
 	"\rcom.market.v1B\vMarketProtoP\x01Z&UltimateTeamX/proto/market/v1;marketv1\xa2\x02\x03MXX\xaa\x02\tMarket.V1\xca\x02\tMarket\\V1\xe2\x02\x15Market\\V1\\GPBMetadata\xea\x02\n" +
 	"Market::V1b\x06proto3"
 
@@ -630,34 +2993,110 @@ func file_market_v1_market_proto_rawDescGZIP() []byte {
 	return file_market_v1_market_proto_rawDescData
 }
 
-var file_market_v1_market_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_market_v1_market_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_market_v1_market_proto_enumTypes = make([]protoimpl.EnumInfo, 6)
+var file_market_v1_market_proto_msgTypes = make([]protoimpl.MessageInfo, 35)
 var file_market_v1_market_proto_goTypes = []any{
-	(ListingStatus)(0),            // 0: market.v1.ListingStatus
-	(*CreateListingRequest)(nil),  // 1: market.v1.CreateListingRequest
-	(*CreateListingResponse)(nil), // 2: market.v1.CreateListingResponse
-	(*PlaceBidRequest)(nil),       // 3: market.v1.PlaceBidRequest
-	(*PlaceBidResponse)(nil),      // 4: market.v1.PlaceBidResponse
-	(*BuyNowRequest)(nil),         // 5: market.v1.BuyNowRequest
-	(*BuyNowResponse)(nil),        // 6: market.v1.BuyNowResponse
-	(*GetListingRequest)(nil),     // 7: market.v1.GetListingRequest
-	(*GetListingResponse)(nil),    // 8: market.v1.GetListingResponse
+	(BidStatus)(0),                         // 0: market.v1.BidStatus
+	(ListingEventType)(0),                  // 1: market.v1.ListingEventType
+	(PriceBoundsSource)(0),                 // 2: market.v1.PriceBoundsSource
+	(PriceField)(0),                        // 3: market.v1.PriceField
+	(ListingKind)(0),                       // 4: market.v1.ListingKind
+	(ListingStatus)(0),                     // 5: market.v1.ListingStatus
+	(*CreateListingRequest)(nil),           // 6: market.v1.CreateListingRequest
+	(*CreateListingResponse)(nil),          // 7: market.v1.CreateListingResponse
+	(*PlaceBidRequest)(nil),                // 8: market.v1.PlaceBidRequest
+	(*PlaceBidResponse)(nil),               // 9: market.v1.PlaceBidResponse
+	(*BuyNowRequest)(nil),                  // 10: market.v1.BuyNowRequest
+	(*BuyNowResponse)(nil),                 // 11: market.v1.BuyNowResponse
+	(*GetListingRequest)(nil),              // 12: market.v1.GetListingRequest
+	(*GetListingResponse)(nil),             // 13: market.v1.GetListingResponse
+	(*CancelListingRequest)(nil),           // 14: market.v1.CancelListingRequest
+	(*CancelListingResponse)(nil),          // 15: market.v1.CancelListingResponse
+	(*SearchListingsRequest)(nil),          // 16: market.v1.SearchListingsRequest
+	(*SearchListingsResponse)(nil),         // 17: market.v1.SearchListingsResponse
+	(*Listing)(nil),                        // 18: market.v1.Listing
+	(*ListBidsRequest)(nil),                // 19: market.v1.ListBidsRequest
+	(*ListBidsResponse)(nil),               // 20: market.v1.ListBidsResponse
+	(*ListMyBidsRequest)(nil),              // 21: market.v1.ListMyBidsRequest
+	(*ListMyBidsResponse)(nil),             // 22: market.v1.ListMyBidsResponse
+	(*Bid)(nil),                            // 23: market.v1.Bid
+	(*WatchListingRequest)(nil),            // 24: market.v1.WatchListingRequest
+	(*ListingEvent)(nil),                   // 25: market.v1.ListingEvent
+	(*GetPriceHistoryRequest)(nil),         // 26: market.v1.GetPriceHistoryRequest
+	(*GetPriceHistoryResponse)(nil),        // 27: market.v1.GetPriceHistoryResponse
+	(*PriceBucket)(nil),                    // 28: market.v1.PriceBucket
+	(*GetLowestBinRequest)(nil),            // 29: market.v1.GetLowestBinRequest
+	(*GetLowestBinResponse)(nil),           // 30: market.v1.GetLowestBinResponse
+	(*GetPlayerPriceBoundsRequest)(nil),    // 31: market.v1.GetPlayerPriceBoundsRequest
+	(*SetPlayerPriceBoundsRequest)(nil),    // 32: market.v1.SetPlayerPriceBoundsRequest
+	(*ClearPlayerPriceBoundsRequest)(nil),  // 33: market.v1.ClearPlayerPriceBoundsRequest
+	(*ClearPlayerPriceBoundsResponse)(nil), // 34: market.v1.ClearPlayerPriceBoundsResponse
+	(*PlayerPriceBounds)(nil),              // 35: market.v1.PlayerPriceBounds
+	(*RelistListingRequest)(nil),           // 36: market.v1.RelistListingRequest
+	(*RelistListingResponse)(nil),          // 37: market.v1.RelistListingResponse
+	(*RelistAllExpiredRequest)(nil),        // 38: market.v1.RelistAllExpiredRequest
+	(*RelistAllExpiredResponse)(nil),       // 39: market.v1.RelistAllExpiredResponse
+	(*RelistFailure)(nil),                  // 40: market.v1.RelistFailure
+	(*v1.Pagination)(nil),                  // 41: common.v1.Pagination
+	(*v1.Sort)(nil),                        // 42: common.v1.Sort
 }
 var file_market_v1_market_proto_depIdxs = []int32{
-	0, // 0: market.v1.GetListingResponse.status:type_name -> market.v1.ListingStatus
-	1, // 1: market.v1.MarketService.CreateListing:input_type -> market.v1.CreateListingRequest
-	3, // 2: market.v1.MarketService.PlaceBid:input_type -> market.v1.PlaceBidRequest
-	5, // 3: market.v1.MarketService.BuyNow:input_type -> market.v1.BuyNowRequest
-	7, // 4: market.v1.MarketService.GetListing:input_type -> market.v1.GetListingRequest
-	2, // 5: market.v1.MarketService.CreateListing:output_type -> market.v1.CreateListingResponse
-	4, // 6: market.v1.MarketService.PlaceBid:output_type -> market.v1.PlaceBidResponse
-	6, // 7: market.v1.MarketService.BuyNow:output_type -> market.v1.BuyNowResponse
-	8, // 8: market.v1.MarketService.GetListing:output_type -> market.v1.GetListingResponse
-	5, // [5:9] is the sub-list for method output_type
-	1, // [1:5] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	5,  // 0: market.v1.GetListingResponse.status:type_name -> market.v1.ListingStatus
+	4,  // 1: market.v1.GetListingResponse.kind:type_name -> market.v1.ListingKind
+	5,  // 2: market.v1.SearchListingsRequest.status:type_name -> market.v1.ListingStatus
+	3,  // 3: market.v1.SearchListingsRequest.price_field:type_name -> market.v1.PriceField
+	41, // 4: market.v1.SearchListingsRequest.pagination:type_name -> common.v1.Pagination
+	42, // 5: market.v1.SearchListingsRequest.sort:type_name -> common.v1.Sort
+	18, // 6: market.v1.SearchListingsResponse.listings:type_name -> market.v1.Listing
+	5,  // 7: market.v1.Listing.status:type_name -> market.v1.ListingStatus
+	4,  // 8: market.v1.Listing.kind:type_name -> market.v1.ListingKind
+	41, // 9: market.v1.ListBidsRequest.pagination:type_name -> common.v1.Pagination
+	23, // 10: market.v1.ListBidsResponse.bids:type_name -> market.v1.Bid
+	41, // 11: market.v1.ListMyBidsRequest.pagination:type_name -> common.v1.Pagination
+	23, // 12: market.v1.ListMyBidsResponse.bids:type_name -> market.v1.Bid
+	0,  // 13: market.v1.Bid.status:type_name -> market.v1.BidStatus
+	1,  // 14: market.v1.ListingEvent.type:type_name -> market.v1.ListingEventType
+	28, // 15: market.v1.GetPriceHistoryResponse.buckets:type_name -> market.v1.PriceBucket
+	2,  // 16: market.v1.PlayerPriceBounds.source:type_name -> market.v1.PriceBoundsSource
+	37, // 17: market.v1.RelistAllExpiredResponse.relisted:type_name -> market.v1.RelistListingResponse
+	40, // 18: market.v1.RelistAllExpiredResponse.failed:type_name -> market.v1.RelistFailure
+	6,  // 19: market.v1.MarketService.CreateListing:input_type -> market.v1.CreateListingRequest
+	8,  // 20: market.v1.MarketService.PlaceBid:input_type -> market.v1.PlaceBidRequest
+	10, // 21: market.v1.MarketService.BuyNow:input_type -> market.v1.BuyNowRequest
+	12, // 22: market.v1.MarketService.GetListing:input_type -> market.v1.GetListingRequest
+	14, // 23: market.v1.MarketService.CancelListing:input_type -> market.v1.CancelListingRequest
+	36, // 24: market.v1.MarketService.RelistListing:input_type -> market.v1.RelistListingRequest
+	38, // 25: market.v1.MarketService.RelistAllExpired:input_type -> market.v1.RelistAllExpiredRequest
+	16, // 26: market.v1.MarketService.SearchListings:input_type -> market.v1.SearchListingsRequest
+	19, // 27: market.v1.MarketService.ListBids:input_type -> market.v1.ListBidsRequest
+	21, // 28: market.v1.MarketService.ListMyBids:input_type -> market.v1.ListMyBidsRequest
+	24, // 29: market.v1.MarketService.WatchListing:input_type -> market.v1.WatchListingRequest
+	26, // 30: market.v1.MarketService.GetPriceHistory:input_type -> market.v1.GetPriceHistoryRequest
+	29, // 31: market.v1.MarketService.GetLowestBin:input_type -> market.v1.GetLowestBinRequest
+	31, // 32: market.v1.MarketService.GetPlayerPriceBounds:input_type -> market.v1.GetPlayerPriceBoundsRequest
+	32, // 33: market.v1.MarketService.SetPlayerPriceBounds:input_type -> market.v1.SetPlayerPriceBoundsRequest
+	33, // 34: market.v1.MarketService.ClearPlayerPriceBounds:input_type -> market.v1.ClearPlayerPriceBoundsRequest
+	7,  // 35: market.v1.MarketService.CreateListing:output_type -> market.v1.CreateListingResponse
+	9,  // 36: market.v1.MarketService.PlaceBid:output_type -> market.v1.PlaceBidResponse
+	11, // 37: market.v1.MarketService.BuyNow:output_type -> market.v1.BuyNowResponse
+	13, // 38: market.v1.MarketService.GetListing:output_type -> market.v1.GetListingResponse
+	15, // 39: market.v1.MarketService.CancelListing:output_type -> market.v1.CancelListingResponse
+	37, // 40: market.v1.MarketService.RelistListing:output_type -> market.v1.RelistListingResponse
+	39, // 41: market.v1.MarketService.RelistAllExpired:output_type -> market.v1.RelistAllExpiredResponse
+	17, // 42: market.v1.MarketService.SearchListings:output_type -> market.v1.SearchListingsResponse
+	20, // 43: market.v1.MarketService.ListBids:output_type -> market.v1.ListBidsResponse
+	22, // 44: market.v1.MarketService.ListMyBids:output_type -> market.v1.ListMyBidsResponse
+	25, // 45: market.v1.MarketService.WatchListing:output_type -> market.v1.ListingEvent
+	27, // 46: market.v1.MarketService.GetPriceHistory:output_type -> market.v1.GetPriceHistoryResponse
+	30, // 47: market.v1.MarketService.GetLowestBin:output_type -> market.v1.GetLowestBinResponse
+	35, // 48: market.v1.MarketService.GetPlayerPriceBounds:output_type -> market.v1.PlayerPriceBounds
+	35, // 49: market.v1.MarketService.SetPlayerPriceBounds:output_type -> market.v1.PlayerPriceBounds
+	34, // 50: market.v1.MarketService.ClearPlayerPriceBounds:output_type -> market.v1.ClearPlayerPriceBoundsResponse
+	35, // [35:51] is the sub-list for method output_type
+	19, // [19:35] is the sub-list for method input_type
+	19, // [19:19] is the sub-list for extension type_name
+	19, // [19:19] is the sub-list for extension extendee
+	0,  // [0:19] is the sub-list for field type_name
 }
 
 func init() { file_market_v1_market_proto_init() }
@@ -670,8 +3109,8 @@ func file_market_v1_market_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_market_v1_market_proto_rawDesc), len(file_market_v1_market_proto_rawDesc)),
-			NumEnums:      1,
-			NumMessages:   8,
+			NumEnums:      6,
+			NumMessages:   35,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -19,10 +19,22 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	MarketService_CreateListing_FullMethodName = "/market.v1.MarketService/CreateListing"
-	MarketService_PlaceBid_FullMethodName      = "/market.v1.MarketService/PlaceBid"
-	MarketService_BuyNow_FullMethodName        = "/market.v1.MarketService/BuyNow"
-	MarketService_GetListing_FullMethodName    = "/market.v1.MarketService/GetListing"
+	MarketService_CreateListing_FullMethodName          = "/market.v1.MarketService/CreateListing"
+	MarketService_PlaceBid_FullMethodName               = "/market.v1.MarketService/PlaceBid"
+	MarketService_BuyNow_FullMethodName                 = "/market.v1.MarketService/BuyNow"
+	MarketService_GetListing_FullMethodName             = "/market.v1.MarketService/GetListing"
+	MarketService_CancelListing_FullMethodName          = "/market.v1.MarketService/CancelListing"
+	MarketService_RelistListing_FullMethodName          = "/market.v1.MarketService/RelistListing"
+	MarketService_RelistAllExpired_FullMethodName       = "/market.v1.MarketService/RelistAllExpired"
+	MarketService_SearchListings_FullMethodName         = "/market.v1.MarketService/SearchListings"
+	MarketService_ListBids_FullMethodName               = "/market.v1.MarketService/ListBids"
+	MarketService_ListMyBids_FullMethodName             = "/market.v1.MarketService/ListMyBids"
+	MarketService_WatchListing_FullMethodName           = "/market.v1.MarketService/WatchListing"
+	MarketService_GetPriceHistory_FullMethodName        = "/market.v1.MarketService/GetPriceHistory"
+	MarketService_GetLowestBin_FullMethodName           = "/market.v1.MarketService/GetLowestBin"
+	MarketService_GetPlayerPriceBounds_FullMethodName   = "/market.v1.MarketService/GetPlayerPriceBounds"
+	MarketService_SetPlayerPriceBounds_FullMethodName   = "/market.v1.MarketService/SetPlayerPriceBounds"
+	MarketService_ClearPlayerPriceBounds_FullMethodName = "/market.v1.MarketService/ClearPlayerPriceBounds"
 )
 
 // MarketServiceClient is the client API for MarketService service.
@@ -33,6 +45,19 @@ type MarketServiceClient interface {
 	PlaceBid(ctx context.Context, in *PlaceBidRequest, opts ...grpc.CallOption) (*PlaceBidResponse, error)
 	BuyNow(ctx context.Context, in *BuyNowRequest, opts ...grpc.CallOption) (*BuyNowResponse, error)
 	GetListing(ctx context.Context, in *GetListingRequest, opts ...grpc.CallOption) (*GetListingResponse, error)
+	CancelListing(ctx context.Context, in *CancelListingRequest, opts ...grpc.CallOption) (*CancelListingResponse, error)
+	RelistListing(ctx context.Context, in *RelistListingRequest, opts ...grpc.CallOption) (*RelistListingResponse, error)
+	RelistAllExpired(ctx context.Context, in *RelistAllExpiredRequest, opts ...grpc.CallOption) (*RelistAllExpiredResponse, error)
+	SearchListings(ctx context.Context, in *SearchListingsRequest, opts ...grpc.CallOption) (*SearchListingsResponse, error)
+	ListBids(ctx context.Context, in *ListBidsRequest, opts ...grpc.CallOption) (*ListBidsResponse, error)
+	ListMyBids(ctx context.Context, in *ListMyBidsRequest, opts ...grpc.CallOption) (*ListMyBidsResponse, error)
+	WatchListing(ctx context.Context, in *WatchListingRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ListingEvent], error)
+	GetPriceHistory(ctx context.Context, in *GetPriceHistoryRequest, opts ...grpc.CallOption) (*GetPriceHistoryResponse, error)
+	GetLowestBin(ctx context.Context, in *GetLowestBinRequest, opts ...grpc.CallOption) (*GetLowestBinResponse, error)
+	GetPlayerPriceBounds(ctx context.Context, in *GetPlayerPriceBoundsRequest, opts ...grpc.CallOption) (*PlayerPriceBounds, error)
+	// RPC admin: richiedono la metadata admin_token.
+	SetPlayerPriceBounds(ctx context.Context, in *SetPlayerPriceBoundsRequest, opts ...grpc.CallOption) (*PlayerPriceBounds, error)
+	ClearPlayerPriceBounds(ctx context.Context, in *ClearPlayerPriceBoundsRequest, opts ...grpc.CallOption) (*ClearPlayerPriceBoundsResponse, error)
 }
 
 type marketServiceClient struct {
@@ -83,6 +108,135 @@ func (c *marketServiceClient) GetListing(ctx context.Context, in *GetListingRequ
 	return out, nil
 }
 
+func (c *marketServiceClient) CancelListing(ctx context.Context, in *CancelListingRequest, opts ...grpc.CallOption) (*CancelListingResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CancelListingResponse)
+	err := c.cc.Invoke(ctx, MarketService_CancelListing_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *marketServiceClient) RelistListing(ctx context.Context, in *RelistListingRequest, opts ...grpc.CallOption) (*RelistListingResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RelistListingResponse)
+	err := c.cc.Invoke(ctx, MarketService_RelistListing_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *marketServiceClient) RelistAllExpired(ctx context.Context, in *RelistAllExpiredRequest, opts ...grpc.CallOption) (*RelistAllExpiredResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RelistAllExpiredResponse)
+	err := c.cc.Invoke(ctx, MarketService_RelistAllExpired_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *marketServiceClient) SearchListings(ctx context.Context, in *SearchListingsRequest, opts ...grpc.CallOption) (*SearchListingsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchListingsResponse)
+	err := c.cc.Invoke(ctx, MarketService_SearchListings_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *marketServiceClient) ListBids(ctx context.Context, in *ListBidsRequest, opts ...grpc.CallOption) (*ListBidsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListBidsResponse)
+	err := c.cc.Invoke(ctx, MarketService_ListBids_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *marketServiceClient) ListMyBids(ctx context.Context, in *ListMyBidsRequest, opts ...grpc.CallOption) (*ListMyBidsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListMyBidsResponse)
+	err := c.cc.Invoke(ctx, MarketService_ListMyBids_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *marketServiceClient) WatchListing(ctx context.Context, in *WatchListingRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ListingEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &MarketService_ServiceDesc.Streams[0], MarketService_WatchListing_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchListingRequest, ListingEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type MarketService_WatchListingClient = grpc.ServerStreamingClient[ListingEvent]
+
+func (c *marketServiceClient) GetPriceHistory(ctx context.Context, in *GetPriceHistoryRequest, opts ...grpc.CallOption) (*GetPriceHistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetPriceHistoryResponse)
+	err := c.cc.Invoke(ctx, MarketService_GetPriceHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *marketServiceClient) GetLowestBin(ctx context.Context, in *GetLowestBinRequest, opts ...grpc.CallOption) (*GetLowestBinResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetLowestBinResponse)
+	err := c.cc.Invoke(ctx, MarketService_GetLowestBin_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *marketServiceClient) GetPlayerPriceBounds(ctx context.Context, in *GetPlayerPriceBoundsRequest, opts ...grpc.CallOption) (*PlayerPriceBounds, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PlayerPriceBounds)
+	err := c.cc.Invoke(ctx, MarketService_GetPlayerPriceBounds_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *marketServiceClient) SetPlayerPriceBounds(ctx context.Context, in *SetPlayerPriceBoundsRequest, opts ...grpc.CallOption) (*PlayerPriceBounds, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PlayerPriceBounds)
+	err := c.cc.Invoke(ctx, MarketService_SetPlayerPriceBounds_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *marketServiceClient) ClearPlayerPriceBounds(ctx context.Context, in *ClearPlayerPriceBoundsRequest, opts ...grpc.CallOption) (*ClearPlayerPriceBoundsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ClearPlayerPriceBoundsResponse)
+	err := c.cc.Invoke(ctx, MarketService_ClearPlayerPriceBounds_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // MarketServiceServer is the server API for MarketService service.
 // All implementations must embed UnimplementedMarketServiceServer
 // for forward compatibility.
@@ -91,6 +245,19 @@ type MarketServiceServer interface {
 	PlaceBid(context.Context, *PlaceBidRequest) (*PlaceBidResponse, error)
 	BuyNow(context.Context, *BuyNowRequest) (*BuyNowResponse, error)
 	GetListing(context.Context, *GetListingRequest) (*GetListingResponse, error)
+	CancelListing(context.Context, *CancelListingRequest) (*CancelListingResponse, error)
+	RelistListing(context.Context, *RelistListingRequest) (*RelistListingResponse, error)
+	RelistAllExpired(context.Context, *RelistAllExpiredRequest) (*RelistAllExpiredResponse, error)
+	SearchListings(context.Context, *SearchListingsRequest) (*SearchListingsResponse, error)
+	ListBids(context.Context, *ListBidsRequest) (*ListBidsResponse, error)
+	ListMyBids(context.Context, *ListMyBidsRequest) (*ListMyBidsResponse, error)
+	WatchListing(*WatchListingRequest, grpc.ServerStreamingServer[ListingEvent]) error
+	GetPriceHistory(context.Context, *GetPriceHistoryRequest) (*GetPriceHistoryResponse, error)
+	GetLowestBin(context.Context, *GetLowestBinRequest) (*GetLowestBinResponse, error)
+	GetPlayerPriceBounds(context.Context, *GetPlayerPriceBoundsRequest) (*PlayerPriceBounds, error)
+	// RPC admin: richiedono la metadata admin_token.
+	SetPlayerPriceBounds(context.Context, *SetPlayerPriceBoundsRequest) (*PlayerPriceBounds, error)
+	ClearPlayerPriceBounds(context.Context, *ClearPlayerPriceBoundsRequest) (*ClearPlayerPriceBoundsResponse, error)
 	mustEmbedUnimplementedMarketServiceServer()
 }
 
@@ -113,6 +280,42 @@ func (UnimplementedMarketServiceServer) BuyNow(context.Context, *BuyNowRequest)
 func (UnimplementedMarketServiceServer) GetListing(context.Context, *GetListingRequest) (*GetListingResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetListing not implemented")
 }
+func (UnimplementedMarketServiceServer) CancelListing(context.Context, *CancelListingRequest) (*CancelListingResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CancelListing not implemented")
+}
+func (UnimplementedMarketServiceServer) RelistListing(context.Context, *RelistListingRequest) (*RelistListingResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RelistListing not implemented")
+}
+func (UnimplementedMarketServiceServer) RelistAllExpired(context.Context, *RelistAllExpiredRequest) (*RelistAllExpiredResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RelistAllExpired not implemented")
+}
+func (UnimplementedMarketServiceServer) SearchListings(context.Context, *SearchListingsRequest) (*SearchListingsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SearchListings not implemented")
+}
+func (UnimplementedMarketServiceServer) ListBids(context.Context, *ListBidsRequest) (*ListBidsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListBids not implemented")
+}
+func (UnimplementedMarketServiceServer) ListMyBids(context.Context, *ListMyBidsRequest) (*ListMyBidsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListMyBids not implemented")
+}
+func (UnimplementedMarketServiceServer) WatchListing(*WatchListingRequest, grpc.ServerStreamingServer[ListingEvent]) error {
+	return status.Error(codes.Unimplemented, "method WatchListing not implemented")
+}
+func (UnimplementedMarketServiceServer) GetPriceHistory(context.Context, *GetPriceHistoryRequest) (*GetPriceHistoryResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetPriceHistory not implemented")
+}
+func (UnimplementedMarketServiceServer) GetLowestBin(context.Context, *GetLowestBinRequest) (*GetLowestBinResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetLowestBin not implemented")
+}
+func (UnimplementedMarketServiceServer) GetPlayerPriceBounds(context.Context, *GetPlayerPriceBoundsRequest) (*PlayerPriceBounds, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetPlayerPriceBounds not implemented")
+}
+func (UnimplementedMarketServiceServer) SetPlayerPriceBounds(context.Context, *SetPlayerPriceBoundsRequest) (*PlayerPriceBounds, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetPlayerPriceBounds not implemented")
+}
+func (UnimplementedMarketServiceServer) ClearPlayerPriceBounds(context.Context, *ClearPlayerPriceBoundsRequest) (*ClearPlayerPriceBoundsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ClearPlayerPriceBounds not implemented")
+}
 func (UnimplementedMarketServiceServer) mustEmbedUnimplementedMarketServiceServer() {}
 func (UnimplementedMarketServiceServer) testEmbeddedByValue()                       {}
 
@@ -206,6 +409,215 @@ func _MarketService_GetListing_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MarketService_CancelListing_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelListingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MarketServiceServer).CancelListing(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MarketService_CancelListing_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MarketServiceServer).CancelListing(ctx, req.(*CancelListingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MarketService_RelistListing_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RelistListingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MarketServiceServer).RelistListing(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MarketService_RelistListing_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MarketServiceServer).RelistListing(ctx, req.(*RelistListingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MarketService_RelistAllExpired_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RelistAllExpiredRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MarketServiceServer).RelistAllExpired(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MarketService_RelistAllExpired_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MarketServiceServer).RelistAllExpired(ctx, req.(*RelistAllExpiredRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MarketService_SearchListings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchListingsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MarketServiceServer).SearchListings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MarketService_SearchListings_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MarketServiceServer).SearchListings(ctx, req.(*SearchListingsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MarketService_ListBids_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListBidsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MarketServiceServer).ListBids(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MarketService_ListBids_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MarketServiceServer).ListBids(ctx, req.(*ListBidsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MarketService_ListMyBids_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMyBidsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MarketServiceServer).ListMyBids(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MarketService_ListMyBids_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MarketServiceServer).ListMyBids(ctx, req.(*ListMyBidsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MarketService_WatchListing_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchListingRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(MarketServiceServer).WatchListing(m, &grpc.GenericServerStream[WatchListingRequest, ListingEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type MarketService_WatchListingServer = grpc.ServerStreamingServer[ListingEvent]
+
+func _MarketService_GetPriceHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPriceHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MarketServiceServer).GetPriceHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MarketService_GetPriceHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MarketServiceServer).GetPriceHistory(ctx, req.(*GetPriceHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MarketService_GetLowestBin_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLowestBinRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MarketServiceServer).GetLowestBin(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MarketService_GetLowestBin_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MarketServiceServer).GetLowestBin(ctx, req.(*GetLowestBinRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MarketService_GetPlayerPriceBounds_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPlayerPriceBoundsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MarketServiceServer).GetPlayerPriceBounds(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MarketService_GetPlayerPriceBounds_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MarketServiceServer).GetPlayerPriceBounds(ctx, req.(*GetPlayerPriceBoundsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MarketService_SetPlayerPriceBounds_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetPlayerPriceBoundsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MarketServiceServer).SetPlayerPriceBounds(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MarketService_SetPlayerPriceBounds_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MarketServiceServer).SetPlayerPriceBounds(ctx, req.(*SetPlayerPriceBoundsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MarketService_ClearPlayerPriceBounds_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClearPlayerPriceBoundsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MarketServiceServer).ClearPlayerPriceBounds(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MarketService_ClearPlayerPriceBounds_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MarketServiceServer).ClearPlayerPriceBounds(ctx, req.(*ClearPlayerPriceBoundsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // MarketService_ServiceDesc is the grpc.ServiceDesc for MarketService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -229,7 +641,57 @@ var MarketService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetListing",
 			Handler:    _MarketService_GetListing_Handler,
 		},
+		{
+			MethodName: "CancelListing",
+			Handler:    _MarketService_CancelListing_Handler,
+		},
+		{
+			MethodName: "RelistListing",
+			Handler:    _MarketService_RelistListing_Handler,
+		},
+		{
+			MethodName: "RelistAllExpired",
+			Handler:    _MarketService_RelistAllExpired_Handler,
+		},
+		{
+			MethodName: "SearchListings",
+			Handler:    _MarketService_SearchListings_Handler,
+		},
+		{
+			MethodName: "ListBids",
+			Handler:    _MarketService_ListBids_Handler,
+		},
+		{
+			MethodName: "ListMyBids",
+			Handler:    _MarketService_ListMyBids_Handler,
+		},
+		{
+			MethodName: "GetPriceHistory",
+			Handler:    _MarketService_GetPriceHistory_Handler,
+		},
+		{
+			MethodName: "GetLowestBin",
+			Handler:    _MarketService_GetLowestBin_Handler,
+		},
+		{
+			MethodName: "GetPlayerPriceBounds",
+			Handler:    _MarketService_GetPlayerPriceBounds_Handler,
+		},
+		{
+			MethodName: "SetPlayerPriceBounds",
+			Handler:    _MarketService_SetPlayerPriceBounds_Handler,
+		},
+		{
+			MethodName: "ClearPlayerPriceBounds",
+			Handler:    _MarketService_ClearPlayerPriceBounds_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchListing",
+			Handler:       _MarketService_WatchListing_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "market/v1/market.proto",
 }
@@ -62,7 +62,7 @@ func main() {
 		os.Exit(1)
 	}
 
-	fmt.Printf("club_id=%s credits=%d cards=%d\n", result.ClubID, result.Credits, len(result.Cards))
+	fmt.Printf("club_id=%s credits=%d available_credits=%d cards=%d\n", result.ClubID, result.Credits, result.AvailableCredits, len(result.Cards))
 	for _, card := range result.Cards {
 		fmt.Printf("card id=%s player_id=%s locked=%v\n", card.ID, card.PlayerID, card.Locked)
 	}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+
+	"UltimateTeamX/service/club/internal/club"
+	"UltimateTeamX/service/club/internal/config"
+	"UltimateTeamX/service/club/internal/db"
+	"github.com/joho/godotenv"
+)
+
+// club-reconcile ricalcola i saldi dal ledger e segnala i club con snapshot divergente.
+// Con -repair riporta credits al saldo del ledger registrando la correzione.
+// Esce con codice 2 se restano divergenze non corrette (utile in cron/CI).
+func main() {
+	repair := flag.Bool("repair", false, "allinea clubs.credits al saldo del ledger")
+	note := flag.String("note", "club-reconcile", "nota registrata in credit_corrections")
+	flag.Parse()
+
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+
+	// 1) Carica env per connessione DB.
+	envPath := os.Getenv("GO_DOTENV_PATH")
+	if envPath == "" {
+		envPath = "service/club/.env"
+	}
+	if err := godotenv.Overload(envPath); err != nil {
+		logger.Warn("impossibile caricare .env", "path", envPath, "error", err)
+	}
+
+	cfg := config.Load()
+	database, err := db.Open(cfg.DBDSN)
+	if err != nil {
+		logger.Error("db connection failed", "error", err)
+		os.Exit(1)
+	}
+	defer database.Close()
+
+	// 2) Club con snapshot diverso dal ledger.
+	ctx := context.Background()
+	repo := club.NewRepo(database)
+	divergences, err := repo.ListLedgerDivergences(ctx)
+	if err != nil {
+		logger.Error("errore riconciliazione", "error", err)
+		os.Exit(1)
+	}
+
+	// 3) Report e, se richiesto, correzione club per club.
+	remaining := 0
+	for _, rec := range divergences {
+		fmt.Printf("club_id=%s snapshot=%d ledger=%d delta=%d\n", rec.ClubID, rec.SnapshotCredits, rec.LedgerCredits, rec.Delta())
+		if !*repair {
+			remaining++
+			continue
+		}
+		fixed, err := repo.RepairClubCredits(ctx, rec.ClubID, *note)
+		if err != nil {
+			if errors.Is(err, club.ErrNegativeLedgerBalance) {
+				logger.Warn("saldo ledger negativo, club non corretto", "club_id", rec.ClubID, "ledger", fixed.LedgerCredits)
+			} else {
+				logger.Error("errore correzione club", "error", err, "club_id", rec.ClubID)
+			}
+			remaining++
+			continue
+		}
+		if fixed.Diverged() {
+			logger.Info("credits corretti", "club_id", rec.ClubID, "from", fixed.SnapshotCredits, "to", fixed.LedgerCredits)
+		}
+	}
+
+	fmt.Printf("clubs_diverged=%d remaining=%d\n", len(divergences), remaining)
+	if remaining > 0 {
+		os.Exit(2)
+	}
+}
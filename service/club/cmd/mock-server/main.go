@@ -22,9 +22,18 @@ func (s *mockClubServer) GetMyClub(_ context.Context, _ *clubv1.GetMyClubRequest
 	return &clubv1.GetMyClubResponse{ClubId: "00000000-0000-0000-0000-000000000001", Credits: 0, Cards: nil}, nil
 }
 
-// LockCard simula un lock carta e genera un lock_id fittizio.
+// GetClubByID simula la risoluzione club -> user con un user_id fittizio.
+func (s *mockClubServer) GetClubByID(_ context.Context, req *clubv1.GetClubByIDRequest) (*clubv1.GetClubByIDResponse, error) {
+	s.logger.Info("mock get club by id", "club_id", req.ClubId)
+	return &clubv1.GetClubByIDResponse{ClubId: req.ClubId, UserId: "00000000-0000-0000-0000-000000000002"}, nil
+}
+
+// LockCard simula un lock carta: riusa il lock_id del chiamante o ne genera uno fittizio.
 func (s *mockClubServer) LockCard(_ context.Context, req *clubv1.LockCardRequest) (*clubv1.LockCardResponse, error) {
-	lockID := uuid.NewString()
+	lockID := req.LockId
+	if lockID == "" {
+		lockID = uuid.NewString()
+	}
 	s.logger.Info("mock lock card", "user_id", req.UserId, "user_card_id", req.UserCardId, "lock_id", lockID)
 	return &clubv1.LockCardResponse{LockId: lockID}, nil
 }
@@ -35,9 +44,12 @@ func (s *mockClubServer) ReleaseCardLock(_ context.Context, req *clubv1.ReleaseC
 	return &clubv1.ReleaseCardLockResponse{Released: true}, nil
 }
 
-// CreateCreditHold simula un hold crediti e ritorna un hold_id fittizio.
+// CreateCreditHold simula un hold crediti: riusa l'hold_id del chiamante o ne genera uno fittizio.
 func (s *mockClubServer) CreateCreditHold(_ context.Context, req *clubv1.CreateCreditHoldRequest) (*clubv1.CreateCreditHoldResponse, error) {
-	holdID := uuid.NewString()
+	holdID := req.HoldId
+	if holdID == "" {
+		holdID = uuid.NewString()
+	}
 	s.logger.Info("mock credit hold", "user_id", req.UserId, "amount", req.Amount, "hold_id", holdID)
 	return &clubv1.CreateCreditHoldResponse{HoldId: holdID}, nil
 }
@@ -48,6 +60,18 @@ func (s *mockClubServer) ReleaseCreditHold(_ context.Context, req *clubv1.Releas
 	return &clubv1.ReleaseCreditHoldResponse{Released: true}, nil
 }
 
+// SettleTrade simula il settlement di un trade e conferma l'operazione.
+func (s *mockClubServer) SettleTrade(_ context.Context, req *clubv1.SettleTradeRequest) (*clubv1.SettleTradeResponse, error) {
+	s.logger.Info("mock settle trade", "seller_user_id", req.SellerUserId, "buyer_user_id", req.BuyerUserId, "user_card_id", req.UserCardId, "amount", req.Amount, "tax_amount", req.TaxAmount, "seller_net_amount", req.SellerNetAmount, "hold_id", req.HoldId, "card_lock_id", req.CardLockId, "bundle_cards", len(req.Cards))
+	return &clubv1.SettleTradeResponse{Settled: true}, nil
+}
+
+// DebitCredits simula l'addebito di crediti (es. penali del market).
+func (s *mockClubServer) DebitCredits(_ context.Context, req *clubv1.DebitCreditsRequest) (*clubv1.DebitCreditsResponse, error) {
+	s.logger.Info("mock debit credits", "user_id", req.UserId, "amount", req.Amount, "reason", req.Reason, "reference_id", req.ReferenceId)
+	return &clubv1.DebitCreditsResponse{Debited: true}, nil
+}
+
 func main() {
 	// Avvio server gRPC mock su GRPC_ADDR (default :50052).
 	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
@@ -2,116 +2,84 @@ package main
 
 import (
 	"context"
-	"database/sql"
-	"errors"
-	"fmt"
 	"log/slog"
+	"net"
 	"os"
+	"os/signal"
+	"syscall"
 	"time"
 
+	clubv1 "UltimateTeamX/proto/club/v1"
+	"UltimateTeamX/service/club/internal/club"
+	"UltimateTeamX/service/club/internal/config"
+	"UltimateTeamX/service/club/internal/db"
 	"github.com/joho/godotenv"
-	_ "github.com/lib/pq"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
 )
 
 func main() {
+	// Bootstrap di logging e config.
 	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
 
-	// 1) Carica env dedicato al flow club.
-	// Carica le variabili da .env per il flow club.
+	// Carica le variabili da .env se presente (solo per dev).
 	envPath := os.Getenv("GO_DOTENV_PATH")
 	if envPath == "" {
-		envPath = "service/club/.env"
+		envPath = ".env"
 	}
 	if err := godotenv.Overload(envPath); err != nil {
+		// Se manca il file .env, continuiamo con le env già presenti.
 		logger.Warn("impossibile caricare .env", "path", envPath, "error", err)
 	} else {
 		logger.Info(".env caricato", "path", envPath)
 	}
 
-	// 2) Costruisce la DSN e apre il DB.
-	dsn := os.Getenv("DB_DSN")
-	if dsn == "" {
-		dsn = buildDSN()
-	}
+	cfg := config.Load()
 
-	db, err := openDB(dsn)
+	// DB richiesto per club, carte e crediti.
+	database, err := db.Open(cfg.DBDSN)
 	if err != nil {
 		logger.Error("db connection failed", "error", err)
 		os.Exit(1)
 	}
-	defer db.Close()
-
-	// 3) Legge i file SQL da CLI ed esegue in transazione.
-	files := os.Args[1:]
-	if len(files) == 0 {
-		logger.Error("nessun file sql passato", "usage", "go run service/club/cmd/server/main.go <file.sql> [file2.sql]")
-		os.Exit(1)
-	}
-
-	for _, file := range files {
-		if err := execSQLFile(db, file); err != nil {
-			logger.Error("esecuzione sql fallita", "file", file, "error", err)
-			os.Exit(1)
-		}
-		logger.Info("sql eseguito", "file", file)
-	}
-}
-
-func openDB(dsn string) (*sql.DB, error) {
-	if dsn == "" {
-		return nil, errors.New("DB_DSN is required")
-	}
-
-	db, err := sql.Open("postgres", dsn)
-	if err != nil {
-		return nil, err
-	}
-
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
-	if err := db.PingContext(ctx); err != nil {
-		return nil, err
-	}
-
-	return db, nil
-}
-
-func execSQLFile(db *sql.DB, path string) error {
-	content, err := os.ReadFile(path)
-	if err != nil {
-		return err
-	}
-
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-	defer cancel()
-
-	tx, err := db.BeginTx(ctx, nil)
+	defer database.Close()
+
+	// Registra ClubService, health check e reflection.
+	server := grpc.NewServer()
+	repo := club.NewRepo(database)
+	clubv1.RegisterClubServiceServer(server, club.NewGRPCServer(club.NewService(repo)))
+
+	healthServer := health.NewServer()
+	healthServer.SetServingStatus(clubv1.ClubService_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_SERVING)
+	healthpb.RegisterHealthServer(server, healthServer)
+	reflection.Register(server)
+
+	// Shutdown con SIGINT/SIGTERM: health NOT_SERVING, poi chiusura delle RPC in corso.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		logger.Info("club shutdown avviato", "timeout", cfg.ShutdownTimeout)
+		healthServer.Shutdown()
+		// Oltre il timeout le RPC ancora aperte vengono interrotte.
+		timer := time.AfterFunc(cfg.ShutdownTimeout, server.Stop)
+		defer timer.Stop()
+		server.GracefulStop()
+	}()
+
+	// Avvia il listener gRPC.
+	listener, err := net.Listen("tcp", cfg.GRPCAddr)
 	if err != nil {
-		return err
-	}
-	if _, err := tx.ExecContext(ctx, string(content)); err != nil {
-		_ = tx.Rollback()
-		return err
-	}
-	return tx.Commit()
-}
-
-func buildDSN() string {
-	host := os.Getenv("DB_HOST")
-	port := getEnv("DB_PORT", "5432")
-	user := os.Getenv("DB_USER")
-	password := os.Getenv("DB_PASSWORD")
-	name := os.Getenv("DB_NAME")
-	sslmode := getEnv("DB_SSLMODE", "require")
-	if host == "" || user == "" || name == "" {
-		return ""
+		logger.Error("grpc listen failed", "error", err)
+		os.Exit(1)
 	}
-	return fmt.Sprintf("postgres://%s:%s@%s:%s/%s?sslmode=%s", user, password, host, port, name, sslmode)
-}
 
-func getEnv(key, fallback string) string {
-	if value := os.Getenv(key); value != "" {
-		return value
+	logger.Info("club grpc listening", "addr", cfg.GRPCAddr)
+	if err := server.Serve(listener); err != nil {
+		logger.Error("grpc serve failed", "error", err)
+		os.Exit(1)
 	}
-	return fallback
+	logger.Info("club grpc fermato")
 }
@@ -0,0 +1,80 @@
+package club
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+
+	"github.com/google/uuid"
+)
+
+// Debit e' un addebito di crediti richiesto da un altro servizio (es. penale del market).
+// ReferenceID identifica l'addebito: un retry con lo stesso riferimento non addebita di nuovo.
+type Debit struct {
+	Amount      int64
+	Reason      string
+	ReferenceID string
+}
+
+// DebitCredits addebita amount al club scrivendo la riga ledger, con la riga del club
+// bloccata (FOR UPDATE) per tutta la transazione. L'addebito non puo' superare i crediti
+// disponibili (credits - hold attivi). Ritorna false se reference_id era gia' addebitato
+// con lo stesso importo (retry): in quel caso non modifica nulla.
+func (r *Repo) DebitCredits(ctx context.Context, clubID uuid.UUID, debit Debit) (bool, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	const selectAvailable = `
+SELECT c.credits - COALESCE((SELECT SUM(h.amount) FROM credit_holds h WHERE h.club_id = c.id AND h.released_at IS NULL), 0)
+FROM clubs c
+WHERE c.id = $1
+FOR UPDATE`
+
+	var available int64
+	err = tx.QueryRowContext(ctx, selectAvailable, clubID).Scan(&available)
+	if err == sql.ErrNoRows {
+		return false, ErrClubNotFound
+	}
+	if err != nil {
+		slog.Error("errore lock club per addebito", "error", err, "club_id", clubID)
+		return false, err
+	}
+
+	// Idempotenza: con il club bloccato un addebito concorrente sullo stesso
+	// reference_id attende il commit dell'altro e qui trova la sua riga.
+	const selectDebited = `
+SELECT amount
+FROM ledger
+WHERE club_id = $1 AND reason = $2 AND reference_id = $3`
+
+	var debited int64
+	err = tx.QueryRowContext(ctx, selectDebited, clubID, debit.Reason, debit.ReferenceID).Scan(&debited)
+	switch {
+	case err == nil:
+		if debited != -debit.Amount {
+			return false, ErrDebitAmountMismatch
+		}
+		return false, nil
+	case err != sql.ErrNoRows:
+		slog.Error("errore lettura addebito", "error", err, "club_id", clubID, "reference_id", debit.ReferenceID)
+		return false, err
+	}
+
+	if available < debit.Amount {
+		return false, ErrInsufficientCredits
+	}
+	entry := LedgerEntry{ClubID: clubID, Amount: -debit.Amount, Reason: debit.Reason, ReferenceID: debit.ReferenceID}
+	if err := appendLedgerEntry(ctx, tx, entry); err != nil {
+		slog.Error("errore scrittura ledger addebito", "error", err, "club_id", clubID, "reason", debit.Reason)
+		return false, err
+	}
+	if err := tx.Commit(); err != nil {
+		return false, err
+	}
+	return true, nil
+}
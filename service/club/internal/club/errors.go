@@ -7,3 +7,41 @@ var ErrClubNotFound = errors.New("club not found")
 
 // ErrUnauthenticated indica credenziali mancanti o invalide.
 var ErrUnauthenticated = errors.New("unauthenticated")
+
+// ErrCardNotFound indica una carta inesistente.
+var ErrCardNotFound = errors.New("card not found")
+
+// ErrCardNotOwned indica una carta che non appartiene al club del chiamante.
+var ErrCardNotOwned = errors.New("card does not belong to club")
+
+// ErrCardAlreadyLocked indica una carta con un lock gia' attivo.
+var ErrCardAlreadyLocked = errors.New("card already locked")
+
+// ErrCardLockNotFound indica un lock_id inesistente.
+var ErrCardLockNotFound = errors.New("card lock not found")
+
+// ErrIDAlreadyUsed indica un lock_id o hold_id scelto dal chiamante gia' usato
+// da una richiesta diversa (altra carta o club, altro importo, gia' rilasciato).
+var ErrIDAlreadyUsed = errors.New("id already used by another request")
+
+// ErrInsufficientCredits indica crediti disponibili (credits - hold attivi) insufficienti.
+var ErrInsufficientCredits = errors.New("insufficient available credits")
+
+// ErrCreditHoldNotFound indica un hold_id inesistente.
+var ErrCreditHoldNotFound = errors.New("credit hold not found")
+
+// ErrBuyerOwnsPlayer indica un buyer che possiede gia' una carta dello stesso giocatore.
+var ErrBuyerOwnsPlayer = errors.New("buyer already owns this player")
+
+// ErrSelfTrade indica un trade con buyer e seller nello stesso club.
+var ErrSelfTrade = errors.New("buyer and seller must be different clubs")
+
+// ErrTradeHoldMismatch indica un trade_id gia' regolato con un hold diverso:
+// il retry del market ha preso un nuovo hold che va rilasciato.
+var ErrTradeHoldMismatch = errors.New("trade already settled with a different hold")
+
+// ErrNegativeLedgerBalance indica un saldo ledger negativo: lo snapshot non puo' essere allineato.
+var ErrNegativeLedgerBalance = errors.New("negative ledger balance")
+
+// ErrDebitAmountMismatch indica un reference_id gia' addebitato con un importo diverso.
+var ErrDebitAmountMismatch = errors.New("reference_id already debited with a different amount")
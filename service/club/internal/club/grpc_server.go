@@ -17,11 +17,11 @@ import (
 // Qui si leggono le metadata gRPC e si mappano gli errori in codici gRPC.
 type GRPCServer struct {
 	clubv1.UnimplementedClubServiceServer
-	reader MyClubReader
+	reader ClubAPI
 }
 
 // NewGRPCServer crea il server gRPC con il dominio.
-func NewGRPCServer(reader MyClubReader) *GRPCServer {
+func NewGRPCServer(reader ClubAPI) *GRPCServer {
 	return &GRPCServer{reader: reader}
 }
 
@@ -58,12 +58,327 @@ func (s *GRPCServer) GetMyClub(ctx context.Context, req *clubv1.GetMyClubRequest
 	}
 
 	return &clubv1.GetMyClubResponse{
-		ClubId:  result.ClubID.String(),
-		Credits: result.Credits,
-		Cards:   cards,
+		ClubId:           result.ClubID.String(),
+		Credits:          result.Credits,
+		AvailableCredits: result.AvailableCredits,
+		Cards:            cards,
 	}, nil
 }
 
+// GetClub ritorna saldo totale e disponibile del club (user_id nel body o nelle metadata gRPC).
+func (s *GRPCServer) GetClub(ctx context.Context, req *clubv1.GetClubRequest) (*clubv1.GetClubResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "request is required")
+	}
+	userID, err := requestUserID(ctx, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	club, err := s.reader.GetClub(ctx, userID)
+	if err != nil {
+		if errors.Is(err, ErrClubNotFound) {
+			return nil, status.Error(codes.NotFound, "club not found")
+		}
+		return nil, status.Error(codes.Internal, "failed to load club")
+	}
+
+	return &clubv1.GetClubResponse{
+		Credits:          club.Credits,
+		AvailableCredits: club.AvailableCredits(),
+	}, nil
+}
+
+// GetClubByID risolve l'user_id proprietario del club richiesto.
+func (s *GRPCServer) GetClubByID(ctx context.Context, req *clubv1.GetClubByIDRequest) (*clubv1.GetClubByIDResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "request is required")
+	}
+	clubID, err := uuid.Parse(strings.TrimSpace(req.ClubId))
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "club_id must be a valid UUID")
+	}
+
+	club, err := s.reader.GetClubByID(ctx, clubID)
+	if err != nil {
+		if errors.Is(err, ErrClubNotFound) {
+			return nil, status.Error(codes.NotFound, "club not found")
+		}
+		return nil, status.Error(codes.Internal, "failed to load club")
+	}
+
+	return &clubv1.GetClubByIDResponse{
+		ClubId: club.ID.String(),
+		UserId: club.UserID.String(),
+	}, nil
+}
+
+// LockCard blocca una carta del club dell'utente (user_id nel body o nelle metadata gRPC).
+// Con lock_id valorizzato la chiamata e' ripetibile: lo stesso lock_id ritorna il lock esistente.
+func (s *GRPCServer) LockCard(ctx context.Context, req *clubv1.LockCardRequest) (*clubv1.LockCardResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "request is required")
+	}
+	userID, err := requestUserID(ctx, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+	userCardID, err := uuid.Parse(strings.TrimSpace(req.UserCardId))
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "user_card_id must be a valid UUID")
+	}
+	lockID, err := optionalUUID(req.LockId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "lock_id must be a valid UUID")
+	}
+	reason := strings.TrimSpace(req.Reason)
+	if reason == "" {
+		return nil, status.Error(codes.InvalidArgument, "reason is required")
+	}
+
+	lock, err := s.reader.LockCard(ctx, userID, userCardID, lockID, reason)
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrClubNotFound):
+			return nil, status.Error(codes.NotFound, "club not found")
+		case errors.Is(err, ErrCardNotFound):
+			return nil, status.Error(codes.NotFound, "card not found")
+		case errors.Is(err, ErrCardNotOwned):
+			return nil, status.Error(codes.PermissionDenied, "card does not belong to club")
+		case errors.Is(err, ErrCardAlreadyLocked):
+			return nil, status.Error(codes.FailedPrecondition, "card already locked")
+		case errors.Is(err, ErrIDAlreadyUsed):
+			return nil, status.Error(codes.FailedPrecondition, "lock_id already used")
+		default:
+			return nil, status.Error(codes.Internal, "failed to lock card")
+		}
+	}
+	return &clubv1.LockCardResponse{LockId: lock.ID.String()}, nil
+}
+
+// ReleaseCardLock rilascia un lock carta; ripetere la chiamata ritorna comunque released.
+func (s *GRPCServer) ReleaseCardLock(ctx context.Context, req *clubv1.ReleaseCardLockRequest) (*clubv1.ReleaseCardLockResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "request is required")
+	}
+	lockID, err := uuid.Parse(strings.TrimSpace(req.LockId))
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "lock_id must be a valid UUID")
+	}
+
+	if err := s.reader.ReleaseCardLock(ctx, lockID); err != nil {
+		if errors.Is(err, ErrCardLockNotFound) {
+			return nil, status.Error(codes.NotFound, "card lock not found")
+		}
+		return nil, status.Error(codes.Internal, "failed to release card lock")
+	}
+	return &clubv1.ReleaseCardLockResponse{Released: true}, nil
+}
+
+// CreateCreditHold blocca crediti del club dell'utente; fallisce con FailedPrecondition
+// se il saldo disponibile (credits - hold attivi) non copre amount. Con hold_id valorizzato
+// la chiamata e' ripetibile: lo stesso hold_id ritorna l'hold esistente.
+func (s *GRPCServer) CreateCreditHold(ctx context.Context, req *clubv1.CreateCreditHoldRequest) (*clubv1.CreateCreditHoldResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "request is required")
+	}
+	userID, err := requestUserID(ctx, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+	if req.Amount <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "amount must be positive")
+	}
+	requestedHoldID, err := optionalUUID(req.HoldId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "hold_id must be a valid UUID")
+	}
+	reason := strings.TrimSpace(req.Reason)
+	if reason == "" {
+		return nil, status.Error(codes.InvalidArgument, "reason is required")
+	}
+
+	holdID, err := s.reader.CreateCreditHold(ctx, userID, requestedHoldID, req.Amount, reason)
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrClubNotFound):
+			return nil, status.Error(codes.NotFound, "club not found")
+		case errors.Is(err, ErrInsufficientCredits):
+			return nil, status.Error(codes.FailedPrecondition, "insufficient available credits")
+		case errors.Is(err, ErrIDAlreadyUsed):
+			return nil, status.Error(codes.FailedPrecondition, "hold_id already used")
+		default:
+			return nil, status.Error(codes.Internal, "failed to create credit hold")
+		}
+	}
+	return &clubv1.CreateCreditHoldResponse{HoldId: holdID.String()}, nil
+}
+
+// ReleaseCreditHold rilascia un hold; ripetere la chiamata ritorna comunque released.
+func (s *GRPCServer) ReleaseCreditHold(ctx context.Context, req *clubv1.ReleaseCreditHoldRequest) (*clubv1.ReleaseCreditHoldResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "request is required")
+	}
+	holdID, err := uuid.Parse(strings.TrimSpace(req.HoldId))
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "hold_id must be a valid UUID")
+	}
+
+	if err := s.reader.ReleaseCreditHold(ctx, holdID); err != nil {
+		if errors.Is(err, ErrCreditHoldNotFound) {
+			return nil, status.Error(codes.NotFound, "credit hold not found")
+		}
+		return nil, status.Error(codes.Internal, "failed to release credit hold")
+	}
+	return &clubv1.ReleaseCreditHoldResponse{Released: true}, nil
+}
+
+// SettleTrade regola un trade del market. Gli errori di business (hold non attivo,
+// carta non piu' del seller, buyer che possiede gia' il giocatore) ritornano
+// FailedPrecondition: il market compensa la saga invece di ripetere la chiamata.
+func (s *GRPCServer) SettleTrade(ctx context.Context, req *clubv1.SettleTradeRequest) (*clubv1.SettleTradeResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "request is required")
+	}
+	trade, err := tradeSettlementFromRequest(req)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if err := s.reader.SettleTrade(ctx, trade); err != nil {
+		switch {
+		case errors.Is(err, ErrClubNotFound):
+			return nil, status.Error(codes.NotFound, "club not found")
+		case errors.Is(err, ErrSelfTrade):
+			return nil, status.Error(codes.InvalidArgument, "buyer and seller must be different clubs")
+		case errors.Is(err, ErrBuyerOwnsPlayer):
+			return nil, status.Error(codes.FailedPrecondition, "buyer already owns this player")
+		case errors.Is(err, ErrCreditHoldNotFound):
+			return nil, status.Error(codes.FailedPrecondition, "credit hold not active for buyer")
+		case errors.Is(err, ErrInsufficientCredits):
+			return nil, status.Error(codes.FailedPrecondition, "insufficient available credits")
+		case errors.Is(err, ErrCardLockNotFound):
+			return nil, status.Error(codes.FailedPrecondition, "card lock not active for card")
+		case errors.Is(err, ErrCardNotOwned):
+			return nil, status.Error(codes.FailedPrecondition, "card does not belong to seller or is locked")
+		case errors.Is(err, ErrTradeHoldMismatch):
+			return nil, status.Error(codes.FailedPrecondition, "trade already settled with a different hold")
+		default:
+			return nil, status.Error(codes.Internal, "failed to settle trade")
+		}
+	}
+	return &clubv1.SettleTradeResponse{Settled: true}, nil
+}
+
+// DebitCredits addebita crediti al club dell'utente (es. penale di ritiro del market).
+// reference_id rende la chiamata idempotente: un retry ritorna debited senza addebitare
+// di nuovo. Un addebito oltre i crediti disponibili ritorna FailedPrecondition.
+func (s *GRPCServer) DebitCredits(ctx context.Context, req *clubv1.DebitCreditsRequest) (*clubv1.DebitCreditsResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "request is required")
+	}
+	userID, err := requestUserID(ctx, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+	if req.Amount <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "amount must be positive")
+	}
+	reason := strings.TrimSpace(req.Reason)
+	if reason == "" {
+		return nil, status.Error(codes.InvalidArgument, "reason is required")
+	}
+	referenceID := strings.TrimSpace(req.ReferenceId)
+	if referenceID == "" {
+		return nil, status.Error(codes.InvalidArgument, "reference_id is required")
+	}
+
+	debit := Debit{Amount: req.Amount, Reason: reason, ReferenceID: referenceID}
+	if err := s.reader.DebitCredits(ctx, userID, debit); err != nil {
+		switch {
+		case errors.Is(err, ErrClubNotFound):
+			return nil, status.Error(codes.NotFound, "club not found")
+		case errors.Is(err, ErrInsufficientCredits):
+			return nil, status.Error(codes.FailedPrecondition, "insufficient available credits")
+		case errors.Is(err, ErrDebitAmountMismatch):
+			return nil, status.Error(codes.FailedPrecondition, "reference_id already used with a different amount")
+		default:
+			return nil, status.Error(codes.Internal, "failed to debit credits")
+		}
+	}
+	return &clubv1.DebitCreditsResponse{Debited: true}, nil
+}
+
+// tradeSettlementFromRequest valida la richiesta SettleTrade.
+// cards (bundle) sostituisce user_card_id/card_lock_id di primo livello.
+func tradeSettlementFromRequest(req *clubv1.SettleTradeRequest) (TradeSettlement, error) {
+	var trade TradeSettlement
+	var err error
+	if trade.TradeID, err = uuid.Parse(strings.TrimSpace(req.TradeId)); err != nil {
+		return TradeSettlement{}, errors.New("trade_id must be a valid UUID")
+	}
+	if trade.BuyerUserID, err = uuid.Parse(strings.TrimSpace(req.BuyerUserId)); err != nil {
+		return TradeSettlement{}, errors.New("buyer_user_id must be a valid UUID")
+	}
+	if trade.SellerUserID, err = uuid.Parse(strings.TrimSpace(req.SellerUserId)); err != nil {
+		return TradeSettlement{}, errors.New("seller_user_id must be a valid UUID")
+	}
+	if trade.HoldID, err = uuid.Parse(strings.TrimSpace(req.HoldId)); err != nil {
+		return TradeSettlement{}, errors.New("hold_id must be a valid UUID")
+	}
+	if trade.Amounts, err = NewTradeAmounts(req.Amount, req.TaxAmount, req.SellerNetAmount); err != nil {
+		return TradeSettlement{}, errors.New("amount, tax_amount and seller_net_amount are inconsistent")
+	}
+
+	cards := req.Cards
+	if len(cards) == 0 {
+		cards = []*clubv1.TradeCard{{UserCardId: req.UserCardId, CardLockId: req.CardLockId}}
+	}
+	seen := make(map[uuid.UUID]struct{}, len(cards))
+	for _, card := range cards {
+		userCardID, err := uuid.Parse(strings.TrimSpace(card.GetUserCardId()))
+		if err != nil {
+			return TradeSettlement{}, errors.New("user_card_id must be a valid UUID")
+		}
+		if _, ok := seen[userCardID]; ok {
+			return TradeSettlement{}, errors.New("cards must not contain duplicates")
+		}
+		seen[userCardID] = struct{}{}
+		lockID := uuid.Nil
+		if strings.TrimSpace(card.GetCardLockId()) != "" {
+			if lockID, err = uuid.Parse(strings.TrimSpace(card.GetCardLockId())); err != nil {
+				return TradeSettlement{}, errors.New("card_lock_id must be a valid UUID")
+			}
+		}
+		trade.Cards = append(trade.Cards, TradeCard{UserCardID: userCardID, LockID: lockID})
+	}
+	return trade, nil
+}
+
+// optionalUUID interpreta un id opzionale: stringa vuota = uuid.Nil.
+func optionalUUID(value string) (uuid.UUID, error) {
+	if strings.TrimSpace(value) == "" {
+		return uuid.Nil, nil
+	}
+	return uuid.Parse(strings.TrimSpace(value))
+}
+
+// requestUserID usa l'user_id del body se presente, altrimenti quello delle metadata gRPC.
+func requestUserID(ctx context.Context, bodyUserID string) (uuid.UUID, error) {
+	if strings.TrimSpace(bodyUserID) == "" {
+		userID, err := userIDFromContext(ctx)
+		if err != nil {
+			return uuid.Nil, status.Error(codes.Unauthenticated, err.Error())
+		}
+		return userID, nil
+	}
+	userID, err := uuid.Parse(strings.TrimSpace(bodyUserID))
+	if err != nil {
+		return uuid.Nil, status.Error(codes.InvalidArgument, "user_id must be a valid UUID")
+	}
+	return userID, nil
+}
+
 // userIDFromContext prova prima dalle metadata gRPC, poi dal context locale.
 func userIDFromContext(ctx context.Context) (uuid.UUID, error) {
 	if md, ok := metadata.FromIncomingContext(ctx); ok {
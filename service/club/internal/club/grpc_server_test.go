@@ -14,14 +14,71 @@ import (
 
 // fakeMyClubReader simula il layer dominio per testare il handler gRPC.
 type fakeMyClubReader struct {
-	result *MyClub
-	err    error
+	result     *MyClub
+	err        error
+	club       Club
+	clubErr    error
+	lockErr    error
+	lockUserID uuid.UUID
+	releaseErr error
+	holdErr    error
+	settleErr  error
+	trade      TradeSettlement
+	debitErr   error
+	debit      Debit
 }
 
 func (f *fakeMyClubReader) GetMyClub(_ context.Context, _ uuid.UUID) (*MyClub, error) {
 	return f.result, f.err
 }
 
+func (f *fakeMyClubReader) GetClubByID(_ context.Context, _ uuid.UUID) (Club, error) {
+	return f.club, f.clubErr
+}
+
+func (f *fakeMyClubReader) LockCard(_ context.Context, userID, userCardID, lockID uuid.UUID, reason string) (CardLock, error) {
+	if f.lockErr != nil {
+		return CardLock{}, f.lockErr
+	}
+	f.lockUserID = userID
+	if lockID == uuid.Nil {
+		lockID = uuid.New()
+	}
+	return CardLock{ID: lockID, UserCardID: userCardID, Reason: reason}, nil
+}
+
+func (f *fakeMyClubReader) ReleaseCardLock(_ context.Context, _ uuid.UUID) error {
+	return f.releaseErr
+}
+
+func (f *fakeMyClubReader) GetClub(_ context.Context, _ uuid.UUID) (Club, error) {
+	return f.club, f.clubErr
+}
+
+func (f *fakeMyClubReader) CreateCreditHold(_ context.Context, _, holdID uuid.UUID, _ int64, _ string) (uuid.UUID, error) {
+	if f.holdErr != nil {
+		return uuid.Nil, f.holdErr
+	}
+	if holdID == uuid.Nil {
+		holdID = uuid.New()
+	}
+	return holdID, nil
+}
+
+func (f *fakeMyClubReader) ReleaseCreditHold(_ context.Context, _ uuid.UUID) error {
+	return f.releaseErr
+}
+
+func (f *fakeMyClubReader) SettleTrade(_ context.Context, trade TradeSettlement) error {
+	f.trade = trade
+	return f.settleErr
+}
+
+func (f *fakeMyClubReader) DebitCredits(_ context.Context, _ uuid.UUID, debit Debit) error {
+	f.debit = debit
+	return f.debitErr
+}
+
 // Verifica mapping OK e conversione a risposta gRPC.
 func TestGetMyClubOK(t *testing.T) {
 	reader := &fakeMyClubReader{
@@ -83,3 +140,289 @@ func TestGetMyClubInternal(t *testing.T) {
 		t.Fatalf("expected Internal, got %v", err)
 	}
 }
+
+// Verifica la risoluzione club_id -> user_id.
+func TestGetClubByIDOK(t *testing.T) {
+	clubID := uuid.New()
+	userID := uuid.New()
+	server := NewGRPCServer(&fakeMyClubReader{club: Club{ID: clubID, UserID: userID}})
+
+	resp, err := server.GetClubByID(context.Background(), &clubv1.GetClubByIDRequest{ClubId: clubID.String()})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.UserId != userID.String() || resp.ClubId != clubID.String() {
+		t.Fatalf("unexpected response: %+v", resp)
+	}
+}
+
+// Verifica NotFound e InvalidArgument su GetClubByID.
+func TestGetClubByIDErrors(t *testing.T) {
+	server := NewGRPCServer(&fakeMyClubReader{clubErr: ErrClubNotFound})
+
+	_, err := server.GetClubByID(context.Background(), &clubv1.GetClubByIDRequest{ClubId: uuid.NewString()})
+	if status.Code(err) != codes.NotFound {
+		t.Fatalf("expected NotFound, got %v", err)
+	}
+
+	_, err = server.GetClubByID(context.Background(), &clubv1.GetClubByIDRequest{ClubId: "not-a-uuid"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument, got %v", err)
+	}
+}
+
+// Verifica LockCard con user_id nel body e mapping degli errori di dominio.
+func TestLockCard(t *testing.T) {
+	userID := uuid.New()
+	reader := &fakeMyClubReader{}
+	server := NewGRPCServer(reader)
+
+	resp, err := server.LockCard(context.Background(), &clubv1.LockCardRequest{
+		UserId:     userID.String(),
+		UserCardId: uuid.NewString(),
+		Reason:     "market_listing",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.LockId == "" || reader.lockUserID != userID {
+		t.Fatalf("unexpected lock response: %+v (user %s)", resp, reader.lockUserID)
+	}
+
+	cases := []struct {
+		err  error
+		code codes.Code
+	}{
+		{ErrCardNotFound, codes.NotFound},
+		{ErrCardNotOwned, codes.PermissionDenied},
+		{ErrCardAlreadyLocked, codes.FailedPrecondition},
+		{ErrIDAlreadyUsed, codes.FailedPrecondition},
+	}
+	for _, tc := range cases {
+		server := NewGRPCServer(&fakeMyClubReader{lockErr: tc.err})
+		_, err := server.LockCard(context.Background(), &clubv1.LockCardRequest{
+			UserId:     userID.String(),
+			UserCardId: uuid.NewString(),
+			Reason:     "market_listing",
+		})
+		if status.Code(err) != tc.code {
+			t.Fatalf("%v: expected %v, got %v", tc.err, tc.code, err)
+		}
+	}
+}
+
+// Verifica LockCard senza user_id ne' nel body ne' nelle metadata.
+func TestLockCardUnauthenticated(t *testing.T) {
+	server := NewGRPCServer(&fakeMyClubReader{})
+
+	_, err := server.LockCard(context.Background(), &clubv1.LockCardRequest{
+		UserCardId: uuid.NewString(),
+		Reason:     "market_listing",
+	})
+	if status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("expected Unauthenticated, got %v", err)
+	}
+}
+
+// Verifica ReleaseCardLock: lock_id valido e lock inesistente.
+func TestReleaseCardLock(t *testing.T) {
+	server := NewGRPCServer(&fakeMyClubReader{})
+	resp, err := server.ReleaseCardLock(context.Background(), &clubv1.ReleaseCardLockRequest{LockId: uuid.NewString()})
+	if err != nil || !resp.Released {
+		t.Fatalf("expected released, got %+v (%v)", resp, err)
+	}
+
+	server = NewGRPCServer(&fakeMyClubReader{releaseErr: ErrCardLockNotFound})
+	_, err = server.ReleaseCardLock(context.Background(), &clubv1.ReleaseCardLockRequest{LockId: uuid.NewString()})
+	if status.Code(err) != codes.NotFound {
+		t.Fatalf("expected NotFound, got %v", err)
+	}
+}
+
+// Verifica GetClub con saldo totale e disponibile.
+func TestGetClubAvailableCredits(t *testing.T) {
+	server := NewGRPCServer(&fakeMyClubReader{club: Club{ID: uuid.New(), Credits: 1000, HeldCredits: 400}})
+
+	resp, err := server.GetClub(context.Background(), &clubv1.GetClubRequest{UserId: uuid.NewString()})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.Credits != 1000 || resp.AvailableCredits != 600 {
+		t.Fatalf("expected credits 1000 / available 600, got %+v", resp)
+	}
+}
+
+// Verifica CreateCreditHold: validazione e saldo disponibile insufficiente.
+func TestCreateCreditHold(t *testing.T) {
+	server := NewGRPCServer(&fakeMyClubReader{})
+	resp, err := server.CreateCreditHold(context.Background(), &clubv1.CreateCreditHoldRequest{
+		UserId: uuid.NewString(),
+		Amount: 1500,
+		Reason: "market_bid",
+	})
+	if err != nil || resp.HoldId == "" {
+		t.Fatalf("expected hold, got %+v (%v)", resp, err)
+	}
+
+	// hold_id scelto dal chiamante: ritornato cosi' com'e'.
+	holdID := uuid.NewString()
+	resp, err = server.CreateCreditHold(context.Background(), &clubv1.CreateCreditHoldRequest{
+		UserId: uuid.NewString(),
+		Amount: 1500,
+		Reason: "market_bid",
+		HoldId: holdID,
+	})
+	if err != nil || resp.HoldId != holdID {
+		t.Fatalf("expected hold %s, got %+v (%v)", holdID, resp, err)
+	}
+	_, err = server.CreateCreditHold(context.Background(), &clubv1.CreateCreditHoldRequest{
+		UserId: uuid.NewString(),
+		Amount: 1500,
+		Reason: "market_bid",
+		HoldId: "not-a-uuid",
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument, got %v", err)
+	}
+
+	_, err = server.CreateCreditHold(context.Background(), &clubv1.CreateCreditHoldRequest{
+		UserId: uuid.NewString(),
+		Amount: 0,
+		Reason: "market_bid",
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument, got %v", err)
+	}
+
+	server = NewGRPCServer(&fakeMyClubReader{holdErr: ErrInsufficientCredits})
+	_, err = server.CreateCreditHold(context.Background(), &clubv1.CreateCreditHoldRequest{
+		UserId: uuid.NewString(),
+		Amount: 1500,
+		Reason: "market_bid",
+	})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("expected FailedPrecondition, got %v", err)
+	}
+}
+
+// Verifica ReleaseCreditHold su hold inesistente.
+func TestReleaseCreditHoldNotFound(t *testing.T) {
+	server := NewGRPCServer(&fakeMyClubReader{releaseErr: ErrCreditHoldNotFound})
+
+	_, err := server.ReleaseCreditHold(context.Background(), &clubv1.ReleaseCreditHoldRequest{HoldId: uuid.NewString()})
+	if status.Code(err) != codes.NotFound {
+		t.Fatalf("expected NotFound, got %v", err)
+	}
+}
+
+func settleTradeRequest() *clubv1.SettleTradeRequest {
+	return &clubv1.SettleTradeRequest{
+		SellerUserId:    uuid.NewString(),
+		BuyerUserId:     uuid.NewString(),
+		UserCardId:      uuid.NewString(),
+		Amount:          2000,
+		HoldId:          uuid.NewString(),
+		TradeId:         uuid.NewString(),
+		CardLockId:      uuid.NewString(),
+		TaxBps:          500,
+		TaxAmount:       100,
+		SellerNetAmount: 1900,
+	}
+}
+
+// Verifica SettleTrade: carta singola e bundle (cards sostituisce user_card_id).
+func TestSettleTrade(t *testing.T) {
+	reader := &fakeMyClubReader{}
+	server := NewGRPCServer(reader)
+
+	req := settleTradeRequest()
+	if _, err := server.SettleTrade(context.Background(), req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(reader.trade.Cards) != 1 || reader.trade.Cards[0].UserCardID.String() != req.UserCardId || reader.trade.Amounts.Net != 1900 {
+		t.Fatalf("unexpected trade: %+v", reader.trade)
+	}
+
+	bundle := settleTradeRequest()
+	bundle.UserCardId, bundle.CardLockId = "", ""
+	bundle.Cards = []*clubv1.TradeCard{
+		{UserCardId: uuid.NewString(), CardLockId: uuid.NewString()},
+		{UserCardId: uuid.NewString(), CardLockId: uuid.NewString()},
+	}
+	if _, err := server.SettleTrade(context.Background(), bundle); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(reader.trade.Cards) != 2 || reader.trade.Cards[1].UserCardID.String() != bundle.Cards[1].UserCardId {
+		t.Fatalf("unexpected bundle trade: %+v", reader.trade)
+	}
+}
+
+// Verifica SettleTrade: importi incoerenti e mapping degli errori di dominio.
+func TestSettleTradeErrors(t *testing.T) {
+	req := settleTradeRequest()
+	req.SellerNetAmount = 2000
+	if _, err := NewGRPCServer(&fakeMyClubReader{}).SettleTrade(context.Background(), req); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument, got %v", err)
+	}
+
+	cases := []struct {
+		err  error
+		code codes.Code
+	}{
+		{ErrBuyerOwnsPlayer, codes.FailedPrecondition},
+		{ErrCreditHoldNotFound, codes.FailedPrecondition},
+		{ErrCardNotOwned, codes.FailedPrecondition},
+		{ErrTradeHoldMismatch, codes.FailedPrecondition},
+		{ErrClubNotFound, codes.NotFound},
+	}
+	for _, tc := range cases {
+		server := NewGRPCServer(&fakeMyClubReader{settleErr: tc.err})
+		_, err := server.SettleTrade(context.Background(), settleTradeRequest())
+		if status.Code(err) != tc.code {
+			t.Fatalf("%v: expected %v, got %v", tc.err, tc.code, err)
+		}
+	}
+}
+
+// Verifica DebitCredits: validazione, richiesta al dominio e mapping degli errori.
+func TestDebitCredits(t *testing.T) {
+	reader := &fakeMyClubReader{}
+	server := NewGRPCServer(reader)
+	req := &clubv1.DebitCreditsRequest{
+		UserId:      uuid.NewString(),
+		Amount:      250,
+		Reason:      "market_cancel_penalty",
+		ReferenceId: uuid.NewString(),
+	}
+	resp, err := server.DebitCredits(context.Background(), req)
+	if err != nil || !resp.Debited {
+		t.Fatalf("expected debited, got %+v (%v)", resp, err)
+	}
+	if reader.debit.Amount != 250 || reader.debit.ReferenceID != req.ReferenceId {
+		t.Fatalf("unexpected debit: %+v", reader.debit)
+	}
+
+	for _, invalid := range []*clubv1.DebitCreditsRequest{
+		{UserId: req.UserId, Amount: 0, Reason: req.Reason, ReferenceId: req.ReferenceId},
+		{UserId: req.UserId, Amount: 250, Reason: req.Reason},
+	} {
+		if _, err := server.DebitCredits(context.Background(), invalid); status.Code(err) != codes.InvalidArgument {
+			t.Fatalf("expected InvalidArgument, got %v", err)
+		}
+	}
+
+	cases := []struct {
+		err  error
+		code codes.Code
+	}{
+		{ErrInsufficientCredits, codes.FailedPrecondition},
+		{ErrDebitAmountMismatch, codes.FailedPrecondition},
+		{ErrClubNotFound, codes.NotFound},
+	}
+	for _, tc := range cases {
+		server := NewGRPCServer(&fakeMyClubReader{debitErr: tc.err})
+		if _, err := server.DebitCredits(context.Background(), req); status.Code(err) != tc.code {
+			t.Fatalf("%v: expected %v, got %v", tc.err, tc.code, err)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package club
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+// Reason delle righe ledger generate da un trade del market.
+// Il seller riceve il lordo e paga la tassa in due righe distinte:
+// la somma delle sue righe e' il netto accreditato.
+const (
+	LedgerReasonTradePurchase = "market_trade_purchase"
+	LedgerReasonTradeSale     = "market_trade_sale_gross"
+	LedgerReasonTradeTax      = "market_trade_sale_tax"
+)
+
+// LedgerReasonOpeningBalance e' il saldo di apertura scritto dalla migration 008
+// per i crediti esistenti prima che ogni variazione passasse dal ledger.
+const LedgerReasonOpeningBalance = "opening_balance"
+
+// ErrInvalidTradeAmounts indica un dettaglio tassa incoerente con il lordo.
+var ErrInvalidTradeAmounts = errors.New("invalid trade amounts")
+
+// LedgerEntry e' una riga del ledger: amount positivo = accredito, negativo = addebito.
+// ReferenceID (opzionale) e' il riferimento esterno del movimento, univoco per club e reason.
+type LedgerEntry struct {
+	ClubID      uuid.UUID
+	Amount      int64
+	Reason      string
+	ReferenceID string
+}
+
+// TradeAmounts e' il dettaglio economico di un trade (lordo pagato, tassa, netto al seller).
+type TradeAmounts struct {
+	Gross int64
+	Tax   int64
+	Net   int64
+}
+
+// NewTradeAmounts valida il dettaglio ricevuto da SettleTrade.
+// Un net a 0 con tassa a 0 (client senza tassa) vale come net = gross.
+func NewTradeAmounts(gross, tax, net int64) (TradeAmounts, error) {
+	if tax == 0 && net == 0 {
+		net = gross
+	}
+	if gross <= 0 || tax < 0 || tax > gross || net != gross-tax {
+		return TradeAmounts{}, ErrInvalidTradeAmounts
+	}
+	return TradeAmounts{Gross: gross, Tax: tax, Net: net}, nil
+}
+
+// TradeLedgerEntries ritorna le righe ledger di un trade: addebito del lordo al buyer,
+// accredito del lordo al seller e, se presente, la tassa trattenuta al seller.
+func TradeLedgerEntries(buyerClubID, sellerClubID uuid.UUID, amounts TradeAmounts) []LedgerEntry {
+	entries := []LedgerEntry{
+		{ClubID: buyerClubID, Amount: -amounts.Gross, Reason: LedgerReasonTradePurchase},
+		{ClubID: sellerClubID, Amount: amounts.Gross, Reason: LedgerReasonTradeSale},
+	}
+	if amounts.Tax > 0 {
+		entries = append(entries, LedgerEntry{ClubID: sellerClubID, Amount: -amounts.Tax, Reason: LedgerReasonTradeTax})
+	}
+	return entries
+}
+
+// Reconciliation confronta lo snapshot clubs.credits con il saldo derivato dal ledger.
+type Reconciliation struct {
+	ClubID          uuid.UUID
+	SnapshotCredits int64
+	LedgerCredits   int64
+}
+
+// Delta ritorna di quanto lo snapshot supera il ledger (negativo = snapshot in difetto).
+func (r Reconciliation) Delta() int64 {
+	return r.SnapshotCredits - r.LedgerCredits
+}
+
+// Diverged indica uno snapshot diverso dal saldo del ledger.
+func (r Reconciliation) Diverged() bool {
+	return r.Delta() != 0
+}
@@ -0,0 +1,74 @@
+package club
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+// Caso: trade tassato, il seller ha lordo e tassa su righe distinte.
+func TestTradeLedgerEntriesWithTax(t *testing.T) {
+	buyer, seller := uuid.New(), uuid.New()
+	amounts, err := NewTradeAmounts(1000, 50, 950)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	entries := TradeLedgerEntries(buyer, seller, amounts)
+	if len(entries) != 3 {
+		t.Fatalf("expected 3 ledger entries, got %d", len(entries))
+	}
+	if entries[0].ClubID != buyer || entries[0].Amount != -1000 || entries[0].Reason != LedgerReasonTradePurchase {
+		t.Fatalf("unexpected buyer entry: %+v", entries[0])
+	}
+	var sellerNet int64
+	for _, entry := range entries[1:] {
+		if entry.ClubID != seller {
+			t.Fatalf("expected seller entry, got %+v", entry)
+		}
+		sellerNet += entry.Amount
+	}
+	if sellerNet != 950 {
+		t.Fatalf("expected seller net 950, got %d", sellerNet)
+	}
+	if entries[2].Reason != LedgerReasonTradeTax {
+		t.Fatalf("expected tax reason, got %s", entries[2].Reason)
+	}
+}
+
+// Caso: trade senza tassa, nessuna riga di tassa.
+func TestTradeLedgerEntriesWithoutTax(t *testing.T) {
+	amounts, err := NewTradeAmounts(1000, 0, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if amounts.Net != 1000 {
+		t.Fatalf("expected net equal to gross, got %d", amounts.Net)
+	}
+	if entries := TradeLedgerEntries(uuid.New(), uuid.New(), amounts); len(entries) != 2 {
+		t.Fatalf("expected 2 ledger entries, got %d", len(entries))
+	}
+}
+
+// Caso: dettaglio tassa incoerente.
+func TestNewTradeAmountsInvalid(t *testing.T) {
+	cases := [][3]int64{{0, 0, 0}, {1000, -1, 1001}, {1000, 1200, -200}, {1000, 50, 1000}}
+	for _, c := range cases {
+		if _, err := NewTradeAmounts(c[0], c[1], c[2]); !errors.Is(err, ErrInvalidTradeAmounts) {
+			t.Fatalf("expected ErrInvalidTradeAmounts for %v, got %v", c, err)
+		}
+	}
+}
+
+// Caso: snapshot diverso dal ledger, delta con segno.
+func TestReconciliationDelta(t *testing.T) {
+	rec := Reconciliation{SnapshotCredits: 1200, LedgerCredits: 1000}
+	if !rec.Diverged() || rec.Delta() != 200 {
+		t.Fatalf("expected divergence of 200, got %d", rec.Delta())
+	}
+	rec = Reconciliation{SnapshotCredits: 800, LedgerCredits: 800}
+	if rec.Diverged() {
+		t.Fatalf("did not expect divergence, got %d", rec.Delta())
+	}
+}
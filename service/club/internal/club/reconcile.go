@@ -0,0 +1,107 @@
+package club
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+
+	"github.com/google/uuid"
+)
+
+// ListLedgerDivergences ritorna i club il cui snapshot credits non coincide con
+// SUM(ledger.amount). Lettura senza lock: RepairClubCredits rilegge il club sotto lock
+// prima di correggerlo.
+func (r *Repo) ListLedgerDivergences(ctx context.Context) ([]Reconciliation, error) {
+	const query = `
+SELECT c.id, c.credits, COALESCE(SUM(l.amount), 0)
+FROM clubs c
+LEFT JOIN ledger l ON l.club_id = c.id
+GROUP BY c.id, c.credits
+HAVING c.credits <> COALESCE(SUM(l.amount), 0)
+ORDER BY c.id`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		slog.Error("errore lettura divergenze ledger", "error", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []Reconciliation
+	for rows.Next() {
+		var rec Reconciliation
+		if err := rows.Scan(&rec.ClubID, &rec.SnapshotCredits, &rec.LedgerCredits); err != nil {
+			return nil, err
+		}
+		result = append(result, rec)
+	}
+	return result, rows.Err()
+}
+
+// RepairClubCredits riporta lo snapshot credits al saldo del ledger e registra la
+// correzione in credit_corrections, con la riga del club bloccata per tutta la
+// transazione. Ritorna la riconciliazione letta sotto lock: se non diverge non scrive nulla.
+func (r *Repo) RepairClubCredits(ctx context.Context, clubID uuid.UUID, note string) (Reconciliation, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return Reconciliation{}, err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	rec := Reconciliation{ClubID: clubID}
+	const selectClub = `
+SELECT credits
+FROM clubs
+WHERE id = $1
+FOR UPDATE`
+
+	err = tx.QueryRowContext(ctx, selectClub, clubID).Scan(&rec.SnapshotCredits)
+	if err == sql.ErrNoRows {
+		return Reconciliation{}, ErrClubNotFound
+	}
+	if err != nil {
+		slog.Error("errore lock club per riconciliazione", "error", err, "club_id", clubID)
+		return Reconciliation{}, err
+	}
+
+	const selectLedger = `
+SELECT COALESCE(SUM(amount), 0)
+FROM ledger
+WHERE club_id = $1`
+
+	if err := tx.QueryRowContext(ctx, selectLedger, clubID).Scan(&rec.LedgerCredits); err != nil {
+		slog.Error("errore somma ledger", "error", err, "club_id", clubID)
+		return Reconciliation{}, err
+	}
+	if !rec.Diverged() {
+		return rec, nil
+	}
+	if rec.LedgerCredits < 0 {
+		return rec, ErrNegativeLedgerBalance
+	}
+
+	const updateCredits = `
+UPDATE clubs
+SET credits = $2
+WHERE id = $1`
+
+	if _, err := tx.ExecContext(ctx, updateCredits, clubID, rec.LedgerCredits); err != nil {
+		slog.Error("errore correzione credits", "error", err, "club_id", clubID)
+		return Reconciliation{}, err
+	}
+
+	const insertCorrection = `
+INSERT INTO credit_corrections (id, club_id, snapshot_credits, ledger_credits, note, created_at)
+VALUES ($1,$2,$3,$4,$5,now())`
+
+	if _, err := tx.ExecContext(ctx, insertCorrection, uuid.New(), clubID, rec.SnapshotCredits, rec.LedgerCredits, note); err != nil {
+		slog.Error("errore insert credit correction", "error", err, "club_id", clubID)
+		return Reconciliation{}, err
+	}
+	if err := tx.Commit(); err != nil {
+		return Reconciliation{}, err
+	}
+	return rec, nil
+}
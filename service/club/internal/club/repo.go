@@ -3,22 +3,42 @@ package club
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"log/slog"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 )
 
 // Accesso dati del club su Postgres (persistence layer).
 // Qui restano le query SQL e la traduzione in tipi di dominio.
 type Club struct {
 	ID      uuid.UUID
+	UserID  uuid.UUID
 	Credits int64
+	// HeldCredits e' la somma degli hold attivi (released_at NULL).
+	HeldCredits int64
 }
 
-// ClubRepository espone le letture necessarie al dominio.
+// AvailableCredits ritorna i crediti spendibili: credits meno gli hold attivi.
+func (c Club) AvailableCredits() int64 {
+	return c.Credits - c.HeldCredits
+}
+
+// Chiave primaria di card_locks: un lock_id del chiamante inserito in parallelo per un'altra carta.
+const constraintCardLocksPkey = "card_locks_pkey"
+
+// ClubRepository espone letture e scritture necessarie al dominio.
 type ClubRepository interface {
 	GetClubByUserID(ctx context.Context, userID uuid.UUID) (Club, error)
+	GetClubByID(ctx context.Context, clubID uuid.UUID) (Club, error)
 	ListUserCardsByClubID(ctx context.Context, clubID uuid.UUID) ([]UserCard, error)
+	LockCard(ctx context.Context, clubID, userCardID, lockID uuid.UUID, reason string) (CardLock, error)
+	ReleaseCardLock(ctx context.Context, lockID uuid.UUID) error
+	CreateCreditHold(ctx context.Context, clubID, holdID uuid.UUID, amount int64, reason string) (uuid.UUID, error)
+	ReleaseCreditHold(ctx context.Context, holdID uuid.UUID) error
+	SettleTrade(ctx context.Context, trade settledTrade) (bool, error)
+	DebitCredits(ctx context.Context, clubID uuid.UUID, debit Debit) (bool, error)
 }
 
 // Repo implementa l'accesso al DB per il club.
@@ -31,15 +51,16 @@ func NewRepo(db *sql.DB) *Repo {
 	return &Repo{db: db}
 }
 
-// GetClubByUserID carica club_id e credits dal user_id.
+// GetClubByUserID carica club_id, credits e hold attivi dal user_id.
 func (r *Repo) GetClubByUserID(ctx context.Context, userID uuid.UUID) (Club, error) {
 	const query = `
-SELECT id, credits
-FROM clubs
-WHERE user_id = $1`
+SELECT c.id, c.user_id, c.credits,
+       COALESCE((SELECT SUM(h.amount) FROM credit_holds h WHERE h.club_id = c.id AND h.released_at IS NULL), 0)
+FROM clubs c
+WHERE c.user_id = $1`
 
 	var club Club
-	err := r.db.QueryRowContext(ctx, query, userID).Scan(&club.ID, &club.Credits)
+	err := r.db.QueryRowContext(ctx, query, userID).Scan(&club.ID, &club.UserID, &club.Credits, &club.HeldCredits)
 	if err == sql.ErrNoRows {
 		return Club{}, ErrClubNotFound
 	}
@@ -50,6 +71,26 @@ WHERE user_id = $1`
 	return club, nil
 }
 
+// GetClubByID carica il club dal suo id (usato per risolvere club -> user).
+func (r *Repo) GetClubByID(ctx context.Context, clubID uuid.UUID) (Club, error) {
+	const query = `
+SELECT c.id, c.user_id, c.credits,
+       COALESCE((SELECT SUM(h.amount) FROM credit_holds h WHERE h.club_id = c.id AND h.released_at IS NULL), 0)
+FROM clubs c
+WHERE c.id = $1`
+
+	var club Club
+	err := r.db.QueryRowContext(ctx, query, clubID).Scan(&club.ID, &club.UserID, &club.Credits, &club.HeldCredits)
+	if err == sql.ErrNoRows {
+		return Club{}, ErrClubNotFound
+	}
+	if err != nil {
+		slog.Error("errore lettura club per id", "error", err, "club_id", clubID)
+		return Club{}, err
+	}
+	return club, nil
+}
+
 // ListUserCardsByClubID ritorna tutte le carte del club.
 func (r *Repo) ListUserCardsByClubID(ctx context.Context, clubID uuid.UUID) ([]UserCard, error) {
 	const query = `
@@ -76,3 +117,301 @@ WHERE club_id = $1`
 	}
 	return cards, nil
 }
+
+// LockCard crea un lock attivo sulla carta del club e marca user_cards.locked nella
+// stessa transazione. La riga della carta e' bloccata (FOR UPDATE) per serializzare
+// lock concorrenti; l'indice univoco parziale su card_locks fa da ultima difesa.
+// lockID uuid.Nil = id generato qui; un lockID del chiamante gia' attivo sulla stessa
+// carta e' un retry e ritorna il lock esistente.
+func (r *Repo) LockCard(ctx context.Context, clubID, userCardID, lockID uuid.UUID, reason string) (CardLock, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return CardLock{}, err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	const selectCard = `
+SELECT club_id, locked
+FROM user_cards
+WHERE id = $1
+FOR UPDATE`
+
+	var ownerClubID uuid.UUID
+	var locked bool
+	err = tx.QueryRowContext(ctx, selectCard, userCardID).Scan(&ownerClubID, &locked)
+	if err == sql.ErrNoRows {
+		return CardLock{}, ErrCardNotFound
+	}
+	if err != nil {
+		slog.Error("errore lettura carta", "error", err, "user_card_id", userCardID)
+		return CardLock{}, err
+	}
+	if ownerClubID != clubID {
+		return CardLock{}, ErrCardNotOwned
+	}
+	if lockID == uuid.Nil {
+		lockID = uuid.New()
+	} else {
+		existing, found, err := cardLockByID(ctx, tx, lockID)
+		if err != nil {
+			return CardLock{}, err
+		}
+		if found {
+			if existing.UserCardID != userCardID {
+				return CardLock{}, ErrIDAlreadyUsed
+			}
+			return existing, nil
+		}
+	}
+	if locked {
+		return CardLock{}, ErrCardAlreadyLocked
+	}
+
+	lock := CardLock{ID: lockID, UserCardID: userCardID, Reason: reason}
+	const insertLock = `
+INSERT INTO card_locks (lock_id, user_card_id, reason, created_at)
+VALUES ($1,$2,$3,now())`
+
+	if _, err := tx.ExecContext(ctx, insertLock, lock.ID, userCardID, reason); err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+			if pqErr.Constraint == constraintCardLocksPkey {
+				return CardLock{}, ErrIDAlreadyUsed
+			}
+			return CardLock{}, ErrCardAlreadyLocked
+		}
+		slog.Error("errore insert card lock", "error", err, "user_card_id", userCardID)
+		return CardLock{}, err
+	}
+
+	const markLocked = `
+UPDATE user_cards
+SET locked = TRUE
+WHERE id = $1`
+
+	if _, err := tx.ExecContext(ctx, markLocked, userCardID); err != nil {
+		slog.Error("errore update carta locked", "error", err, "user_card_id", userCardID)
+		return CardLock{}, err
+	}
+	if err := tx.Commit(); err != nil {
+		return CardLock{}, err
+	}
+	return lock, nil
+}
+
+// cardLockByID legge il lock attivo con lock_id; un lock gia' rilasciato non puo'
+// essere ripreso da un retry e ritorna ErrIDAlreadyUsed.
+func cardLockByID(ctx context.Context, tx *sql.Tx, lockID uuid.UUID) (CardLock, bool, error) {
+	const selectLock = `
+SELECT user_card_id, reason, released_at IS NOT NULL
+FROM card_locks
+WHERE lock_id = $1`
+
+	lock := CardLock{ID: lockID}
+	var released bool
+	err := tx.QueryRowContext(ctx, selectLock, lockID).Scan(&lock.UserCardID, &lock.Reason, &released)
+	if err == sql.ErrNoRows {
+		return CardLock{}, false, nil
+	}
+	if err != nil {
+		slog.Error("errore lettura card lock", "error", err, "lock_id", lockID)
+		return CardLock{}, false, err
+	}
+	if released {
+		return CardLock{}, false, ErrIDAlreadyUsed
+	}
+	return lock, true, nil
+}
+
+// ReleaseCardLock chiude il lock e sblocca la carta nella stessa transazione.
+// Idempotente: un lock gia' rilasciato non modifica nulla e non ritorna errore.
+func (r *Repo) ReleaseCardLock(ctx context.Context, lockID uuid.UUID) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	const selectLock = `
+SELECT user_card_id, released_at IS NOT NULL
+FROM card_locks
+WHERE lock_id = $1
+FOR UPDATE`
+
+	var userCardID uuid.UUID
+	var released bool
+	err = tx.QueryRowContext(ctx, selectLock, lockID).Scan(&userCardID, &released)
+	if err == sql.ErrNoRows {
+		return ErrCardLockNotFound
+	}
+	if err != nil {
+		slog.Error("errore lettura card lock", "error", err, "lock_id", lockID)
+		return err
+	}
+	if released {
+		return nil
+	}
+
+	const releaseLock = `
+UPDATE card_locks
+SET released_at = now()
+WHERE lock_id = $1`
+
+	if _, err := tx.ExecContext(ctx, releaseLock, lockID); err != nil {
+		slog.Error("errore rilascio card lock", "error", err, "lock_id", lockID)
+		return err
+	}
+
+	const markUnlocked = `
+UPDATE user_cards
+SET locked = FALSE
+WHERE id = $1`
+
+	if _, err := tx.ExecContext(ctx, markUnlocked, userCardID); err != nil {
+		slog.Error("errore update carta unlocked", "error", err, "user_card_id", userCardID)
+		return err
+	}
+	return tx.Commit()
+}
+
+// CreateCreditHold crea un hold se credits - SUM(hold attivi) >= amount.
+// La riga del club e' bloccata (FOR UPDATE) per tutta la transazione: hold concorrenti
+// sullo stesso club vengono serializzati e non possono superare il saldo.
+// holdID uuid.Nil = id generato qui; un holdID del chiamante gia' attivo per lo stesso
+// club e importo e' un retry e non blocca altri crediti.
+func (r *Repo) CreateCreditHold(ctx context.Context, clubID, holdID uuid.UUID, amount int64, reason string) (uuid.UUID, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	const selectClub = `
+SELECT credits
+FROM clubs
+WHERE id = $1
+FOR UPDATE`
+
+	var credits int64
+	err = tx.QueryRowContext(ctx, selectClub, clubID).Scan(&credits)
+	if err == sql.ErrNoRows {
+		return uuid.Nil, ErrClubNotFound
+	}
+	if err != nil {
+		slog.Error("errore lock club", "error", err, "club_id", clubID)
+		return uuid.Nil, err
+	}
+	if holdID == uuid.Nil {
+		holdID = uuid.New()
+	} else {
+		const selectExisting = `
+SELECT club_id, amount, released_at IS NOT NULL
+FROM credit_holds
+WHERE id = $1`
+
+		var heldClubID uuid.UUID
+		var heldAmount int64
+		var released bool
+		err := tx.QueryRowContext(ctx, selectExisting, holdID).Scan(&heldClubID, &heldAmount, &released)
+		switch {
+		case err == nil:
+			if heldClubID != clubID || heldAmount != amount || released {
+				return uuid.Nil, ErrIDAlreadyUsed
+			}
+			return holdID, nil
+		case err != sql.ErrNoRows:
+			slog.Error("errore lettura credit hold", "error", err, "hold_id", holdID)
+			return uuid.Nil, err
+		}
+	}
+
+	const selectHeld = `
+SELECT COALESCE(SUM(amount), 0)
+FROM credit_holds
+WHERE club_id = $1 AND released_at IS NULL`
+
+	var held int64
+	if err := tx.QueryRowContext(ctx, selectHeld, clubID).Scan(&held); err != nil {
+		slog.Error("errore somma hold attivi", "error", err, "club_id", clubID)
+		return uuid.Nil, err
+	}
+	if credits-held < amount {
+		return uuid.Nil, ErrInsufficientCredits
+	}
+
+	const insertHold = `
+INSERT INTO credit_holds (id, club_id, amount, reason, created_at)
+VALUES ($1,$2,$3,$4,now())`
+
+	if _, err := tx.ExecContext(ctx, insertHold, holdID, clubID, amount, reason); err != nil {
+		// Stesso hold_id inserito in parallelo per un altro club.
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+			return uuid.Nil, ErrIDAlreadyUsed
+		}
+		slog.Error("errore insert credit hold", "error", err, "club_id", clubID)
+		return uuid.Nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return uuid.Nil, err
+	}
+	return holdID, nil
+}
+
+// ReleaseCreditHold valorizza released_at se l'hold e' ancora attivo.
+// Idempotente: un hold gia' rilasciato non viene modificato e non ritorna errore.
+func (r *Repo) ReleaseCreditHold(ctx context.Context, holdID uuid.UUID) error {
+	const query = `
+UPDATE credit_holds
+SET released_at = COALESCE(released_at, now())
+WHERE id = $1`
+
+	res, err := r.db.ExecContext(ctx, query, holdID)
+	if err != nil {
+		slog.Error("errore rilascio credit hold", "error", err, "hold_id", holdID)
+		return err
+	}
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return ErrCreditHoldNotFound
+	}
+	return nil
+}
+
+// appendLedgerEntry e' l'unico punto che modifica clubs.credits: aggiorna lo snapshot
+// e scrive la riga ledger nella stessa transazione, cosi' credits resta uguale a
+// SUM(ledger.amount). Il CHECK credits >= 0 fa fallire gli addebiti oltre il saldo.
+func appendLedgerEntry(ctx context.Context, tx *sql.Tx, entry LedgerEntry) error {
+	const updateCredits = `
+UPDATE clubs
+SET credits = credits + $2
+WHERE id = $1`
+
+	res, err := tx.ExecContext(ctx, updateCredits, entry.ClubID, entry.Amount)
+	if err != nil {
+		return err
+	}
+	updated, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if updated == 0 {
+		return ErrClubNotFound
+	}
+
+	const insertLedger = `
+INSERT INTO ledger (id, club_id, amount, reason, reference_id, created_at)
+VALUES ($1,$2,$3,$4,NULLIF($5, ''),now())`
+
+	_, err = tx.ExecContext(ctx, insertLedger, uuid.New(), entry.ClubID, entry.Amount, entry.Reason, entry.ReferenceID)
+	return err
+}
@@ -52,6 +52,14 @@ func TestRepoGetClubAndCards(t *testing.T) {
 		t.Fatalf("unexpected club data: %+v", club)
 	}
 
+	byID, err := repo.GetClubByID(ctx, clubID)
+	if err != nil {
+		t.Fatalf("GetClubByID: %v", err)
+	}
+	if byID.UserID != userID {
+		t.Fatalf("unexpected club owner: %+v", byID)
+	}
+
 	cards, err := repo.ListUserCardsByClubID(ctx, clubID)
 	if err != nil {
 		t.Fatalf("ListUserCardsByClubID: %v", err)
@@ -80,3 +88,391 @@ func TestRepoGetClubNotFound(t *testing.T) {
 		t.Fatalf("expected ErrClubNotFound, got %v", err)
 	}
 }
+
+// Test d'integrazione: lock carta, secondo lock rifiutato, rilascio idempotente.
+func TestRepoLockAndReleaseCard(t *testing.T) {
+	dsn := os.Getenv("CLUB_TEST_DSN")
+	if dsn == "" {
+		t.Skip("CLUB_TEST_DSN not set")
+	}
+
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	defer db.Close()
+
+	ctx := context.Background()
+	repo := NewRepo(db)
+
+	clubID := uuid.New()
+	cardID := uuid.New()
+	if _, err := db.ExecContext(ctx, `INSERT INTO clubs (id, user_id, credits) VALUES ($1,$2,$3)`, clubID, uuid.New(), 0); err != nil {
+		t.Fatalf("insert club: %v", err)
+	}
+	t.Cleanup(func() {
+		_, _ = db.ExecContext(ctx, `DELETE FROM card_locks WHERE user_card_id = $1`, cardID)
+		_, _ = db.ExecContext(ctx, `DELETE FROM user_cards WHERE club_id = $1`, clubID)
+		_, _ = db.ExecContext(ctx, `DELETE FROM clubs WHERE id = $1`, clubID)
+	})
+	if _, err := db.ExecContext(ctx, `INSERT INTO user_cards (id, club_id, player_id, locked) VALUES ($1,$2,$3,$4)`, cardID, clubID, uuid.New(), false); err != nil {
+		t.Fatalf("insert user_cards: %v", err)
+	}
+
+	if _, err := repo.LockCard(ctx, uuid.New(), cardID, uuid.Nil, "market_listing"); !errors.Is(err, ErrCardNotOwned) {
+		t.Fatalf("expected ErrCardNotOwned, got %v", err)
+	}
+	lock, err := repo.LockCard(ctx, clubID, cardID, uuid.Nil, "market_listing")
+	if err != nil {
+		t.Fatalf("LockCard: %v", err)
+	}
+	if _, err := repo.LockCard(ctx, clubID, cardID, uuid.Nil, "market_listing"); !errors.Is(err, ErrCardAlreadyLocked) {
+		t.Fatalf("expected ErrCardAlreadyLocked, got %v", err)
+	}
+	// Retry con lo stesso lock_id: ritorna il lock attivo.
+	if replay, err := repo.LockCard(ctx, clubID, cardID, lock.ID, "market_listing"); err != nil || replay.ID != lock.ID {
+		t.Fatalf("expected replay of lock %s, got %+v (%v)", lock.ID, replay, err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if err := repo.ReleaseCardLock(ctx, lock.ID); err != nil {
+			t.Fatalf("ReleaseCardLock #%d: %v", i+1, err)
+		}
+	}
+	if _, err := repo.LockCard(ctx, clubID, cardID, lock.ID, "market_listing"); !errors.Is(err, ErrIDAlreadyUsed) {
+		t.Fatalf("expected ErrIDAlreadyUsed for released lock_id, got %v", err)
+	}
+	var locked bool
+	if err := db.QueryRowContext(ctx, `SELECT locked FROM user_cards WHERE id = $1`, cardID).Scan(&locked); err != nil {
+		t.Fatalf("select locked: %v", err)
+	}
+	if locked {
+		t.Fatalf("expected card to be unlocked")
+	}
+	if err := repo.ReleaseCardLock(ctx, uuid.New()); !errors.Is(err, ErrCardLockNotFound) {
+		t.Fatalf("expected ErrCardLockNotFound, got %v", err)
+	}
+}
+
+// Test d'integrazione: hold oltre il saldo disponibile rifiutato, rilascio idempotente.
+func TestRepoCreditHolds(t *testing.T) {
+	dsn := os.Getenv("CLUB_TEST_DSN")
+	if dsn == "" {
+		t.Skip("CLUB_TEST_DSN not set")
+	}
+
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	defer db.Close()
+
+	ctx := context.Background()
+	repo := NewRepo(db)
+
+	clubID := uuid.New()
+	userID := uuid.New()
+	if _, err := db.ExecContext(ctx, `INSERT INTO clubs (id, user_id, credits) VALUES ($1,$2,$3)`, clubID, userID, 1000); err != nil {
+		t.Fatalf("insert club: %v", err)
+	}
+	t.Cleanup(func() {
+		_, _ = db.ExecContext(ctx, `DELETE FROM credit_holds WHERE club_id = $1`, clubID)
+		_, _ = db.ExecContext(ctx, `DELETE FROM clubs WHERE id = $1`, clubID)
+	})
+
+	holdID, err := repo.CreateCreditHold(ctx, clubID, uuid.Nil, 700, "market_bid")
+	if err != nil {
+		t.Fatalf("CreateCreditHold: %v", err)
+	}
+	if _, err := repo.CreateCreditHold(ctx, clubID, uuid.Nil, 400, "market_bid"); !errors.Is(err, ErrInsufficientCredits) {
+		t.Fatalf("expected ErrInsufficientCredits, got %v", err)
+	}
+	// Retry con lo stesso hold_id: nessun nuovo hold, importo diverso rifiutato.
+	if replay, err := repo.CreateCreditHold(ctx, clubID, holdID, 700, "market_bid"); err != nil || replay != holdID {
+		t.Fatalf("expected replay of hold %s, got %s (%v)", holdID, replay, err)
+	}
+	if _, err := repo.CreateCreditHold(ctx, clubID, holdID, 300, "market_bid"); !errors.Is(err, ErrIDAlreadyUsed) {
+		t.Fatalf("expected ErrIDAlreadyUsed, got %v", err)
+	}
+	club, err := repo.GetClubByUserID(ctx, userID)
+	if err != nil {
+		t.Fatalf("GetClubByUserID: %v", err)
+	}
+	if club.Credits != 1000 || club.AvailableCredits() != 300 {
+		t.Fatalf("unexpected balances: %+v", club)
+	}
+
+	for i := 0; i < 2; i++ {
+		if err := repo.ReleaseCreditHold(ctx, holdID); err != nil {
+			t.Fatalf("ReleaseCreditHold #%d: %v", i+1, err)
+		}
+	}
+	if _, err := repo.CreateCreditHold(ctx, clubID, uuid.Nil, 1000, "market_bid"); err != nil {
+		t.Fatalf("expected hold after release, got %v", err)
+	}
+	if err := repo.ReleaseCreditHold(ctx, uuid.New()); !errors.Is(err, ErrCreditHoldNotFound) {
+		t.Fatalf("expected ErrCreditHoldNotFound, got %v", err)
+	}
+}
+
+// Test d'integrazione: settlement completo, retry idempotente, retry con altro hold e buyer con lo stesso giocatore.
+func TestRepoSettleTrade(t *testing.T) {
+	dsn := os.Getenv("CLUB_TEST_DSN")
+	if dsn == "" {
+		t.Skip("CLUB_TEST_DSN not set")
+	}
+
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	defer db.Close()
+
+	ctx := context.Background()
+	repo := NewRepo(db)
+
+	buyerClubID, sellerClubID := uuid.New(), uuid.New()
+	cardID, playerID := uuid.New(), uuid.New()
+	tradeID := uuid.New()
+	for _, c := range []struct {
+		id      uuid.UUID
+		credits int64
+	}{{buyerClubID, 5000}, {sellerClubID, 0}} {
+		if _, err := db.ExecContext(ctx, `INSERT INTO clubs (id, user_id, credits) VALUES ($1,$2,$3)`, c.id, uuid.New(), c.credits); err != nil {
+			t.Fatalf("insert club: %v", err)
+		}
+	}
+	t.Cleanup(func() {
+		for _, clubID := range []uuid.UUID{buyerClubID, sellerClubID} {
+			_, _ = db.ExecContext(ctx, `DELETE FROM settled_trades WHERE buyer_club_id = $1 OR seller_club_id = $1`, clubID)
+			_, _ = db.ExecContext(ctx, `DELETE FROM ledger WHERE club_id = $1`, clubID)
+			_, _ = db.ExecContext(ctx, `DELETE FROM credit_holds WHERE club_id = $1`, clubID)
+		}
+		_, _ = db.ExecContext(ctx, `DELETE FROM card_locks WHERE user_card_id = $1`, cardID)
+		for _, clubID := range []uuid.UUID{buyerClubID, sellerClubID} {
+			_, _ = db.ExecContext(ctx, `DELETE FROM user_cards WHERE club_id = $1`, clubID)
+			_, _ = db.ExecContext(ctx, `DELETE FROM clubs WHERE id = $1`, clubID)
+		}
+	})
+	if _, err := db.ExecContext(ctx, `INSERT INTO user_cards (id, club_id, player_id, locked) VALUES ($1,$2,$3,$4)`, cardID, sellerClubID, playerID, false); err != nil {
+		t.Fatalf("insert user_cards: %v", err)
+	}
+	lock, err := repo.LockCard(ctx, sellerClubID, cardID, uuid.Nil, "market_listing")
+	if err != nil {
+		t.Fatalf("LockCard: %v", err)
+	}
+	holdID, err := repo.CreateCreditHold(ctx, buyerClubID, uuid.Nil, 2000, "market_buy_now")
+	if err != nil {
+		t.Fatalf("CreateCreditHold: %v", err)
+	}
+
+	trade := settledTrade{
+		TradeSettlement: TradeSettlement{
+			TradeID: tradeID,
+			HoldID:  holdID,
+			Amounts: TradeAmounts{Gross: 2000, Tax: 100, Net: 1900},
+			Cards:   []TradeCard{{UserCardID: cardID, LockID: lock.ID}},
+		},
+		BuyerClubID:  buyerClubID,
+		SellerClubID: sellerClubID,
+	}
+	for i, want := range []bool{true, false} {
+		settled, err := repo.SettleTrade(ctx, trade)
+		if err != nil {
+			t.Fatalf("SettleTrade #%d: %v", i+1, err)
+		}
+		if settled != want {
+			t.Fatalf("SettleTrade #%d: expected settled=%v, got %v", i+1, want, settled)
+		}
+	}
+	retry := trade
+	retry.HoldID = uuid.New()
+	if _, err := repo.SettleTrade(ctx, retry); !errors.Is(err, ErrTradeHoldMismatch) {
+		t.Fatalf("expected ErrTradeHoldMismatch, got %v", err)
+	}
+
+	buyer, err := repo.GetClubByID(ctx, buyerClubID)
+	if err != nil {
+		t.Fatalf("GetClubByID: %v", err)
+	}
+	seller, err := repo.GetClubByID(ctx, sellerClubID)
+	if err != nil {
+		t.Fatalf("GetClubByID: %v", err)
+	}
+	if buyer.Credits != 3000 || buyer.AvailableCredits() != 3000 || seller.Credits != 1900 {
+		t.Fatalf("unexpected balances: buyer=%+v seller=%+v", buyer, seller)
+	}
+	var ownerClubID uuid.UUID
+	var locked bool
+	if err := db.QueryRowContext(ctx, `SELECT club_id, locked FROM user_cards WHERE id = $1`, cardID).Scan(&ownerClubID, &locked); err != nil {
+		t.Fatalf("select card: %v", err)
+	}
+	if ownerClubID != buyerClubID || locked {
+		t.Fatalf("expected unlocked card owned by buyer, got club=%s locked=%v", ownerClubID, locked)
+	}
+
+	// Il buyer possiede ora il giocatore: una seconda carta dello stesso giocatore e' rifiutata.
+	otherCardID := uuid.New()
+	if _, err := db.ExecContext(ctx, `INSERT INTO user_cards (id, club_id, player_id, locked) VALUES ($1,$2,$3,$4)`, otherCardID, sellerClubID, playerID, false); err != nil {
+		t.Fatalf("insert user_cards: %v", err)
+	}
+	holdID, err = repo.CreateCreditHold(ctx, buyerClubID, uuid.Nil, 1000, "market_buy_now")
+	if err != nil {
+		t.Fatalf("CreateCreditHold: %v", err)
+	}
+	trade.TradeID = uuid.New()
+	trade.HoldID = holdID
+	trade.Amounts = TradeAmounts{Gross: 1000, Net: 1000}
+	trade.Cards = []TradeCard{{UserCardID: otherCardID}}
+	if _, err := repo.SettleTrade(ctx, trade); !errors.Is(err, ErrBuyerOwnsPlayer) {
+		t.Fatalf("expected ErrBuyerOwnsPlayer, got %v", err)
+	}
+}
+
+// Test d'integrazione: snapshot divergente segnalato e riportato al saldo del ledger.
+func TestRepoReconcileCredits(t *testing.T) {
+	dsn := os.Getenv("CLUB_TEST_DSN")
+	if dsn == "" {
+		t.Skip("CLUB_TEST_DSN not set")
+	}
+
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	defer db.Close()
+
+	ctx := context.Background()
+	repo := NewRepo(db)
+
+	clubID := uuid.New()
+	if _, err := db.ExecContext(ctx, `INSERT INTO clubs (id, user_id, credits) VALUES ($1,$2,$3)`, clubID, uuid.New(), 1500); err != nil {
+		t.Fatalf("insert club: %v", err)
+	}
+	t.Cleanup(func() {
+		_, _ = db.ExecContext(ctx, `DELETE FROM credit_corrections WHERE club_id = $1`, clubID)
+		_, _ = db.ExecContext(ctx, `DELETE FROM ledger WHERE club_id = $1`, clubID)
+		_, _ = db.ExecContext(ctx, `DELETE FROM clubs WHERE id = $1`, clubID)
+	})
+	if _, err := db.ExecContext(ctx, `INSERT INTO ledger (id, club_id, amount, reason) VALUES ($1,$2,$3,$4)`, uuid.New(), clubID, 1000, LedgerReasonOpeningBalance); err != nil {
+		t.Fatalf("insert ledger: %v", err)
+	}
+
+	divergences, err := repo.ListLedgerDivergences(ctx)
+	if err != nil {
+		t.Fatalf("ListLedgerDivergences: %v", err)
+	}
+	found := false
+	for _, rec := range divergences {
+		if rec.ClubID == clubID {
+			found = rec.SnapshotCredits == 1500 && rec.LedgerCredits == 1000
+		}
+	}
+	if !found {
+		t.Fatalf("expected divergence for club %s, got %+v", clubID, divergences)
+	}
+
+	rec, err := repo.RepairClubCredits(ctx, clubID, "test")
+	if err != nil {
+		t.Fatalf("RepairClubCredits: %v", err)
+	}
+	if rec.Delta() != 500 {
+		t.Fatalf("expected delta 500, got %+v", rec)
+	}
+	club, err := repo.GetClubByID(ctx, clubID)
+	if err != nil {
+		t.Fatalf("GetClubByID: %v", err)
+	}
+	if club.Credits != 1000 {
+		t.Fatalf("expected credits 1000 after repair, got %d", club.Credits)
+	}
+	var corrections int
+	if err := db.QueryRowContext(ctx, `SELECT COUNT(*) FROM credit_corrections WHERE club_id = $1`, clubID).Scan(&corrections); err != nil {
+		t.Fatalf("count corrections: %v", err)
+	}
+	if corrections != 1 {
+		t.Fatalf("expected one correction, got %d", corrections)
+	}
+
+	// Saldo ledger negativo: lo snapshot non viene toccato.
+	if _, err := db.ExecContext(ctx, `INSERT INTO ledger (id, club_id, amount, reason) VALUES ($1,$2,$3,$4)`, uuid.New(), clubID, -1200, LedgerReasonOpeningBalance); err != nil {
+		t.Fatalf("insert negative ledger: %v", err)
+	}
+	if _, err := repo.RepairClubCredits(ctx, clubID, "test"); !errors.Is(err, ErrNegativeLedgerBalance) {
+		t.Fatalf("expected ErrNegativeLedgerBalance, got %v", err)
+	}
+	club, err = repo.GetClubByID(ctx, clubID)
+	if err != nil {
+		t.Fatalf("GetClubByID: %v", err)
+	}
+	if club.Credits != 1000 {
+		t.Fatalf("expected credits to stay 1000 with a negative ledger, got %d", club.Credits)
+	}
+}
+
+// Test d'integrazione: addebito sul ledger, retry idempotente e saldo disponibile insufficiente.
+func TestRepoDebitCredits(t *testing.T) {
+	dsn := os.Getenv("CLUB_TEST_DSN")
+	if dsn == "" {
+		t.Skip("CLUB_TEST_DSN not set")
+	}
+
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	defer db.Close()
+
+	ctx := context.Background()
+	repo := NewRepo(db)
+
+	clubID := uuid.New()
+	if _, err := db.ExecContext(ctx, `INSERT INTO clubs (id, user_id, credits) VALUES ($1,$2,$3)`, clubID, uuid.New(), 0); err != nil {
+		t.Fatalf("insert club: %v", err)
+	}
+	t.Cleanup(func() {
+		_, _ = db.ExecContext(ctx, `DELETE FROM ledger WHERE club_id = $1`, clubID)
+		_, _ = db.ExecContext(ctx, `DELETE FROM credit_holds WHERE club_id = $1`, clubID)
+		_, _ = db.ExecContext(ctx, `DELETE FROM clubs WHERE id = $1`, clubID)
+	})
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		t.Fatalf("begin: %v", err)
+	}
+	if err := appendLedgerEntry(ctx, tx, LedgerEntry{ClubID: clubID, Amount: 1000, Reason: LedgerReasonOpeningBalance}); err != nil {
+		t.Fatalf("appendLedgerEntry: %v", err)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatalf("commit: %v", err)
+	}
+	if _, err := db.ExecContext(ctx, `INSERT INTO credit_holds (id, club_id, amount, reason) VALUES ($1,$2,$3,$4)`, uuid.New(), clubID, 600, "market_bid"); err != nil {
+		t.Fatalf("insert hold: %v", err)
+	}
+
+	debit := Debit{Amount: 300, Reason: "market_cancel_penalty", ReferenceID: uuid.NewString()}
+	for i, want := range []bool{true, false} {
+		debited, err := repo.DebitCredits(ctx, clubID, debit)
+		if err != nil {
+			t.Fatalf("DebitCredits #%d: %v", i+1, err)
+		}
+		if debited != want {
+			t.Fatalf("DebitCredits #%d: expected debited=%v, got %v", i+1, want, debited)
+		}
+	}
+	// Restano 700 crediti con 600 in hold: 100 disponibili.
+	if _, err := repo.DebitCredits(ctx, clubID, Debit{Amount: 200, Reason: "market_cancel_penalty", ReferenceID: uuid.NewString()}); !errors.Is(err, ErrInsufficientCredits) {
+		t.Fatalf("expected ErrInsufficientCredits, got %v", err)
+	}
+	debit.Amount = 50
+	if _, err := repo.DebitCredits(ctx, clubID, debit); !errors.Is(err, ErrDebitAmountMismatch) {
+		t.Fatalf("expected ErrDebitAmountMismatch, got %v", err)
+	}
+
+	var credits, ledgerCredits int64
+	if err := db.QueryRowContext(ctx, `SELECT credits, (SELECT SUM(amount) FROM ledger WHERE club_id = $1) FROM clubs WHERE id = $1`, clubID).Scan(&credits, &ledgerCredits); err != nil {
+		t.Fatalf("select balances: %v", err)
+	}
+	if credits != 700 || ledgerCredits != 700 {
+		t.Fatalf("expected snapshot and ledger at 700, got %d/%d", credits, ledgerCredits)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"log/slog"
 
 	"github.com/google/uuid"
 )
@@ -35,8 +36,113 @@ func (s *Service) GetMyClub(ctx context.Context, userID uuid.UUID) (*MyClub, err
 	}
 
 	return &MyClub{
-		ClubID:  club.ID,
-		Credits: club.Credits,
-		Cards:   cards,
+		ClubID:           club.ID,
+		Credits:          club.Credits,
+		AvailableCredits: club.AvailableCredits(),
+		Cards:            cards,
 	}, nil
 }
+
+// GetClub carica il club dell'utente con saldo totale e disponibile.
+func (s *Service) GetClub(ctx context.Context, userID uuid.UUID) (Club, error) {
+	club, err := s.repo.GetClubByUserID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) || errors.Is(err, ErrClubNotFound) {
+			return Club{}, ErrClubNotFound
+		}
+		return Club{}, err
+	}
+	return club, nil
+}
+
+// GetClubByID carica il club dal suo id, mappando l'assenza in ErrClubNotFound.
+func (s *Service) GetClubByID(ctx context.Context, clubID uuid.UUID) (Club, error) {
+	club, err := s.repo.GetClubByID(ctx, clubID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) || errors.Is(err, ErrClubNotFound) {
+			return Club{}, ErrClubNotFound
+		}
+		return Club{}, err
+	}
+	return club, nil
+}
+
+// LockCard blocca una carta del club dell'utente: la carta deve appartenere al suo club.
+// lockID uuid.Nil lascia generare l'id al repository.
+func (s *Service) LockCard(ctx context.Context, userID, userCardID, lockID uuid.UUID, reason string) (CardLock, error) {
+	club, err := s.repo.GetClubByUserID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) || errors.Is(err, ErrClubNotFound) {
+			return CardLock{}, ErrClubNotFound
+		}
+		return CardLock{}, err
+	}
+	return s.repo.LockCard(ctx, club.ID, userCardID, lockID, reason)
+}
+
+// ReleaseCardLock rilascia il lock; un lock gia' rilasciato non e' un errore.
+func (s *Service) ReleaseCardLock(ctx context.Context, lockID uuid.UUID) error {
+	return s.repo.ReleaseCardLock(ctx, lockID)
+}
+
+// CreateCreditHold blocca amount crediti del club dell'utente.
+// Il controllo sul saldo disponibile avviene nel repository sotto lock del club.
+// holdID uuid.Nil lascia generare l'id al repository.
+func (s *Service) CreateCreditHold(ctx context.Context, userID, holdID uuid.UUID, amount int64, reason string) (uuid.UUID, error) {
+	club, err := s.GetClub(ctx, userID)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	return s.repo.CreateCreditHold(ctx, club.ID, holdID, amount, reason)
+}
+
+// ReleaseCreditHold rilascia l'hold; un hold gia' rilasciato non e' un errore.
+func (s *Service) ReleaseCreditHold(ctx context.Context, holdID uuid.UUID) error {
+	return s.repo.ReleaseCreditHold(ctx, holdID)
+}
+
+// SettleTrade risolve i club di buyer e seller e regola il trade in una transazione.
+// Un trade_id gia' regolato non e' un errore: il market lo ripete dopo crash o timeout.
+func (s *Service) SettleTrade(ctx context.Context, trade TradeSettlement) error {
+	buyer, err := s.GetClub(ctx, trade.BuyerUserID)
+	if err != nil {
+		return err
+	}
+	seller, err := s.GetClub(ctx, trade.SellerUserID)
+	if err != nil {
+		return err
+	}
+	if buyer.ID == seller.ID {
+		return ErrSelfTrade
+	}
+
+	settled, err := s.repo.SettleTrade(ctx, settledTrade{
+		TradeSettlement: trade,
+		BuyerClubID:     buyer.ID,
+		SellerClubID:    seller.ID,
+	})
+	if err != nil {
+		return err
+	}
+	if !settled {
+		slog.Info("trade gia' regolato", "trade_id", trade.TradeID)
+	}
+	return nil
+}
+
+// DebitCredits addebita crediti al club dell'utente; un retry con lo stesso
+// reference_id non addebita di nuovo.
+func (s *Service) DebitCredits(ctx context.Context, userID uuid.UUID, debit Debit) error {
+	club, err := s.GetClub(ctx, userID)
+	if err != nil {
+		return err
+	}
+	debited, err := s.repo.DebitCredits(ctx, club.ID, debit)
+	if err != nil {
+		return err
+	}
+	if !debited {
+		slog.Info("addebito gia' registrato", "club_id", club.ID, "reference_id", debit.ReferenceID)
+	}
+	return nil
+}
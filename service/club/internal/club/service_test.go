@@ -11,13 +11,33 @@ import (
 
 // fakeRepo simula il repository per testare la logica di dominio.
 type fakeRepo struct {
-	club     Club
-	cards    []UserCard
-	clubErr  error
-	cardsErr error
+	club        Club
+	clubsByUser map[uuid.UUID]Club // se valorizzato, un club diverso per user_id
+	cards       []UserCard
+	clubErr     error
+	cardsErr    error
+	lockErr     error
+	lockClubID  uuid.UUID
+	releaseErr  error
+	holdErr     error
+	holdClubID  uuid.UUID
+	settleErr   error
+	settled     []settledTrade
+	debitErr    error
+	debits      []Debit
 }
 
-func (f *fakeRepo) GetClubByUserID(_ context.Context, _ uuid.UUID) (Club, error) {
+func (f *fakeRepo) GetClubByUserID(_ context.Context, userID uuid.UUID) (Club, error) {
+	if f.clubErr != nil {
+		return Club{}, f.clubErr
+	}
+	if club, ok := f.clubsByUser[userID]; ok {
+		return club, nil
+	}
+	return f.club, nil
+}
+
+func (f *fakeRepo) GetClubByID(_ context.Context, _ uuid.UUID) (Club, error) {
 	if f.clubErr != nil {
 		return Club{}, f.clubErr
 	}
@@ -31,6 +51,68 @@ func (f *fakeRepo) ListUserCardsByClubID(_ context.Context, _ uuid.UUID) ([]User
 	return f.cards, nil
 }
 
+func (f *fakeRepo) LockCard(_ context.Context, clubID, userCardID, lockID uuid.UUID, reason string) (CardLock, error) {
+	if f.lockErr != nil {
+		return CardLock{}, f.lockErr
+	}
+	f.lockClubID = clubID
+	if lockID == uuid.Nil {
+		lockID = uuid.New()
+	}
+	return CardLock{ID: lockID, UserCardID: userCardID, Reason: reason}, nil
+}
+
+func (f *fakeRepo) ReleaseCardLock(_ context.Context, _ uuid.UUID) error {
+	return f.releaseErr
+}
+
+func (f *fakeRepo) CreateCreditHold(_ context.Context, clubID, holdID uuid.UUID, _ int64, _ string) (uuid.UUID, error) {
+	if f.holdErr != nil {
+		return uuid.Nil, f.holdErr
+	}
+	f.holdClubID = clubID
+	if holdID == uuid.Nil {
+		holdID = uuid.New()
+	}
+	return holdID, nil
+}
+
+func (f *fakeRepo) ReleaseCreditHold(_ context.Context, _ uuid.UUID) error {
+	return f.releaseErr
+}
+
+func (f *fakeRepo) SettleTrade(_ context.Context, trade settledTrade) (bool, error) {
+	if f.settleErr != nil {
+		return false, f.settleErr
+	}
+	for _, done := range f.settled {
+		if done.TradeID == trade.TradeID {
+			if done.HoldID != trade.HoldID || done.BuyerClubID != trade.BuyerClubID {
+				return false, ErrTradeHoldMismatch
+			}
+			return false, nil
+		}
+	}
+	f.settled = append(f.settled, trade)
+	return true, nil
+}
+
+func (f *fakeRepo) DebitCredits(_ context.Context, _ uuid.UUID, debit Debit) (bool, error) {
+	if f.debitErr != nil {
+		return false, f.debitErr
+	}
+	for _, done := range f.debits {
+		if done.Reason == debit.Reason && done.ReferenceID == debit.ReferenceID {
+			if done.Amount != debit.Amount {
+				return false, ErrDebitAmountMismatch
+			}
+			return false, nil
+		}
+	}
+	f.debits = append(f.debits, debit)
+	return true, nil
+}
+
 // Caso: club esistente con carte.
 func TestServiceGetMyClubOK(t *testing.T) {
 	clubID := uuid.New()
@@ -103,3 +185,130 @@ func TestServiceGetMyClubDBError(t *testing.T) {
 		t.Fatalf("expected generic error, got %v", err)
 	}
 }
+
+// Caso: LockCard usa il club risolto dall'utente.
+func TestServiceLockCardUsesCallerClub(t *testing.T) {
+	clubID := uuid.New()
+	repo := &fakeRepo{club: Club{ID: clubID}}
+	service := NewService(repo)
+
+	lock, err := service.LockCard(context.Background(), uuid.New(), uuid.New(), uuid.Nil, "market_listing")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if lock.ID == uuid.Nil || repo.lockClubID != clubID {
+		t.Fatalf("expected lock on club %s, got %+v (club %s)", clubID, lock, repo.lockClubID)
+	}
+}
+
+// Caso: LockCard senza club per l'utente.
+func TestServiceLockCardClubNotFound(t *testing.T) {
+	repo := &fakeRepo{clubErr: sql.ErrNoRows}
+	service := NewService(repo)
+
+	_, err := service.LockCard(context.Background(), uuid.New(), uuid.New(), uuid.Nil, "market_listing")
+	if !errors.Is(err, ErrClubNotFound) {
+		t.Fatalf("expected ErrClubNotFound, got %v", err)
+	}
+}
+
+// Caso: il saldo disponibile esclude gli hold attivi.
+func TestServiceGetMyClubAvailableCredits(t *testing.T) {
+	repo := &fakeRepo{club: Club{ID: uuid.New(), Credits: 1000, HeldCredits: 300}}
+	service := NewService(repo)
+
+	result, err := service.GetMyClub(context.Background(), uuid.New())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Credits != 1000 || result.AvailableCredits != 700 {
+		t.Fatalf("expected credits 1000 / available 700, got %d / %d", result.Credits, result.AvailableCredits)
+	}
+}
+
+// Caso: CreateCreditHold usa il club risolto dall'utente e propaga il saldo insufficiente.
+func TestServiceCreateCreditHold(t *testing.T) {
+	clubID := uuid.New()
+	repo := &fakeRepo{club: Club{ID: clubID}}
+	service := NewService(repo)
+
+	if _, err := service.CreateCreditHold(context.Background(), uuid.New(), uuid.Nil, 100, "market_bid"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if repo.holdClubID != clubID {
+		t.Fatalf("expected hold on club %s, got %s", clubID, repo.holdClubID)
+	}
+
+	repo.holdErr = ErrInsufficientCredits
+	if _, err := service.CreateCreditHold(context.Background(), uuid.New(), uuid.Nil, 100, "market_bid"); !errors.Is(err, ErrInsufficientCredits) {
+		t.Fatalf("expected ErrInsufficientCredits, got %v", err)
+	}
+}
+
+// Caso: SettleTrade ripetuto con lo stesso trade_id regola una sola volta.
+func TestServiceSettleTradeIdempotent(t *testing.T) {
+	buyerUserID, sellerUserID := uuid.New(), uuid.New()
+	repo := &fakeRepo{clubsByUser: map[uuid.UUID]Club{
+		buyerUserID:  {ID: uuid.New()},
+		sellerUserID: {ID: uuid.New()},
+	}}
+	service := NewService(repo)
+
+	trade := TradeSettlement{
+		TradeID:      uuid.New(),
+		BuyerUserID:  buyerUserID,
+		SellerUserID: sellerUserID,
+		HoldID:       uuid.New(),
+		Amounts:      TradeAmounts{Gross: 1000, Net: 1000},
+		Cards:        []TradeCard{{UserCardID: uuid.New(), LockID: uuid.New()}},
+	}
+	for i := 0; i < 2; i++ {
+		if err := service.SettleTrade(context.Background(), trade); err != nil {
+			t.Fatalf("settle #%d: unexpected error: %v", i+1, err)
+		}
+	}
+	if len(repo.settled) != 1 {
+		t.Fatalf("expected one settled trade, got %d", len(repo.settled))
+	}
+	settled := repo.settled[0]
+	if settled.BuyerClubID != repo.clubsByUser[buyerUserID].ID || settled.SellerClubID != repo.clubsByUser[sellerUserID].ID {
+		t.Fatalf("unexpected clubs: %+v", settled)
+	}
+
+	// Retry con un nuovo hold: non e' un replay, il market deve rilasciarlo.
+	trade.HoldID = uuid.New()
+	if err := service.SettleTrade(context.Background(), trade); !errors.Is(err, ErrTradeHoldMismatch) {
+		t.Fatalf("expected ErrTradeHoldMismatch, got %v", err)
+	}
+}
+
+// Caso: buyer e seller nello stesso club.
+func TestServiceSettleTradeSelfTrade(t *testing.T) {
+	service := NewService(&fakeRepo{club: Club{ID: uuid.New()}})
+
+	err := service.SettleTrade(context.Background(), TradeSettlement{TradeID: uuid.New(), BuyerUserID: uuid.New(), SellerUserID: uuid.New()})
+	if !errors.Is(err, ErrSelfTrade) {
+		t.Fatalf("expected ErrSelfTrade, got %v", err)
+	}
+}
+
+// Caso: DebitCredits ripetuto con lo stesso reference_id addebita una sola volta.
+func TestServiceDebitCreditsIdempotent(t *testing.T) {
+	repo := &fakeRepo{club: Club{ID: uuid.New()}}
+	service := NewService(repo)
+
+	debit := Debit{Amount: 100, Reason: "market_cancel_penalty", ReferenceID: uuid.NewString()}
+	for i := 0; i < 2; i++ {
+		if err := service.DebitCredits(context.Background(), uuid.New(), debit); err != nil {
+			t.Fatalf("debit #%d: unexpected error: %v", i+1, err)
+		}
+	}
+	if len(repo.debits) != 1 {
+		t.Fatalf("expected one debit, got %d", len(repo.debits))
+	}
+
+	debit.Amount = 200
+	if err := service.DebitCredits(context.Background(), uuid.New(), debit); !errors.Is(err, ErrDebitAmountMismatch) {
+		t.Fatalf("expected ErrDebitAmountMismatch, got %v", err)
+	}
+}
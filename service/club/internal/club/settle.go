@@ -0,0 +1,212 @@
+package club
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// Vincolo di user_cards: un club possiede al massimo una carta per giocatore.
+const constraintUserCardsClubPlayer = "uq_user_cards_club_player"
+
+// TradeSettlement e' un trade del market da regolare in club-svc.
+type TradeSettlement struct {
+	TradeID      uuid.UUID
+	BuyerUserID  uuid.UUID
+	SellerUserID uuid.UUID
+	HoldID       uuid.UUID
+	Amounts      TradeAmounts
+	Cards        []TradeCard
+}
+
+// TradeCard e' una carta del trade con il lock preso alla creazione del listing.
+// LockID uuid.Nil = carta senza lock (listing storici): deve essere sbloccata.
+type TradeCard struct {
+	UserCardID uuid.UUID
+	LockID     uuid.UUID
+}
+
+// settledTrade e' il trade con i club gia' risolti, come lo scrive il repository.
+type settledTrade struct {
+	TradeSettlement
+	BuyerClubID  uuid.UUID
+	SellerClubID uuid.UUID
+}
+
+// SettleTrade regola il trade in una sola transazione:
+// registra trade_id, consuma l'hold del buyer, sposta i crediti con le righe ledger
+// e passa le carte al buyer rilasciando i lock. Ritorna false se trade_id era gia'
+// regolato con lo stesso hold (retry del market): in quel caso non modifica nulla.
+// Un retry con hold_id diverso ritorna ErrTradeHoldMismatch.
+func (r *Repo) SettleTrade(ctx context.Context, trade settledTrade) (bool, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	// 1) Idempotenza: un trade_id gia' presente chiude qui. Un settlement concorrente
+	// sullo stesso trade_id attende il commit dell'altro e poi non inserisce nulla.
+	const insertTrade = `
+INSERT INTO settled_trades (trade_id, buyer_club_id, seller_club_id, hold_id, amount, tax_amount, created_at)
+VALUES ($1,$2,$3,$4,$5,$6,now())
+ON CONFLICT (trade_id) DO NOTHING`
+
+	res, err := tx.ExecContext(ctx, insertTrade, trade.TradeID, trade.BuyerClubID, trade.SellerClubID, trade.HoldID, trade.Amounts.Gross, trade.Amounts.Tax)
+	if err != nil {
+		slog.Error("errore insert settled trade", "error", err, "trade_id", trade.TradeID)
+		return false, err
+	}
+	inserted, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	if inserted == 0 {
+		return false, checkSettledHold(ctx, tx, trade)
+	}
+
+	// 2) Lock dei due club in ordine di id, cosi' trade incrociati non vanno in deadlock.
+	const lockClubs = `
+SELECT id
+FROM clubs
+WHERE id IN ($1, $2)
+ORDER BY id
+FOR UPDATE`
+
+	rows, err := tx.QueryContext(ctx, lockClubs, trade.BuyerClubID, trade.SellerClubID)
+	if err != nil {
+		slog.Error("errore lock club del trade", "error", err, "trade_id", trade.TradeID)
+		return false, err
+	}
+	if err := rows.Close(); err != nil {
+		return false, err
+	}
+
+	// 3) Consuma l'hold del buyer: deve essere suo e ancora attivo.
+	const consumeHold = `
+UPDATE credit_holds
+SET released_at = now()
+WHERE id = $1 AND club_id = $2 AND released_at IS NULL`
+
+	res, err = tx.ExecContext(ctx, consumeHold, trade.HoldID, trade.BuyerClubID)
+	if err != nil {
+		slog.Error("errore consumo credit hold", "error", err, "hold_id", trade.HoldID)
+		return false, err
+	}
+	consumed, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	if consumed == 0 {
+		return false, ErrCreditHoldNotFound
+	}
+
+	// 4) Con l'hold consumato, il saldo disponibile del buyer deve coprire il lordo.
+	const selectAvailable = `
+SELECT c.credits - COALESCE((SELECT SUM(h.amount) FROM credit_holds h WHERE h.club_id = c.id AND h.released_at IS NULL), 0)
+FROM clubs c
+WHERE c.id = $1`
+
+	var available int64
+	if err := tx.QueryRowContext(ctx, selectAvailable, trade.BuyerClubID).Scan(&available); err != nil {
+		slog.Error("errore lettura saldo buyer", "error", err, "club_id", trade.BuyerClubID)
+		return false, err
+	}
+	if available < trade.Amounts.Gross {
+		return false, ErrInsufficientCredits
+	}
+
+	// 5) Crediti e ledger: lordo al buyer, netto al seller (lordo e tassa in righe distinte).
+	for _, entry := range TradeLedgerEntries(trade.BuyerClubID, trade.SellerClubID, trade.Amounts) {
+		if err := appendLedgerEntry(ctx, tx, entry); err != nil {
+			slog.Error("errore scrittura ledger trade", "error", err, "trade_id", trade.TradeID, "reason", entry.Reason)
+			return false, err
+		}
+	}
+
+	// 6) Carte: rilascio del lock e passaggio al buyer.
+	for _, card := range trade.Cards {
+		if err := transferCard(ctx, tx, card, trade.SellerClubID, trade.BuyerClubID); err != nil {
+			return false, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// checkSettledHold confronta il trade gia' regolato con il retry: solo lo stesso
+// hold del buyer e' un replay, altrimenti il nuovo hold resterebbe bloccato.
+func checkSettledHold(ctx context.Context, tx *sql.Tx, trade settledTrade) error {
+	const selectSettled = `
+SELECT hold_id, buyer_club_id
+FROM settled_trades
+WHERE trade_id = $1`
+
+	var holdID, buyerClubID uuid.UUID
+	if err := tx.QueryRowContext(ctx, selectSettled, trade.TradeID).Scan(&holdID, &buyerClubID); err != nil {
+		slog.Error("errore lettura settled trade", "error", err, "trade_id", trade.TradeID)
+		return err
+	}
+	if holdID != trade.HoldID || buyerClubID != trade.BuyerClubID {
+		return ErrTradeHoldMismatch
+	}
+	return nil
+}
+
+// transferCard rilascia il lock della carta e la sposta dal seller al buyer.
+// Se il buyer possiede gia' lo stesso giocatore il vincolo uq_user_cards_club_player
+// fa fallire l'update: l'errore diventa ErrBuyerOwnsPlayer.
+func transferCard(ctx context.Context, tx *sql.Tx, card TradeCard, sellerClubID, buyerClubID uuid.UUID) error {
+	if card.LockID != uuid.Nil {
+		const releaseLock = `
+UPDATE card_locks
+SET released_at = now()
+WHERE lock_id = $1 AND user_card_id = $2 AND released_at IS NULL`
+
+		res, err := tx.ExecContext(ctx, releaseLock, card.LockID, card.UserCardID)
+		if err != nil {
+			slog.Error("errore rilascio card lock nel trade", "error", err, "lock_id", card.LockID)
+			return err
+		}
+		released, err := res.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if released == 0 {
+			return ErrCardLockNotFound
+		}
+	}
+
+	// Senza lock_id la carta non deve avere lock attivi di altri flussi.
+	const moveCard = `
+UPDATE user_cards
+SET club_id = $3, locked = FALSE
+WHERE id = $1 AND club_id = $2 AND ($4 OR locked = FALSE)`
+
+	res, err := tx.ExecContext(ctx, moveCard, card.UserCardID, sellerClubID, buyerClubID, card.LockID != uuid.Nil)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Constraint == constraintUserCardsClubPlayer {
+			return ErrBuyerOwnsPlayer
+		}
+		slog.Error("errore passaggio carta al buyer", "error", err, "user_card_id", card.UserCardID)
+		return err
+	}
+	moved, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if moved == 0 {
+		return ErrCardNotOwned
+	}
+	return nil
+}
@@ -12,11 +12,55 @@ type MyClubReader interface {
 	GetMyClub(ctx context.Context, userID uuid.UUID) (*MyClub, error)
 }
 
+// ClubByIDReader risolve un club dal suo id (es. club_id -> user_id per il market).
+type ClubByIDReader interface {
+	GetClubByID(ctx context.Context, clubID uuid.UUID) (Club, error)
+}
+
+// ClubByUserReader carica il club dell'utente senza le carte (saldo totale e disponibile).
+type ClubByUserReader interface {
+	GetClub(ctx context.Context, userID uuid.UUID) (Club, error)
+}
+
+// CreditHolder blocca e sblocca crediti del club (es. offerte sul market).
+type CreditHolder interface {
+	CreateCreditHold(ctx context.Context, userID, holdID uuid.UUID, amount int64, reason string) (uuid.UUID, error)
+	ReleaseCreditHold(ctx context.Context, holdID uuid.UUID) error
+}
+
+// TradeSettler regola i trade del market (crediti, ledger e carte).
+type TradeSettler interface {
+	SettleTrade(ctx context.Context, trade TradeSettlement) error
+}
+
+// CardLocker blocca e sblocca le carte del club (es. carte in vendita sul market).
+type CardLocker interface {
+	LockCard(ctx context.Context, userID, userCardID, lockID uuid.UUID, reason string) (CardLock, error)
+	ReleaseCardLock(ctx context.Context, lockID uuid.UUID) error
+}
+
+// CreditDebiter addebita crediti al club (es. penali del market).
+type CreditDebiter interface {
+	DebitCredits(ctx context.Context, userID uuid.UUID, debit Debit) error
+}
+
+// ClubAPI raccoglie le operazioni di dominio esposte dal server gRPC.
+type ClubAPI interface {
+	MyClubReader
+	ClubByIDReader
+	ClubByUserReader
+	CardLocker
+	CreditHolder
+	TradeSettler
+	CreditDebiter
+}
+
 // MyClub rappresenta il club con i dati necessari al dominio.
 type MyClub struct {
-	ClubID  uuid.UUID
-	Credits int64
-	Cards   []UserCard
+	ClubID           uuid.UUID
+	Credits          int64
+	AvailableCredits int64
+	Cards            []UserCard
 }
 
 // UserCard rappresenta una carta posseduta dal club.
@@ -25,3 +69,10 @@ type UserCard struct {
 	PlayerID uuid.UUID
 	Locked   bool
 }
+
+// CardLock e' un lock attivo su una carta.
+type CardLock struct {
+	ID         uuid.UUID
+	UserCardID uuid.UUID
+	Reason     string
+}
@@ -0,0 +1,60 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"time"
+)
+
+// Config contiene le impostazioni runtime per club-svc.
+type Config struct {
+	GRPCAddr string
+	DBDSN    string
+	// Tempo massimo per chiudere le RPC in corso allo shutdown; poi lo stop e' forzato.
+	ShutdownTimeout time.Duration
+}
+
+// Load legge le variabili d'ambiente con default minimi.
+func Load() Config {
+	dbDSN := os.Getenv("DB_DSN")
+	if dbDSN == "" {
+		dbDSN = buildDSN()
+	}
+
+	return Config{
+		GRPCAddr:        getEnv("GRPC_ADDR", ":50052"),
+		DBDSN:           dbDSN,
+		ShutdownTimeout: getEnvDuration("SHUTDOWN_TIMEOUT", 10*time.Second),
+	}
+}
+
+// getEnv ritorna il fallback quando la variabile non è presente.
+func getEnv(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
+
+// getEnvDuration legge una durata (es. "5s"); valori non validi usano il fallback.
+func getEnvDuration(key string, fallback time.Duration) time.Duration {
+	value, err := time.ParseDuration(os.Getenv(key))
+	if err != nil || value <= 0 {
+		return fallback
+	}
+	return value
+}
+
+func buildDSN() string {
+	// Se mancano dati minimi, torna vuota e fallisce piu' avanti.
+	host := os.Getenv("DB_HOST")
+	port := getEnv("DB_PORT", "5432")
+	user := os.Getenv("DB_USER")
+	password := os.Getenv("DB_PASSWORD")
+	name := os.Getenv("DB_NAME")
+	sslmode := getEnv("DB_SSLMODE", "require")
+	if host == "" || user == "" || name == "" {
+		return ""
+	}
+	return fmt.Sprintf("postgres://%s:%s@%s:%s/%s?sslmode=%s", user, password, host, port, name, sslmode)
+}
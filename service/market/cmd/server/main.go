@@ -1,17 +1,23 @@
 package main
 
 import (
+	"context"
 	"log/slog"
 	"net"
 	"os"
+	"os/signal"
+	"syscall"
 	"time"
 
+	"UltimateTeamX/pkg/redisx"
 	clubv1 "UltimateTeamX/proto/club/v1"
 	marketv1 "UltimateTeamX/proto/market/v1"
 	"UltimateTeamX/service/market/internal/config"
 	"UltimateTeamX/service/market/internal/db"
+	"UltimateTeamX/service/market/internal/idempotency"
 	"UltimateTeamX/service/market/internal/lock"
 	"UltimateTeamX/service/market/internal/market"
+	"UltimateTeamX/service/market/internal/ratelimit"
 	"github.com/joho/godotenv"
 	"github.com/redis/go-redis/v9"
 	"google.golang.org/grpc"
@@ -53,20 +59,70 @@ func main() {
 	}
 	defer clubConn.Close()
 
-	// Redis e' usato per i lock distribuiti delle listing.
+	// Redis e' usato per i lock distribuiti delle listing, le idempotency key e gli eventi.
 	redisClient := redis.NewClient(&redis.Options{
 		Addr:     cfg.RedisAddr,
 		Password: cfg.RedisPassword,
 	})
 	redisLock := lock.NewRedisLock(redisClient, 8*time.Second, 3, 100*time.Millisecond)
+	idempotencyStore := idempotency.NewRedisStore(redisClient, cfg.IdempotencyTTL, 30*time.Second)
+	eventBus := market.NewRedisEventBus(redisx.NewPubSub(redisClient))
+
+	// Tabella dei rilanci minimi configurata per il deploy.
+	bidIncrements, err := market.ParseBidIncrements(cfg.MinBidIncrements)
+	if err != nil {
+		logger.Error("invalid MIN_BID_INCREMENTS", "error", err)
+		os.Exit(1)
+	}
+
+	if cfg.SellerTaxBps > 10000 {
+		logger.Error("invalid SELLER_TAX_BPS", "value", cfg.SellerTaxBps)
+		os.Exit(1)
+	}
 
 	// Registra MarketService.
 	server := grpc.NewServer()
 	repo := market.NewRepo(database)
 	clubClient := clubv1.NewClubServiceClient(clubConn)
-	marketv1.RegisterMarketServiceServer(server, market.NewServer(logger, repo, clubClient, redisLock))
+	opts := []market.Option{
+		market.WithCancelPenaltyBps(cfg.CancelPenaltyBps),
+		market.WithSellerTaxBps(cfg.SellerTaxBps),
+		market.WithSagaLog(repo),
+		market.WithIdempotency(idempotencyStore),
+		market.WithEventBus(eventBus),
+		market.WithSoftClose(cfg.SoftCloseWindow, cfg.SoftCloseExtension, cfg.SoftCloseMaxExtensions),
+		market.WithBidIncrements(bidIncrements),
+		market.WithLimits(market.Limits{
+			MaxActiveListings: cfg.MaxActiveListingsPerSeller,
+			MaxWinningBids:    cfg.MaxWinningBidsPerBidder,
+		}),
+		market.WithPriceBounds(market.PriceBoundsConfig{
+			Window:    cfg.PriceBoundsWindow,
+			MinTrades: int64(cfg.PriceBoundsMinTrades),
+			BandBps:   cfg.PriceBoundsBandBps,
+		}),
+		market.WithAdminToken(cfg.AdminToken),
+	}
+	if cfg.BidRateLimit > 0 {
+		opts = append(opts, market.WithBidRateLimiter(ratelimit.NewRedisLimiter(redisClient, cfg.BidRateLimit, cfg.BidRateWindow)))
+	}
+	marketServer := market.NewServer(logger, repo, clubClient, redisLock, opts...)
+	marketv1.RegisterMarketServiceServer(server, marketServer)
 	reflection.Register(server)
 
+	// Worker di chiusura listing scaduti e recovery saga; si fermano con SIGINT/SIGTERM.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go market.NewExpiryWorker(logger, marketServer, cfg.ExpiryInterval, cfg.ExpiryBatchSize).Run(ctx)
+	go market.NewSagaRecoveryWorker(logger, marketServer, cfg.SagaRecoveryInterval, cfg.SagaStaleAfter, cfg.ExpiryBatchSize).Run(ctx)
+	go func() {
+		<-ctx.Done()
+		// Gli stream WatchListing non terminano da soli: dopo un timeout si forza lo stop.
+		timer := time.AfterFunc(10*time.Second, server.Stop)
+		defer timer.Stop()
+		server.GracefulStop()
+	}()
+
 	// Avvia il listener gRPC.
 	listener, err := net.Listen("tcp", cfg.GRPCAddr)
 	if err != nil {
@@ -3,6 +3,8 @@ package config
 import (
 	"fmt"
 	"os"
+	"strconv"
+	"time"
 )
 
 // Config contiene le impostazioni runtime per market-svc.
@@ -12,6 +14,38 @@ type Config struct {
 	ClubGRPCAddr  string
 	RedisAddr     string
 	RedisPassword string
+	// Worker di chiusura dei listing scaduti.
+	ExpiryInterval  time.Duration
+	ExpiryBatchSize int
+	// Penale (basis point del best_bid) per ritirare listing con offerte; 0 = vietato.
+	CancelPenaltyBps int64
+	// Tassa (basis point del prezzo) trattenuta al seller su ogni vendita; 0 = nessuna tassa.
+	SellerTaxBps int64
+	// Recovery loop delle saga rimaste STARTED dopo un crash.
+	SagaRecoveryInterval time.Duration
+	SagaStaleAfter       time.Duration
+	// Durata delle risposte salvate per idempotency key.
+	IdempotencyTTL time.Duration
+	// Anti-sniping: un bid negli ultimi SoftCloseWindow estende l'asta di SoftCloseExtension
+	// (al massimo SoftCloseMaxExtensions volte); window 0 = disattivato.
+	SoftCloseWindow        time.Duration
+	SoftCloseExtension     time.Duration
+	SoftCloseMaxExtensions int
+	// Limiti anti-abuso per club (0 = nessun limite).
+	MaxActiveListingsPerSeller int
+	MaxWinningBidsPerBidder    int
+	// Rate limit Redis dei PlaceBid per utente: BidRateLimit bid ogni BidRateWindow (0 = disattivato).
+	BidRateLimit  int
+	BidRateWindow time.Duration
+	// Range di prezzo per giocatore derivato dalla mediana dei trade recenti:
+	// +/- PriceBoundsBandBps su PriceBoundsWindow, con almeno PriceBoundsMinTrades trade (band 0 = disattivato).
+	PriceBoundsWindow    time.Duration
+	PriceBoundsMinTrades int
+	PriceBoundsBandBps   int64
+	// Token richiesto nelle metadata (admin_token) dalle RPC admin; vuoto = RPC admin disattivate.
+	AdminToken string
+	// Tabella dei rilanci minimi, es. "1000:50,10000:100,*:250" (vuota = +1 credito).
+	MinBidIncrements string
 }
 
 // Load legge le variabili d'ambiente con default minimi.
@@ -22,11 +56,30 @@ func Load() Config {
 	}
 
 	return Config{
-		GRPCAddr:      getEnv("GRPC_ADDR", ":50053"),
-		DBDSN:         dbDSN,
-		ClubGRPCAddr:  os.Getenv("CLUB_GRPC_ADDR"),
-		RedisAddr:     getEnv("REDIS_ADDR", "localhost:6379"),
-		RedisPassword: os.Getenv("REDIS_PASSWORD"),
+		GRPCAddr:                   getEnv("GRPC_ADDR", ":50053"),
+		DBDSN:                      dbDSN,
+		ClubGRPCAddr:               os.Getenv("CLUB_GRPC_ADDR"),
+		RedisAddr:                  getEnv("REDIS_ADDR", "localhost:6379"),
+		RedisPassword:              os.Getenv("REDIS_PASSWORD"),
+		ExpiryInterval:             getEnvDuration("EXPIRY_INTERVAL", 5*time.Second),
+		ExpiryBatchSize:            getEnvInt("EXPIRY_BATCH_SIZE", 100),
+		CancelPenaltyBps:           int64(getEnvNonNegativeInt("CANCEL_PENALTY_BPS", 0)),
+		SellerTaxBps:               int64(getEnvNonNegativeInt("SELLER_TAX_BPS", 0)),
+		SagaRecoveryInterval:       getEnvDuration("SAGA_RECOVERY_INTERVAL", 30*time.Second),
+		SagaStaleAfter:             getEnvDuration("SAGA_STALE_AFTER", time.Minute),
+		IdempotencyTTL:             getEnvDuration("IDEMPOTENCY_TTL", 24*time.Hour),
+		SoftCloseWindow:            getEnvDuration("SOFT_CLOSE_WINDOW", 0),
+		SoftCloseExtension:         getEnvDuration("SOFT_CLOSE_EXTENSION", 30*time.Second),
+		SoftCloseMaxExtensions:     getEnvNonNegativeInt("SOFT_CLOSE_MAX_EXTENSIONS", 10),
+		MinBidIncrements:           os.Getenv("MIN_BID_INCREMENTS"),
+		MaxActiveListingsPerSeller: getEnvNonNegativeInt("MAX_ACTIVE_LISTINGS_PER_SELLER", 0),
+		MaxWinningBidsPerBidder:    getEnvNonNegativeInt("MAX_WINNING_BIDS_PER_BIDDER", 0),
+		BidRateLimit:               getEnvNonNegativeInt("BID_RATE_LIMIT", 0),
+		BidRateWindow:              getEnvDuration("BID_RATE_WINDOW", time.Minute),
+		PriceBoundsWindow:          getEnvDuration("PRICE_BOUNDS_WINDOW", 7*24*time.Hour),
+		PriceBoundsMinTrades:       getEnvNonNegativeInt("PRICE_BOUNDS_MIN_TRADES", 10),
+		PriceBoundsBandBps:         int64(getEnvNonNegativeInt("PRICE_BOUNDS_BAND_BPS", 0)),
+		AdminToken:                 os.Getenv("MARKET_ADMIN_TOKEN"),
 	}
 }
 
@@ -38,6 +91,34 @@ func getEnv(key, fallback string) string {
 	return fallback
 }
 
+// getEnvDuration legge una durata (es. "5s"); valori non validi usano il fallback.
+func getEnvDuration(key string, fallback time.Duration) time.Duration {
+	value, err := time.ParseDuration(os.Getenv(key))
+	if err != nil || value <= 0 {
+		return fallback
+	}
+	return value
+}
+
+// getEnvInt legge un intero positivo; valori non validi usano il fallback.
+func getEnvInt(key string, fallback int) int {
+	value, err := strconv.Atoi(os.Getenv(key))
+	if err != nil || value <= 0 {
+		return fallback
+	}
+	return value
+}
+
+// getEnvNonNegativeInt legge un intero >= 0 per le impostazioni dove 0 ha un significato
+// (es. nessuna estensione, limite disattivato); valori non validi o negativi usano il fallback.
+func getEnvNonNegativeInt(key string, fallback int) int {
+	value, err := strconv.Atoi(os.Getenv(key))
+	if err != nil || value < 0 {
+		return fallback
+	}
+	return value
+}
+
 func buildDSN() string {
 	// Se mancano dati minimi, torna vuota e fallisce piu' avanti.
 	host := os.Getenv("DB_HOST")
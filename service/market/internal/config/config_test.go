@@ -0,0 +1,32 @@
+package config
+
+import "testing"
+
+// Verifica che 0 sia un valore valido per le impostazioni dove disattiva qualcosa.
+func TestLoadKeepsExplicitZero(t *testing.T) {
+	t.Setenv("SOFT_CLOSE_MAX_EXTENSIONS", "0")
+	t.Setenv("PRICE_BOUNDS_MIN_TRADES", "0")
+	t.Setenv("EXPIRY_BATCH_SIZE", "0")
+
+	cfg := Load()
+	if cfg.SoftCloseMaxExtensions != 0 {
+		t.Fatalf("expected SOFT_CLOSE_MAX_EXTENSIONS 0, got %d", cfg.SoftCloseMaxExtensions)
+	}
+	if cfg.PriceBoundsMinTrades != 0 {
+		t.Fatalf("expected PRICE_BOUNDS_MIN_TRADES 0, got %d", cfg.PriceBoundsMinTrades)
+	}
+	if cfg.ExpiryBatchSize != 100 {
+		t.Fatalf("expected default EXPIRY_BATCH_SIZE for 0, got %d", cfg.ExpiryBatchSize)
+	}
+}
+
+// Verifica che valori negativi o non numerici usino il default.
+func TestLoadRejectsInvalidInts(t *testing.T) {
+	t.Setenv("SOFT_CLOSE_MAX_EXTENSIONS", "-1")
+	t.Setenv("PRICE_BOUNDS_MIN_TRADES", "abc")
+
+	cfg := Load()
+	if cfg.SoftCloseMaxExtensions != 10 || cfg.PriceBoundsMinTrades != 10 {
+		t.Fatalf("expected defaults, got %d/%d", cfg.SoftCloseMaxExtensions, cfg.PriceBoundsMinTrades)
+	}
+}
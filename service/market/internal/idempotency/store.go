@@ -0,0 +1,107 @@
+package idempotency
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Errori ritornati da Begin quando la chiave non puo' essere eseguita.
+var (
+	ErrInProgress = errors.New("idempotency key in progress")
+	ErrMismatch   = errors.New("idempotency key reused with different payload")
+)
+
+// Stati del record salvato per chiave.
+const (
+	statePending = "PENDING"
+	stateDone    = "DONE"
+)
+
+// Result descrive l'esito di Begin: con Replay la risposta salvata va ritornata cosi' com'e'.
+type Result struct {
+	Replay   bool
+	Response []byte
+}
+
+// Store conserva chiave -> risposta delle RPC mutative.
+type Store interface {
+	// Begin prenota la chiave per fingerprint; se gia' completata ritorna la risposta salvata.
+	Begin(ctx context.Context, key, fingerprint string) (Result, error)
+	// Complete salva la risposta serializzata per tutta la TTL.
+	Complete(ctx context.Context, key, fingerprint string, response []byte) error
+	// Abort libera la chiave dopo un errore, cosi' il client puo' ripetere.
+	Abort(ctx context.Context, key string) error
+}
+
+// record e' il valore JSON salvato in Redis.
+type record struct {
+	Fingerprint string `json:"fingerprint"`
+	State       string `json:"state"`
+	Response    []byte `json:"response,omitempty"`
+}
+
+// RedisStore implementa Store su Redis con TTL.
+type RedisStore struct {
+	client     *redis.Client
+	ttl        time.Duration
+	pendingTTL time.Duration
+}
+
+// NewRedisStore usa ttl per le risposte salvate e pendingTTL per le chiavi in esecuzione.
+// pendingTTL breve evita chiavi bloccate in caso di crash a meta' richiesta.
+func NewRedisStore(client *redis.Client, ttl, pendingTTL time.Duration) *RedisStore {
+	return &RedisStore{client: client, ttl: ttl, pendingTTL: pendingTTL}
+}
+
+func (s *RedisStore) Begin(ctx context.Context, key, fingerprint string) (Result, error) {
+	pending, err := json.Marshal(record{Fingerprint: fingerprint, State: statePending})
+	if err != nil {
+		return Result{}, err
+	}
+	// Due tentativi: la chiave puo' scadere tra SETNX e GET.
+	for attempt := 0; attempt < 2; attempt++ {
+		ok, err := s.client.SetNX(ctx, key, pending, s.pendingTTL).Result()
+		if err != nil {
+			return Result{}, err
+		}
+		if ok {
+			return Result{}, nil
+		}
+
+		raw, err := s.client.Get(ctx, key).Bytes()
+		if errors.Is(err, redis.Nil) {
+			continue
+		}
+		if err != nil {
+			return Result{}, err
+		}
+		var saved record
+		if err := json.Unmarshal(raw, &saved); err != nil {
+			return Result{}, err
+		}
+		if saved.Fingerprint != fingerprint {
+			return Result{}, ErrMismatch
+		}
+		if saved.State != stateDone {
+			return Result{}, ErrInProgress
+		}
+		return Result{Replay: true, Response: saved.Response}, nil
+	}
+	return Result{}, ErrInProgress
+}
+
+func (s *RedisStore) Complete(ctx context.Context, key, fingerprint string, response []byte) error {
+	done, err := json.Marshal(record{Fingerprint: fingerprint, State: stateDone, Response: response})
+	if err != nil {
+		return err
+	}
+	return s.client.Set(ctx, key, done, s.ttl).Err()
+}
+
+func (s *RedisStore) Abort(ctx context.Context, key string) error {
+	return s.client.Del(ctx, key).Err()
+}
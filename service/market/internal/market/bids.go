@@ -0,0 +1,123 @@
+package market
+
+import (
+	"context"
+	"strings"
+
+	marketv1 "UltimateTeamX/proto/market/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ListBids ritorna lo storico dei bid di un listing, dal piu' recente.
+func (s *Server) ListBids(ctx context.Context, req *marketv1.ListBidsRequest) (*marketv1.ListBidsResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "request is required")
+	}
+	if strings.TrimSpace(req.ListingId) == "" {
+		return nil, status.Error(codes.InvalidArgument, "listing_id is required")
+	}
+	if !isUUID(req.ListingId) {
+		return nil, status.Error(codes.InvalidArgument, "listing_id must be a valid UUID")
+	}
+
+	// 1) Verifica che il listing esista (NotFound esplicito invece di lista vuota).
+	if _, err := s.loadListing(ctx, req.ListingId); err != nil {
+		return nil, err
+	}
+
+	// 2) Pagina dei bid dal DB market.
+	page, pageSize := normalizePage(req.Pagination)
+	bids, total, err := s.repo.ListBidsByListing(ctx, req.ListingId, int(pageSize), int((page-1)*pageSize))
+	if err != nil {
+		s.logger.Error("errore lettura bid del listing", "error", err, "listing_id", req.ListingId)
+		return nil, status.Error(codes.Internal, "failed to list bids")
+	}
+
+	// 3) Risolve i bidder in user_id, una sola chiamata per club.
+	items, err := bidsToProto(bids, s.clubUserResolver(ctx))
+	if err != nil {
+		return nil, err
+	}
+	return &marketv1.ListBidsResponse{Bids: items, TotalCount: total, Page: page, PageSize: pageSize}, nil
+}
+
+// ListMyBids ritorna lo storico dei bid dell'utente su tutti i listing, dal piu' recente.
+func (s *Server) ListMyBids(ctx context.Context, req *marketv1.ListMyBidsRequest) (*marketv1.ListMyBidsResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "request is required")
+	}
+	if strings.TrimSpace(req.BidderUserId) == "" {
+		return nil, status.Error(codes.InvalidArgument, "bidder_user_id is required")
+	}
+	if !isUUID(req.BidderUserId) {
+		return nil, status.Error(codes.InvalidArgument, "bidder_user_id must be a valid UUID")
+	}
+
+	// 1) Risolve bidder_club_id via club-svc.
+	bidderClubID, err := s.clubIDForUser(ctx, req.BidderUserId)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2) Pagina dei bid del club dal DB market.
+	page, pageSize := normalizePage(req.Pagination)
+	bids, total, err := s.repo.ListBidsByBidder(ctx, bidderClubID, int(pageSize), int((page-1)*pageSize))
+	if err != nil {
+		s.logger.Error("errore lettura bid del bidder", "error", err, "bidder_club_id", bidderClubID)
+		return nil, status.Error(codes.Internal, "failed to list bids")
+	}
+
+	// Tutti i bid sono dello stesso club: l'user_id e' gia' noto.
+	resolve := func(string) (string, error) { return req.BidderUserId, nil }
+	items, err := bidsToProto(bids, resolve)
+	if err != nil {
+		return nil, err
+	}
+	return &marketv1.ListMyBidsResponse{Bids: items, TotalCount: total, Page: page, PageSize: pageSize}, nil
+}
+
+// bidsToProto converte i bid risolvendo il bidder in user_id.
+func bidsToProto(bids []Bid, resolve func(clubID string) (string, error)) ([]*marketv1.Bid, error) {
+	items := make([]*marketv1.Bid, 0, len(bids))
+	for _, bid := range bids {
+		bidderUserID, err := resolve(bid.BidderClubID)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, &marketv1.Bid{
+			BidId:         bid.ID,
+			ListingId:     bid.ListingID,
+			BidderUserId:  bidderUserID,
+			Amount:        bid.Amount,
+			CreatedAtUnix: bid.CreatedAtUnix,
+			Status:        bidStatusToProto(bid),
+			Auto:          bid.Auto,
+		})
+	}
+	return items, nil
+}
+
+// bidStatusToProto deriva l'esito del bid dallo stato del listing.
+// Un listing ACTIVE gia' scaduto ma non ancora chiuso dal worker mantiene WINNING/OUTBID.
+func bidStatusToProto(bid Bid) marketv1.BidStatus {
+	isBest := bid.ListingBestBid != nil && bid.ListingBestBidderClubID != nil &&
+		*bid.ListingBestBid == bid.Amount && *bid.ListingBestBidderClubID == bid.BidderClubID
+
+	switch bid.ListingStatus {
+	case listingStatusActive:
+		if isBest {
+			return marketv1.BidStatus_BID_STATUS_WINNING
+		}
+		return marketv1.BidStatus_BID_STATUS_OUTBID
+	case listingStatusSold:
+		if isBest {
+			return marketv1.BidStatus_BID_STATUS_WON
+		}
+		return marketv1.BidStatus_BID_STATUS_LOST
+	case listingStatusExpired, listingStatusCancelled:
+		return marketv1.BidStatus_BID_STATUS_LOST
+	default:
+		return marketv1.BidStatus_BID_STATUS_UNSPECIFIED
+	}
+}
@@ -0,0 +1,95 @@
+package market
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	clubv1 "UltimateTeamX/proto/club/v1"
+	commonv1 "UltimateTeamX/proto/common/v1"
+	marketv1 "UltimateTeamX/proto/market/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Test suite per lo storico dei bid.
+
+func TestListBidsDerivesStatus(t *testing.T) {
+	bestBid := int64(1500)
+	bestBidder := "club-b"
+	repo := &fakeRepo{
+		bids: []Bid{
+			{ID: "bid-2", ListingID: "listing-1", BidderClubID: "club-b", Amount: 1500, ListingStatus: listingStatusActive, ListingBestBid: &bestBid, ListingBestBidderClubID: &bestBidder},
+			{ID: "bid-1", ListingID: "listing-1", BidderClubID: "club-a", Amount: 1200, ListingStatus: listingStatusActive, ListingBestBid: &bestBid, ListingBestBidderClubID: &bestBidder},
+		},
+		bidsTotal: 2,
+	}
+	club := &fakeClub{clubOwners: map[string]string{"club-a": "user-a", "club-b": "user-b"}}
+	server := NewServer(slog.Default(), repo, club, nil)
+
+	resp, err := server.ListBids(context.Background(), &marketv1.ListBidsRequest{
+		ListingId:  "11111111-1111-1111-1111-111111111111",
+		Pagination: &commonv1.Pagination{Page: 2, PageSize: 5},
+	})
+	if err != nil {
+		t.Fatalf("expected success, got error: %v", err)
+	}
+	if repo.lastBidsQuery.limit != 5 || repo.lastBidsQuery.offset != 5 {
+		t.Fatalf("unexpected page: %+v", repo.lastBidsQuery)
+	}
+	if resp.TotalCount != 2 || len(resp.Bids) != 2 {
+		t.Fatalf("unexpected response: %+v", resp)
+	}
+	if resp.Bids[0].Status != marketv1.BidStatus_BID_STATUS_WINNING || resp.Bids[0].BidderUserId != "user-b" {
+		t.Fatalf("expected winning bid for user-b, got %+v", resp.Bids[0])
+	}
+	if resp.Bids[1].Status != marketv1.BidStatus_BID_STATUS_OUTBID || resp.Bids[1].BidderUserId != "user-a" {
+		t.Fatalf("expected outbid for user-a, got %+v", resp.Bids[1])
+	}
+}
+
+func TestListBidsListingNotFound(t *testing.T) {
+	server := NewServer(slog.Default(), &fakeRepo{getListingErr: ErrNotFound}, &fakeClub{}, nil)
+
+	_, err := server.ListBids(context.Background(), &marketv1.ListBidsRequest{ListingId: "11111111-1111-1111-1111-111111111111"})
+	if status.Code(err) != codes.NotFound {
+		t.Fatalf("expected NotFound, got %v", err)
+	}
+}
+
+func TestListMyBidsUsesBidderClub(t *testing.T) {
+	price := int64(2000)
+	winner := "club-me"
+	other := "club-other"
+	repo := &fakeRepo{
+		bids: []Bid{
+			{ID: "bid-1", ListingID: "listing-1", BidderClubID: "club-me", Amount: 2000, ListingStatus: listingStatusSold, ListingBestBid: &price, ListingBestBidderClubID: &winner},
+			{ID: "bid-2", ListingID: "listing-2", BidderClubID: "club-me", Amount: 900, ListingStatus: listingStatusSold, ListingBestBid: &price, ListingBestBidderClubID: &other},
+			{ID: "bid-3", ListingID: "listing-3", BidderClubID: "club-me", Amount: 500, ListingStatus: listingStatusCancelled},
+		},
+		bidsTotal: 3,
+	}
+	club := &fakeClub{getMyClubResp: &clubv1.GetMyClubResponse{ClubId: "club-me"}}
+	server := NewServer(slog.Default(), repo, club, nil)
+
+	resp, err := server.ListMyBids(context.Background(), &marketv1.ListMyBidsRequest{BidderUserId: "22222222-2222-2222-2222-222222222222"})
+	if err != nil {
+		t.Fatalf("expected success, got error: %v", err)
+	}
+	if repo.lastBidsQuery.bidderClubID != "club-me" || repo.lastBidsQuery.limit != defaultPageSize {
+		t.Fatalf("unexpected query: %+v", repo.lastBidsQuery)
+	}
+	want := []marketv1.BidStatus{
+		marketv1.BidStatus_BID_STATUS_WON,
+		marketv1.BidStatus_BID_STATUS_LOST,
+		marketv1.BidStatus_BID_STATUS_LOST,
+	}
+	for i, bid := range resp.Bids {
+		if bid.Status != want[i] {
+			t.Fatalf("bid %d: expected %v, got %v", i, want[i], bid.Status)
+		}
+		if bid.BidderUserId != "22222222-2222-2222-2222-222222222222" {
+			t.Fatalf("expected bidder user id to be echoed")
+		}
+	}
+}
@@ -0,0 +1,281 @@
+package market
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"UltimateTeamX/pkg/grpcx"
+	commonv1 "UltimateTeamX/proto/common/v1"
+	marketv1 "UltimateTeamX/proto/market/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Codice dell'errore strutturato per i prezzi fuori dal range del giocatore.
+const errorCodePriceOutOfRange = "PRICE_OUT_OF_RANGE"
+
+// Origine del range di prezzo applicato.
+const (
+	priceBoundsSourceAdmin  = "ADMIN"
+	priceBoundsSourceTrades = "TRADES"
+)
+
+// PriceBounds e' il range di prezzo di un giocatore; un lato a 0 significa nessun limite.
+type PriceBounds struct {
+	Min        int64
+	Max        int64
+	Source     string
+	SampleSize int64
+}
+
+// contains indica se il prezzo rientra nel range.
+func (b PriceBounds) contains(price int64) bool {
+	return (b.Min <= 0 || price >= b.Min) && (b.Max <= 0 || price <= b.Max)
+}
+
+// PriceBoundsConfig deriva il range dalla mediana dei trade recenti del giocatore:
+// [mediana - BandBps, mediana + BandBps] se nella Window ci sono almeno MinTrades trade.
+// BandBps 0 = nessun range derivato (restano gli override admin).
+type PriceBoundsConfig struct {
+	Window    time.Duration
+	MinTrades int64
+	BandBps   int64
+}
+
+// WithPriceBounds abilita il range di prezzo derivato dai trade recenti.
+func WithPriceBounds(cfg PriceBoundsConfig) Option {
+	return func(s *Server) {
+		s.priceBounds = cfg
+	}
+}
+
+// WithAdminToken abilita le RPC admin per chi presenta il token nelle metadata.
+func WithAdminToken(token string) Option {
+	return func(s *Server) {
+		s.adminToken = token
+	}
+}
+
+// GetPlayerPriceBounds ritorna il range di prezzo applicato ai listing del giocatore.
+func (s *Server) GetPlayerPriceBounds(ctx context.Context, req *marketv1.GetPlayerPriceBoundsRequest) (*marketv1.PlayerPriceBounds, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "request is required")
+	}
+	if err := validatePlayerID(req.PlayerId); err != nil {
+		return nil, err
+	}
+	bounds, err := s.playerPriceBounds(ctx, req.PlayerId)
+	if err != nil {
+		return nil, err
+	}
+	return priceBoundsToProto(req.PlayerId, bounds), nil
+}
+
+// SetPlayerPriceBounds imposta l'override admin del range di prezzo del giocatore.
+func (s *Server) SetPlayerPriceBounds(ctx context.Context, req *marketv1.SetPlayerPriceBoundsRequest) (*marketv1.PlayerPriceBounds, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "request is required")
+	}
+	if err := s.requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	if err := validatePlayerID(req.PlayerId); err != nil {
+		return nil, err
+	}
+	if req.MinPrice < 0 || req.MaxPrice < 0 {
+		return nil, status.Error(codes.InvalidArgument, "min_price and max_price must not be negative")
+	}
+	if req.MinPrice == 0 && req.MaxPrice == 0 {
+		return nil, status.Error(codes.InvalidArgument, "min_price or max_price is required")
+	}
+	if req.MinPrice > 0 && req.MaxPrice > 0 && req.MinPrice > req.MaxPrice {
+		return nil, status.Error(codes.InvalidArgument, "min_price must be <= max_price")
+	}
+
+	bounds := PriceBounds{Min: req.MinPrice, Max: req.MaxPrice, Source: priceBoundsSourceAdmin}
+	if err := s.repo.UpsertPriceBounds(ctx, req.PlayerId, bounds); err != nil {
+		s.logger.Error("errore salvataggio range prezzo", "error", err, "player_id", req.PlayerId)
+		return nil, status.Error(codes.Internal, "failed to save price bounds")
+	}
+	s.logger.Info("range prezzo impostato da admin", "player_id", req.PlayerId, "min_price", req.MinPrice, "max_price", req.MaxPrice)
+	return priceBoundsToProto(req.PlayerId, bounds), nil
+}
+
+// ClearPlayerPriceBounds rimuove l'override admin: torna il range derivato dai trade.
+func (s *Server) ClearPlayerPriceBounds(ctx context.Context, req *marketv1.ClearPlayerPriceBoundsRequest) (*marketv1.ClearPlayerPriceBoundsResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "request is required")
+	}
+	if err := s.requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	if err := validatePlayerID(req.PlayerId); err != nil {
+		return nil, err
+	}
+	cleared, err := s.repo.DeletePriceBounds(ctx, req.PlayerId)
+	if err != nil {
+		s.logger.Error("errore rimozione range prezzo", "error", err, "player_id", req.PlayerId)
+		return nil, status.Error(codes.Internal, "failed to clear price bounds")
+	}
+	s.logger.Info("range prezzo admin rimosso", "player_id", req.PlayerId, "cleared", cleared)
+	return &marketv1.ClearPlayerPriceBoundsResponse{Cleared: cleared}, nil
+}
+
+// playerPriceBounds risolve il range del giocatore: override admin, altrimenti
+// range derivato dai trade recenti, altrimenti nessun limite.
+func (s *Server) playerPriceBounds(ctx context.Context, playerID string) (PriceBounds, error) {
+	if playerID == "" {
+		return PriceBounds{}, nil
+	}
+	bounds, err := s.repo.GetPriceBounds(ctx, playerID)
+	if err == nil {
+		return bounds, nil
+	}
+	if !errors.Is(err, ErrNotFound) {
+		s.logger.Error("errore lettura range prezzo", "error", err, "player_id", playerID)
+		return PriceBounds{}, status.Error(codes.Internal, "failed to load price bounds")
+	}
+
+	cfg := s.priceBounds
+	if cfg.BandBps <= 0 {
+		return PriceBounds{}, nil
+	}
+	median, count, err := s.repo.TradePriceStats(ctx, playerID, time.Now().Add(-cfg.Window))
+	if err != nil {
+		s.logger.Error("errore lettura statistiche trade", "error", err, "player_id", playerID)
+		return PriceBounds{}, status.Error(codes.Internal, "failed to load price bounds")
+	}
+	if count == 0 || count < cfg.MinTrades {
+		return PriceBounds{}, nil
+	}
+	bounds = PriceBounds{
+		Max:        median * (10000 + cfg.BandBps) / 10000,
+		Source:     priceBoundsSourceTrades,
+		SampleSize: count,
+	}
+	if cfg.BandBps < 10000 {
+		bounds.Min = max(median*(10000-cfg.BandBps)/10000, 1)
+	}
+	return bounds, nil
+}
+
+// checkPriceBounds verifica i prezzi indicati contro il range del giocatore.
+// Listing senza player_id (creati prima della migrazione 006) non hanno range.
+func (s *Server) checkPriceBounds(ctx context.Context, playerID, field string, prices ...int64) error {
+	bounds, err := s.playerPriceBounds(ctx, playerID)
+	if err != nil {
+		return err
+	}
+	for _, price := range prices {
+		if price > 0 && !bounds.contains(price) {
+			return priceOutOfRangeError(field+" outside player price range", bounds)
+		}
+	}
+	return nil
+}
+
+// bundlePriceBounds somma i range dei giocatori del lotto: il minimo e' la somma dei
+// minimi, il massimo la somma dei massimi. Basta una carta senza massimo perche' il
+// bundle non abbia massimo.
+func (s *Server) bundlePriceBounds(ctx context.Context, items []ListingItem) (PriceBounds, error) {
+	var total PriceBounds
+	unbounded := false
+	for _, item := range items {
+		bounds, err := s.playerPriceBounds(ctx, item.PlayerID)
+		if err != nil {
+			return PriceBounds{}, err
+		}
+		total.Min += bounds.Min
+		if bounds.Max <= 0 {
+			unbounded = true
+		}
+		total.Max += bounds.Max
+	}
+	if unbounded {
+		total.Max = 0
+	}
+	return total, nil
+}
+
+// checkListingPriceBounds verifica i prezzi contro il range del listing:
+// quello del giocatore per i SINGLE, la somma dei range delle carte per i BUNDLE.
+func (s *Server) checkListingPriceBounds(ctx context.Context, listing Listing, field string, prices ...int64) error {
+	if listing.Kind != listingKindBundle {
+		return s.checkPriceBounds(ctx, listing.PlayerID, field, prices...)
+	}
+	bounds, err := s.bundlePriceBounds(ctx, listing.Items)
+	if err != nil {
+		return err
+	}
+	for _, price := range prices {
+		if price > 0 && !bounds.contains(price) {
+			return priceOutOfRangeError(field+" outside bundle price range", bounds)
+		}
+	}
+	return nil
+}
+
+// priceOutOfRangeError costruisce il FailedPrecondition con dettaglio common.v1.Error
+// contenente min_price e max_price del giocatore.
+func priceOutOfRangeError(message string, bounds PriceBounds) error {
+	st := status.New(codes.FailedPrecondition, message)
+	detailed, err := st.WithDetails(&commonv1.Error{
+		Code:    errorCodePriceOutOfRange,
+		Message: message,
+		Metadata: map[string]string{
+			"min_price": strconv.FormatInt(bounds.Min, 10),
+			"max_price": strconv.FormatInt(bounds.Max, 10),
+		},
+	})
+	if err != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
+
+// requireAdmin verifica il token admin nelle metadata gRPC.
+func (s *Server) requireAdmin(ctx context.Context) error {
+	if s.adminToken == "" {
+		return status.Error(codes.PermissionDenied, "admin api disabled")
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get(grpcx.AdminTokenMetadataKey)
+	if len(values) == 0 || subtle.ConstantTimeCompare([]byte(strings.TrimSpace(values[0])), []byte(s.adminToken)) != 1 {
+		return status.Error(codes.PermissionDenied, "admin token required")
+	}
+	return nil
+}
+
+// validatePlayerID valida il player_id delle RPC sui range di prezzo.
+func validatePlayerID(playerID string) error {
+	if strings.TrimSpace(playerID) == "" {
+		return status.Error(codes.InvalidArgument, "player_id is required")
+	}
+	if !isUUID(playerID) {
+		return status.Error(codes.InvalidArgument, "player_id must be a valid UUID")
+	}
+	return nil
+}
+
+// priceBoundsToProto converte il range nel messaggio gRPC.
+func priceBoundsToProto(playerID string, bounds PriceBounds) *marketv1.PlayerPriceBounds {
+	resp := &marketv1.PlayerPriceBounds{
+		PlayerId:   playerID,
+		MinPrice:   bounds.Min,
+		MaxPrice:   bounds.Max,
+		SampleSize: bounds.SampleSize,
+		Source:     marketv1.PriceBoundsSource_PRICE_BOUNDS_SOURCE_NONE,
+	}
+	switch bounds.Source {
+	case priceBoundsSourceAdmin:
+		resp.Source = marketv1.PriceBoundsSource_PRICE_BOUNDS_SOURCE_ADMIN
+	case priceBoundsSourceTrades:
+		resp.Source = marketv1.PriceBoundsSource_PRICE_BOUNDS_SOURCE_TRADES
+	}
+	return resp
+}
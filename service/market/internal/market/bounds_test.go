@@ -0,0 +1,136 @@
+package market
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"UltimateTeamX/pkg/grpcx"
+	clubv1 "UltimateTeamX/proto/club/v1"
+	commonv1 "UltimateTeamX/proto/common/v1"
+	marketv1 "UltimateTeamX/proto/market/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Test suite per i range di prezzo per giocatore.
+
+func TestPlayerPriceBoundsDerivedFromTrades(t *testing.T) {
+	repo := &fakeRepo{tradeMedian: 2000, tradeCount: 8}
+	server := NewServer(slog.Default(), repo, &fakeClub{}, nil,
+		WithPriceBounds(PriceBoundsConfig{Window: 7 * 24 * time.Hour, MinTrades: 5, BandBps: 5000}))
+
+	resp, err := server.GetPlayerPriceBounds(context.Background(), &marketv1.GetPlayerPriceBoundsRequest{PlayerId: testPlayerID})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.MinPrice != 1000 || resp.MaxPrice != 3000 || resp.SampleSize != 8 ||
+		resp.Source != marketv1.PriceBoundsSource_PRICE_BOUNDS_SOURCE_TRADES {
+		t.Fatalf("unexpected bounds: %+v", resp)
+	}
+
+	// Pochi trade: nessun range derivato.
+	repo.tradeCount = 3
+	resp, err = server.GetPlayerPriceBounds(context.Background(), &marketv1.GetPlayerPriceBoundsRequest{PlayerId: testPlayerID})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.Source != marketv1.PriceBoundsSource_PRICE_BOUNDS_SOURCE_NONE || resp.MinPrice != 0 || resp.MaxPrice != 0 {
+		t.Fatalf("expected no bounds, got %+v", resp)
+	}
+}
+
+func TestSetPlayerPriceBoundsRequiresAdmin(t *testing.T) {
+	repo := &fakeRepo{tradeMedian: 2000, tradeCount: 8}
+	server := NewServer(slog.Default(), repo, &fakeClub{}, nil,
+		WithAdminToken("secret"),
+		WithPriceBounds(PriceBoundsConfig{MinTrades: 1, BandBps: 5000}))
+	req := &marketv1.SetPlayerPriceBoundsRequest{PlayerId: testPlayerID, MinPrice: 500, MaxPrice: 900}
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(grpcx.AdminTokenMetadataKey, "wrong"))
+	if _, err := server.SetPlayerPriceBounds(ctx, req); status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("expected PermissionDenied, got %v", err)
+	}
+
+	ctx = metadata.NewIncomingContext(context.Background(), metadata.Pairs(grpcx.AdminTokenMetadataKey, "secret"))
+	if _, err := server.SetPlayerPriceBounds(ctx, req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp, err := server.GetPlayerPriceBounds(context.Background(), &marketv1.GetPlayerPriceBoundsRequest{PlayerId: testPlayerID})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.MinPrice != 500 || resp.MaxPrice != 900 || resp.Source != marketv1.PriceBoundsSource_PRICE_BOUNDS_SOURCE_ADMIN {
+		t.Fatalf("expected admin override to win over trades, got %+v", resp)
+	}
+
+	cleared, err := server.ClearPlayerPriceBounds(ctx, &marketv1.ClearPlayerPriceBoundsRequest{PlayerId: testPlayerID})
+	if err != nil || !cleared.Cleared {
+		t.Fatalf("expected override to be cleared, got %+v %v", cleared, err)
+	}
+}
+
+func TestCreateListingOutsidePriceBounds(t *testing.T) {
+	cardID := "22222222-2222-2222-2222-222222222222"
+	repo := &fakeRepo{priceBounds: map[string]PriceBounds{testPlayerID: {Min: 500, Max: 5000, Source: priceBoundsSourceAdmin}}}
+	club := &fakeClub{getMyClubResp: &clubv1.GetMyClubResponse{
+		ClubId: "club-seller",
+		Cards:  []*clubv1.Card{{Id: cardID, PlayerId: testPlayerID}},
+	}}
+	server := NewServer(slog.Default(), repo, club, nil)
+
+	_, err := server.CreateListing(context.Background(), &marketv1.CreateListingRequest{
+		SellerUserId:  "11111111-1111-1111-1111-111111111111",
+		UserCardId:    cardID,
+		StartPrice:    1000,
+		BuyNowPrice:   9000,
+		ExpiresAtUnix: time.Now().Add(time.Hour).Unix(),
+	})
+	st, _ := status.FromError(err)
+	if st.Code() != codes.FailedPrecondition {
+		t.Fatalf("expected FailedPrecondition, got %v", err)
+	}
+	detail, ok := st.Details()[0].(*commonv1.Error)
+	if !ok || detail.Code != errorCodePriceOutOfRange || detail.Metadata["max_price"] != "5000" {
+		t.Fatalf("unexpected error detail: %+v", st.Details())
+	}
+	if repo.createCalls != 0 {
+		t.Fatalf("did not expect listing to be created")
+	}
+}
+
+func TestPlaceBidAndBuyNowOutsidePriceBounds(t *testing.T) {
+	buyNow := int64(8000)
+	repo := &fakeRepo{
+		listing: Listing{
+			ID:            "listing-1",
+			SellerClubID:  "club-seller",
+			PlayerID:      testPlayerID,
+			Status:        listingStatusActive,
+			StartPrice:    1000,
+			BuyNowPrice:   &buyNow,
+			ExpiresAtUnix: time.Now().Add(time.Hour).Unix(),
+		},
+		priceBounds: map[string]PriceBounds{testPlayerID: {Max: 5000, Source: priceBoundsSourceAdmin}},
+	}
+	server := NewServer(slog.Default(), repo, &fakeClub{}, &fakeLock{token: "token", ok: true})
+
+	_, err := server.PlaceBid(context.Background(), &marketv1.PlaceBidRequest{
+		ListingId:    "11111111-1111-1111-1111-111111111111",
+		BidderUserId: "22222222-2222-2222-2222-222222222222",
+		MaxBid:       6000,
+	})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("expected FailedPrecondition for bid, got %v", err)
+	}
+
+	_, err = server.BuyNow(context.Background(), &marketv1.BuyNowRequest{
+		ListingId:   "11111111-1111-1111-1111-111111111111",
+		BuyerUserId: "22222222-2222-2222-2222-222222222222",
+	})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("expected FailedPrecondition for buy now, got %v", err)
+	}
+}
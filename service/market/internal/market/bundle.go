@@ -0,0 +1,173 @@
+package market
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	clubv1 "UltimateTeamX/proto/club/v1"
+	marketv1 "UltimateTeamX/proto/market/v1"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Numero di carte ammesse in un bundle.
+const (
+	minBundleCards = 2
+	maxBundleCards = 10
+)
+
+// createBundleListing mette in vendita piu' carte come un unico lotto.
+// Le carte sono bloccate una alla volta sotto la stessa saga: se un lock fallisce
+// i lock gia' presi vengono rilasciati e nessun listing viene creato.
+func (s *Server) createBundleListing(ctx context.Context, req *marketv1.CreateListingRequest) (*marketv1.CreateListingResponse, error) {
+	cardIDs := req.BundleUserCardIds
+
+	// 1) Nessuna carta del lotto deve essere gia' in un listing attivo.
+	for _, cardID := range cardIDs {
+		existingID, err := s.repo.ActiveListingByCard(ctx, cardID)
+		if err != nil {
+			s.logger.Error("errore verifica listing attivo", "error", err)
+			return nil, status.Error(codes.Internal, "failed to check existing listing")
+		}
+		if existingID != "" {
+			return nil, status.Error(codes.AlreadyExists, "active listing already exists for card "+cardID)
+		}
+	}
+
+	// 2) Risolve seller_club_id e player delle carte via club-svc.
+	sellerClub, err := s.clubForUser(ctx, req.SellerUserId)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkActiveListingsLimit(ctx, sellerClub.ClubId); err != nil {
+		return nil, err
+	}
+	items := make([]ListingItem, 0, len(cardIDs))
+	for _, cardID := range cardIDs {
+		items = append(items, ListingItem{UserCardID: cardID, PlayerID: playerIDForCard(sellerClub, cardID)})
+	}
+	// Il range del lotto e' la somma dei range dei giocatori.
+	bundle := Listing{Kind: listingKindBundle, Items: items}
+	if err := s.checkListingPriceBounds(ctx, bundle, "listing price", req.StartPrice, req.BuyNowPrice); err != nil {
+		return nil, err
+	}
+
+	// 3) Registra la saga prima di toccare club-svc.
+	listingID := uuid.NewString()
+	saga, err := s.startSaga(ctx, sagaKindCreateListing, listingID, req.SellerUserId)
+	if err != nil {
+		return nil, err
+	}
+
+	// 4) Lock di tutte le carte, ognuno registrato prima della chiamata;
+	// al primo rifiuto si compensano i lock gia' presi.
+	for i, cardID := range cardIDs {
+		lockID, err := s.beginSagaStep(ctx, saga, sagaStepCardLock, compensationReleaseCardLock)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.lockCard(ctx, req.SellerUserId, cardID, lockID); err != nil {
+			s.failSagaStep(ctx, saga, err)
+			return nil, err
+		}
+		items[i].LockID = lockID
+	}
+
+	// 5) Inserisce listing e carte del lotto nella stessa transazione.
+	listing := Listing{
+		ID:            listingID,
+		SellerClubID:  sellerClub.ClubId,
+		UserCardID:    cardIDs[0],
+		StartPrice:    req.StartPrice,
+		BuyNowPrice:   optionalPrice(req.BuyNowPrice),
+		ReservePrice:  optionalPrice(req.ReservePrice),
+		Status:        listingStatusActive,
+		ExpiresAtUnix: req.ExpiresAtUnix,
+		Kind:          listingKindBundle,
+		Items:         items,
+	}
+	if err := s.repo.CreateListing(ctx, listing); err != nil {
+		s.logger.Error("errore creazione bundle nel db", "error", err, "listing_id", listingID)
+		_ = s.compensateSaga(ctx, saga)
+		return nil, status.Error(codes.Internal, "failed to create listing")
+	}
+	s.finishSaga(ctx, saga, sagaStatusCompleted)
+
+	s.logger.Info("bundle creato", "listing_id", listingID, "cards", len(items))
+	return &marketv1.CreateListingResponse{ListingId: listingID}, nil
+}
+
+// validateBundleCards valida le carte di un bundle: user_card_id vuoto,
+// da minBundleCards a maxBundleCards UUID distinti.
+func validateBundleCards(req *marketv1.CreateListingRequest) error {
+	if strings.TrimSpace(req.UserCardId) != "" {
+		return errors.New("user_card_id must be empty for bundle listings")
+	}
+	if len(req.BundleUserCardIds) < minBundleCards || len(req.BundleUserCardIds) > maxBundleCards {
+		return errors.New("bundle must contain between 2 and 10 cards")
+	}
+	seen := make(map[string]struct{}, len(req.BundleUserCardIds))
+	for _, cardID := range req.BundleUserCardIds {
+		if !isUUID(cardID) {
+			return errors.New("bundle_user_card_ids must be valid UUIDs")
+		}
+		if _, ok := seen[cardID]; ok {
+			return errors.New("bundle_user_card_ids must not contain duplicates")
+		}
+		seen[cardID] = struct{}{}
+	}
+	return nil
+}
+
+// releaseCardLocks rilascia in club-svc i lock carta del listing (uno per carta nei bundle).
+// Il rilascio e' idempotente sul lock_id: su errore il chiamante puo' ripetere tutto.
+func (s *Server) releaseCardLocks(ctx context.Context, listing Listing) error {
+	lockIDs := listingLockIDs(listing)
+	if len(lockIDs) == 0 {
+		s.logger.Warn("listing senza lock_id, lock carta non rilasciato", "listing_id", listing.ID)
+		return nil
+	}
+	for _, lockID := range lockIDs {
+		if _, err := s.club.ReleaseCardLock(ctx, &clubv1.ReleaseCardLockRequest{LockId: lockID}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// listingLockIDs ritorna i lock carta del listing.
+func listingLockIDs(listing Listing) []string {
+	if listing.Kind == listingKindBundle {
+		lockIDs := make([]string, 0, len(listing.Items))
+		for _, item := range listing.Items {
+			lockIDs = append(lockIDs, item.LockID)
+		}
+		return lockIDs
+	}
+	if listing.LockID == "" {
+		return nil
+	}
+	return []string{listing.LockID}
+}
+
+// listingCardIDs ritorna le carte in vendita nel listing.
+func listingCardIDs(listing Listing) []string {
+	if listing.Kind != listingKindBundle {
+		return []string{listing.UserCardID}
+	}
+	cardIDs := make([]string, 0, len(listing.Items))
+	for _, item := range listing.Items {
+		cardIDs = append(cardIDs, item.UserCardID)
+	}
+	return cardIDs
+}
+
+// listingKindToProto mappa il tipo di listing nell'enum gRPC (SINGLE per i listing storici).
+func listingKindToProto(listing Listing) marketv1.ListingKind {
+	if listing.Kind == listingKindBundle {
+		return marketv1.ListingKind_LISTING_KIND_BUNDLE
+	}
+	return marketv1.ListingKind_LISTING_KIND_SINGLE
+}
@@ -0,0 +1,173 @@
+package market
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"testing"
+	"time"
+
+	clubv1 "UltimateTeamX/proto/club/v1"
+	commonv1 "UltimateTeamX/proto/common/v1"
+	marketv1 "UltimateTeamX/proto/market/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Test suite per i listing BUNDLE.
+
+var bundleCardIDs = []string{
+	"44444444-4444-4444-4444-444444444441",
+	"44444444-4444-4444-4444-444444444442",
+	"44444444-4444-4444-4444-444444444443",
+}
+
+func bundleRequest() *marketv1.CreateListingRequest {
+	return &marketv1.CreateListingRequest{
+		SellerUserId:      "11111111-1111-1111-1111-111111111111",
+		BundleUserCardIds: bundleCardIDs,
+		StartPrice:        3000,
+		ExpiresAtUnix:     time.Now().Add(time.Hour).Unix(),
+	}
+}
+
+func bundleListing() Listing {
+	items := make([]ListingItem, 0, len(bundleCardIDs))
+	for i, cardID := range bundleCardIDs {
+		items = append(items, ListingItem{UserCardID: cardID, LockID: fmt.Sprintf("lock-%d", i+1)})
+	}
+	return Listing{
+		ID:            "listing-1",
+		SellerClubID:  "club-seller",
+		UserCardID:    bundleCardIDs[0],
+		StartPrice:    3000,
+		Status:        listingStatusActive,
+		ExpiresAtUnix: time.Now().Add(-time.Minute).Unix(),
+		Kind:          listingKindBundle,
+		Items:         items,
+	}
+}
+
+// Caso: tutte le carte del lotto bloccate e salvate nello stesso listing.
+func TestCreateBundleListing(t *testing.T) {
+	repo := &fakeRepo{}
+	club := &fakeClub{}
+	server := NewServer(slog.Default(), repo, club, nil)
+
+	if _, err := server.CreateListing(context.Background(), bundleRequest()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if club.lockCalls != len(bundleCardIDs) {
+		t.Fatalf("expected %d LockCard calls, got %d", len(bundleCardIDs), club.lockCalls)
+	}
+	created := repo.createdListing
+	if created.Kind != listingKindBundle || len(created.Items) != len(bundleCardIDs) || created.UserCardID != bundleCardIDs[0] {
+		t.Fatalf("unexpected bundle listing: %+v", created)
+	}
+	if created.Items[2].LockID != club.lockIDs[2] || created.LockID != "" {
+		t.Fatalf("expected one lock per card, got %+v", created.Items)
+	}
+}
+
+// Caso: un lock rifiutato rilascia quelli gia' presi e non crea il listing.
+func TestCreateBundleListingRollsBackLocks(t *testing.T) {
+	repo := &fakeRepo{}
+	club := &fakeClub{lockErr: status.Error(codes.FailedPrecondition, "card locked"), lockFailOnCall: 3}
+	server := NewServer(slog.Default(), repo, club, nil)
+
+	_, err := server.CreateListing(context.Background(), bundleRequest())
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("expected FailedPrecondition, got %v", err)
+	}
+	if club.releaseCalls != 2 || club.releaseLastLockID != club.lockIDs[0] {
+		t.Fatalf("expected locks released in reverse order, got %d calls (last %s)", club.releaseCalls, club.releaseLastLockID)
+	}
+	if repo.createCalls != 0 {
+		t.Fatalf("did not expect listing to be created")
+	}
+}
+
+// Caso: prezzi del bundle fuori dalla somma dei range dei giocatori.
+func TestCreateBundleListingOutsidePriceBounds(t *testing.T) {
+	cards := make([]*clubv1.Card, 0, len(bundleCardIDs))
+	priceBounds := make(map[string]PriceBounds, len(bundleCardIDs))
+	for i, cardID := range bundleCardIDs {
+		playerID := fmt.Sprintf("player-%d", i+1)
+		cards = append(cards, &clubv1.Card{Id: cardID, PlayerId: playerID})
+		priceBounds[playerID] = PriceBounds{Min: 500, Max: 1000, Source: priceBoundsSourceAdmin}
+	}
+	repo := &fakeRepo{priceBounds: priceBounds}
+	club := &fakeClub{getMyClubResp: &clubv1.GetMyClubResponse{ClubId: "club-seller", Cards: cards}}
+	server := NewServer(slog.Default(), repo, club, nil)
+
+	tooLow := bundleRequest()
+	tooLow.StartPrice = 1000
+	tooHigh := bundleRequest()
+	tooHigh.BuyNowPrice = 3500
+
+	for _, req := range []*marketv1.CreateListingRequest{tooLow, tooHigh} {
+		_, err := server.CreateListing(context.Background(), req)
+		st, _ := status.FromError(err)
+		if st.Code() != codes.FailedPrecondition {
+			t.Fatalf("expected FailedPrecondition, got %v", err)
+		}
+		detail, ok := st.Details()[0].(*commonv1.Error)
+		if !ok || detail.Metadata["min_price"] != "1500" || detail.Metadata["max_price"] != "3000" {
+			t.Fatalf("unexpected error detail: %+v", st.Details())
+		}
+	}
+	if club.lockCalls != 0 || repo.createCalls != 0 {
+		t.Fatalf("did not expect locks or listing, got %d locks %d creates", club.lockCalls, repo.createCalls)
+	}
+
+	// Entro il range il lotto viene creato.
+	if _, err := server.CreateListing(context.Background(), bundleRequest()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+// Caso: carte del bundle non valide.
+func TestCreateBundleListingInvalid(t *testing.T) {
+	server := NewServer(slog.Default(), &fakeRepo{}, &fakeClub{}, nil)
+
+	duplicated := bundleRequest()
+	duplicated.BundleUserCardIds = []string{bundleCardIDs[0], bundleCardIDs[0]}
+	withSingleCard := bundleRequest()
+	withSingleCard.UserCardId = bundleCardIDs[0]
+	tooSmall := bundleRequest()
+	tooSmall.BundleUserCardIds = bundleCardIDs[:1]
+
+	for _, req := range []*marketv1.CreateListingRequest{duplicated, withSingleCard, tooSmall} {
+		if _, err := server.CreateListing(context.Background(), req); status.Code(err) != codes.InvalidArgument {
+			t.Fatalf("expected InvalidArgument, got %v", err)
+		}
+	}
+}
+
+// Caso: il settlement di un bundle trasferisce tutte le carte in una sola chiamata.
+func TestBundleSettlementIncludesAllCards(t *testing.T) {
+	server := NewServer(slog.Default(), &fakeRepo{}, &fakeClub{}, nil)
+
+	settle := server.tradeSettlement(bundleListing(), "seller", "buyer", "hold-1", 3000)
+	if len(settle.Cards) != len(bundleCardIDs) || settle.CardLockId != "" {
+		t.Fatalf("unexpected settlement: %+v", settle)
+	}
+	if settle.Cards[1].UserCardId != bundleCardIDs[1] || settle.Cards[1].CardLockId != "lock-2" {
+		t.Fatalf("unexpected bundle card: %+v", settle.Cards[1])
+	}
+}
+
+// Caso: scadenza senza offerte, tutti i lock del lotto rilasciati.
+func TestExpireBundleReleasesAllLocks(t *testing.T) {
+	repo := &fakeRepo{listing: bundleListing()}
+	club := &fakeClub{}
+	server := NewServer(slog.Default(), repo, club, &fakeLock{token: "token", ok: true})
+
+	done, err := server.closeExpiredListing(context.Background(), "listing-1")
+	if err != nil || !done {
+		t.Fatalf("expected listing closed, got %v %v", done, err)
+	}
+	if club.releaseCalls != len(bundleCardIDs) {
+		t.Fatalf("expected %d card locks released, got %d", len(bundleCardIDs), club.releaseCalls)
+	}
+}
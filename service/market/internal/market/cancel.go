@@ -0,0 +1,137 @@
+package market
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	clubv1 "UltimateTeamX/proto/club/v1"
+	marketv1 "UltimateTeamX/proto/market/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// CancelListing ritira un listing ACTIVE del seller e sblocca la carta in club-svc.
+// Con offerte presenti il ritiro e' ammesso solo se e' configurata una penale.
+func (s *Server) CancelListing(ctx context.Context, req *marketv1.CancelListingRequest) (*marketv1.CancelListingResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "request is required")
+	}
+	if strings.TrimSpace(req.ListingId) == "" {
+		return nil, status.Error(codes.InvalidArgument, "listing_id is required")
+	}
+	if strings.TrimSpace(req.SellerUserId) == "" {
+		return nil, status.Error(codes.InvalidArgument, "seller_user_id is required")
+	}
+	if !isUUID(req.ListingId) {
+		return nil, status.Error(codes.InvalidArgument, "listing_id must be a valid UUID")
+	}
+	if !isUUID(req.SellerUserId) {
+		return nil, status.Error(codes.InvalidArgument, "seller_user_id must be a valid UUID")
+	}
+	if s.locker == nil {
+		return nil, status.Error(codes.Internal, "redis lock not configured")
+	}
+
+	// 1) Risolve seller_club_id via club-svc.
+	sellerClubID, err := s.clubIDForUser(ctx, req.SellerUserId)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2) Lock Redis del listing: esclude bid, buy now e worker di scadenza.
+	unlock, err := s.acquireListingLock(ctx, req.ListingId)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	// 3) Carica listing e verifica stato e proprietario.
+	listing, err := s.loadListing(ctx, req.ListingId)
+	if err != nil {
+		return nil, err
+	}
+	if listing.SellerClubID != sellerClubID {
+		return nil, status.Error(codes.PermissionDenied, "listing does not belong to seller")
+	}
+	if listing.Status != listingStatusActive {
+		return nil, status.Error(codes.FailedPrecondition, "listing not active")
+	}
+	if listing.ExpiresAtUnix <= time.Now().Unix() {
+		return nil, status.Error(codes.FailedPrecondition, "listing expired")
+	}
+
+	// 4) Con offerte: serve la penale configurata, addebitata al seller.
+	// reference_id = listing_id rende l'addebito idempotente sui retry.
+	// L'hold del best bidder e' letto prima dell'addebito, per rilasciarlo dopo il ritiro.
+	var penalty int64
+	var bestBidHoldID string
+	if listing.BestBid != nil {
+		if s.cancelPenaltyBps <= 0 {
+			return nil, status.Error(codes.FailedPrecondition, "listing has bids")
+		}
+		if bestBidHoldID, err = s.bestBidHoldID(ctx, listing); err != nil {
+			return nil, err
+		}
+		penalty = cancelPenalty(*listing.BestBid, s.cancelPenaltyBps)
+		if _, err := s.club.DebitCredits(ctx, &clubv1.DebitCreditsRequest{
+			UserId:      req.SellerUserId,
+			Amount:      penalty,
+			Reason:      "market_cancel_penalty",
+			ReferenceId: listing.ID,
+		}); err != nil {
+			if grpcStatus, ok := status.FromError(err); ok {
+				s.logger.Warn("penale ritiro rifiutata da club-svc", "code", grpcStatus.Code(), "error", grpcStatus.Message())
+				return nil, grpcStatus.Err()
+			}
+			s.logger.Error("errore addebito penale ritiro", "error", err, "listing_id", listing.ID)
+			return nil, status.Error(codes.Internal, "failed to charge cancel penalty")
+		}
+	}
+
+	// 5) Saga CANCEL_LISTING: lock carta e hold del best bidder sono registrati come
+	// rilasci prima dell'update, cosi' dopo un crash il recovery li completa.
+	lockIDs := listingLockIDs(listing)
+	if len(lockIDs) == 0 {
+		s.logger.Warn("listing senza lock_id, lock carta non rilasciato", "listing_id", listing.ID)
+	}
+	saga, err := s.startSaga(ctx, sagaKindCancelListing, listing.ID, req.SellerUserId)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.recordReleaseSteps(ctx, saga, sagaStepLockRelease, lockIDs...); err != nil {
+		return nil, err
+	}
+	if err := s.recordReleaseSteps(ctx, saga, sagaStepHoldRelease, bestBidHoldID); err != nil {
+		return nil, err
+	}
+
+	// 6) Marca il listing CANCELLED prima di sbloccare la carta: se l'update fallisce
+	// il listing resta in vendita con la carta ancora bloccata.
+	if err := s.repo.MarkListingCancelled(ctx, listing.ID); err != nil {
+		_ = s.compensateSaga(ctx, saga)
+		if errors.Is(err, ErrListingNotActive) {
+			return nil, status.Error(codes.FailedPrecondition, "listing not active")
+		}
+		s.logger.Error("errore aggiornamento listing a CANCELLED", "error", err, "listing_id", listing.ID)
+		return nil, status.Error(codes.Internal, "failed to cancel listing")
+	}
+
+	// 7) Sblocca la carta, rilascia l'hold del best bidder e notifica i watcher.
+	// Il listing e' gia' chiuso: un rilascio fallito lascia la saga STARTED per il recovery.
+	_ = s.completeSaga(ctx, saga)
+	s.publishEvent(ctx, listing, marketv1.ListingEventType_LISTING_EVENT_TYPE_CANCELLED, 0, "")
+
+	s.logger.Info("listing ritirato", "listing_id", listing.ID, "penalty", penalty)
+	return &marketv1.CancelListingResponse{Cancelled: true, Penalty: penalty}, nil
+}
+
+// cancelPenalty calcola la penale in basis point del best_bid (minimo 1 credito).
+func cancelPenalty(bestBid, bps int64) int64 {
+	penalty := bestBid * bps / 10000
+	if penalty < 1 {
+		return 1
+	}
+	return penalty
+}
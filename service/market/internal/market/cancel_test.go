@@ -0,0 +1,152 @@
+package market
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	clubv1 "UltimateTeamX/proto/club/v1"
+	marketv1 "UltimateTeamX/proto/market/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Test suite per il ritiro dei listing.
+
+func cancelRequest() *marketv1.CancelListingRequest {
+	return &marketv1.CancelListingRequest{
+		ListingId:    "11111111-1111-1111-1111-111111111111",
+		SellerUserId: "22222222-2222-2222-2222-222222222222",
+	}
+}
+
+func TestCancelListingWithoutBids(t *testing.T) {
+	repo := &fakeRepo{
+		listing: Listing{
+			ID:            "listing-1",
+			SellerClubID:  "club-seller",
+			Status:        listingStatusActive,
+			ExpiresAtUnix: time.Now().Add(time.Hour).Unix(),
+			LockID:        "lock-1",
+		},
+	}
+	club := &fakeClub{getMyClubResp: &clubv1.GetMyClubResponse{ClubId: "club-seller"}}
+	server := NewServer(slog.Default(), repo, club, &fakeLock{token: "token", ok: true})
+
+	resp, err := server.CancelListing(context.Background(), cancelRequest())
+	if err != nil {
+		t.Fatalf("expected success, got error: %v", err)
+	}
+	if !resp.Cancelled || resp.Penalty != 0 {
+		t.Fatalf("unexpected response: %+v", resp)
+	}
+	if club.releaseCalls != 1 || club.releaseLastLockID != "lock-1" {
+		t.Fatalf("expected ReleaseCardLock with lock-1")
+	}
+	if repo.cancelledCalls != 1 {
+		t.Fatalf("expected listing to be marked cancelled")
+	}
+	if club.lastDebit != nil {
+		t.Fatalf("did not expect a penalty")
+	}
+}
+
+func TestCancelListingKeepsCardLockedWhenUpdateFails(t *testing.T) {
+	repo := &fakeRepo{
+		listing: Listing{
+			ID:            "listing-1",
+			SellerClubID:  "club-seller",
+			Status:        listingStatusActive,
+			ExpiresAtUnix: time.Now().Add(time.Hour).Unix(),
+			LockID:        "lock-1",
+		},
+		cancelledErr: ErrListingNotActive,
+	}
+	club := &fakeClub{getMyClubResp: &clubv1.GetMyClubResponse{ClubId: "club-seller"}}
+	server := NewServer(slog.Default(), repo, club, &fakeLock{token: "token", ok: true})
+
+	_, err := server.CancelListing(context.Background(), cancelRequest())
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("expected FailedPrecondition, got %v", err)
+	}
+	if club.releaseCalls != 0 {
+		t.Fatalf("did not expect card lock release when the listing stays active")
+	}
+}
+
+func TestCancelListingWithBidsRejectedWithoutPenalty(t *testing.T) {
+	bestBid := int64(1500)
+	bestBidder := "club-bidder"
+	repo := &fakeRepo{
+		listing: Listing{
+			ID:               "listing-1",
+			SellerClubID:     "club-seller",
+			Status:           listingStatusActive,
+			ExpiresAtUnix:    time.Now().Add(time.Hour).Unix(),
+			BestBid:          &bestBid,
+			BestBidderClubID: &bestBidder,
+		},
+	}
+	club := &fakeClub{getMyClubResp: &clubv1.GetMyClubResponse{ClubId: "club-seller"}}
+	server := NewServer(slog.Default(), repo, club, &fakeLock{token: "token", ok: true})
+
+	_, err := server.CancelListing(context.Background(), cancelRequest())
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("expected FailedPrecondition, got %v", err)
+	}
+	if repo.cancelledCalls != 0 || club.releaseCalls != 0 {
+		t.Fatalf("did not expect listing to be cancelled")
+	}
+}
+
+func TestCancelListingWithBidsChargesPenalty(t *testing.T) {
+	bestBid := int64(2000)
+	bestBidder := "club-bidder"
+	repo := &fakeRepo{
+		listing: Listing{
+			ID:               "listing-1",
+			SellerClubID:     "club-seller",
+			Status:           listingStatusActive,
+			ExpiresAtUnix:    time.Now().Add(time.Hour).Unix(),
+			BestBid:          &bestBid,
+			BestBidderClubID: &bestBidder,
+			LockID:           "lock-1",
+		},
+		holdIDForBid: "hold-best",
+	}
+	club := &fakeClub{getMyClubResp: &clubv1.GetMyClubResponse{ClubId: "club-seller"}}
+	server := NewServer(slog.Default(), repo, club, &fakeLock{token: "token", ok: true}, WithCancelPenaltyBps(500))
+
+	resp, err := server.CancelListing(context.Background(), cancelRequest())
+	if err != nil {
+		t.Fatalf("expected success, got error: %v", err)
+	}
+	if resp.Penalty != 100 {
+		t.Fatalf("expected penalty 100, got %d", resp.Penalty)
+	}
+	if club.lastDebit == nil || club.lastDebit.Amount != 100 || club.lastDebit.ReferenceId != "listing-1" {
+		t.Fatalf("unexpected debit: %+v", club.lastDebit)
+	}
+	if club.releaseHoldCalls != 1 || club.releaseHoldID != "hold-best" {
+		t.Fatalf("expected best bidder hold to be released")
+	}
+}
+
+func TestCancelListingNotOwner(t *testing.T) {
+	repo := &fakeRepo{
+		listing: Listing{
+			ID:            "listing-1",
+			SellerClubID:  "club-other",
+			Status:        listingStatusActive,
+			ExpiresAtUnix: time.Now().Add(time.Hour).Unix(),
+		},
+	}
+	club := &fakeClub{getMyClubResp: &clubv1.GetMyClubResponse{ClubId: "club-seller"}}
+	server := NewServer(slog.Default(), repo, club, &fakeLock{token: "token", ok: true})
+
+	_, err := server.CancelListing(context.Background(), cancelRequest())
+	if status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("expected PermissionDenied, got %v", err)
+	}
+}
@@ -0,0 +1,208 @@
+package market
+
+import (
+	"context"
+	"time"
+
+	"UltimateTeamX/pkg/redisx"
+	marketv1 "UltimateTeamX/proto/market/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// EventBus distribuisce gli eventi dei listing tra le repliche di market-svc.
+type EventBus interface {
+	Publish(ctx context.Context, event *marketv1.ListingEvent) error
+	// Subscribe ritorna gli eventi del listing finche' non viene chiamata la funzione di chiusura.
+	Subscribe(ctx context.Context, listingID string) (<-chan *marketv1.ListingEvent, func(), error)
+}
+
+// WithEventBus abilita la pubblicazione degli eventi e WatchListing.
+func WithEventBus(bus EventBus) Option {
+	return func(s *Server) {
+		s.events = bus
+	}
+}
+
+// RedisEventBus implementa EventBus su Redis pub/sub, un canale per listing.
+type RedisEventBus struct {
+	pubsub *redisx.PubSub
+}
+
+// NewRedisEventBus usa il pub/sub Redis condiviso.
+func NewRedisEventBus(pubsub *redisx.PubSub) *RedisEventBus {
+	return &RedisEventBus{pubsub: pubsub}
+}
+
+func (b *RedisEventBus) Publish(ctx context.Context, event *marketv1.ListingEvent) error {
+	payload, err := proto.Marshal(event)
+	if err != nil {
+		return err
+	}
+	return b.pubsub.Publish(ctx, listingEventsChannel(event.ListingId), payload)
+}
+
+func (b *RedisEventBus) Subscribe(ctx context.Context, listingID string) (<-chan *marketv1.ListingEvent, func(), error) {
+	ctx, cancel := context.WithCancel(ctx)
+	payloads, closeSub, err := b.pubsub.Subscribe(ctx, listingEventsChannel(listingID))
+	if err != nil {
+		cancel()
+		return nil, nil, err
+	}
+
+	events := make(chan *marketv1.ListingEvent)
+	go func() {
+		defer close(events)
+		for payload := range payloads {
+			event := &marketv1.ListingEvent{}
+			if err := proto.Unmarshal(payload, event); err != nil {
+				continue
+			}
+			select {
+			case events <- event:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return events, func() {
+		cancel()
+		_ = closeSub()
+	}, nil
+}
+
+// listingEventsChannel e' il canale Redis degli eventi di un listing.
+func listingEventsChannel(listingID string) string {
+	return "market:listing:" + listingID + ":events"
+}
+
+// WatchListing invia in streaming gli eventi del listing finche' non si chiude (SOLD, EXPIRED, CANCELLED).
+// Se il listing e' gia' chiuso invia subito l'evento finale.
+func (s *Server) WatchListing(req *marketv1.WatchListingRequest, stream grpc.ServerStreamingServer[marketv1.ListingEvent]) error {
+	if req == nil {
+		return status.Error(codes.InvalidArgument, "request is required")
+	}
+	if req.ListingId == "" {
+		return status.Error(codes.InvalidArgument, "listing_id is required")
+	}
+	if !isUUID(req.ListingId) {
+		return status.Error(codes.InvalidArgument, "listing_id must be a valid UUID")
+	}
+	if s.events == nil {
+		return status.Error(codes.Unavailable, "listing events not configured")
+	}
+	ctx := stream.Context()
+
+	// 1) Iscrizione prima della lettura: nessun evento va perso tra lettura e stream.
+	events, unsubscribe, err := s.events.Subscribe(ctx, req.ListingId)
+	if err != nil {
+		s.logger.Error("errore iscrizione eventi listing", "error", err, "listing_id", req.ListingId)
+		return status.Error(codes.Internal, "failed to watch listing")
+	}
+	defer unsubscribe()
+
+	// 2) Listing gia' chiuso: evento finale e fine stream.
+	listing, err := s.loadListing(ctx, req.ListingId)
+	if err != nil {
+		return err
+	}
+	if final := closedListingEvent(listing); final != nil {
+		if final.Type == marketv1.ListingEventType_LISTING_EVENT_TYPE_SOLD && listing.BestBidderClubID != nil {
+			if final.UserId, err = s.userIDForClub(ctx, *listing.BestBidderClubID); err != nil {
+				return err
+			}
+		}
+		return stream.Send(final)
+	}
+
+	// 3) Inoltra gli eventi fino all'evento finale o alla chiusura del client.
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-events:
+			if !ok {
+				if ctx.Err() != nil {
+					return nil
+				}
+				return status.Error(codes.Unavailable, "listing events interrupted")
+			}
+			if err := stream.Send(event); err != nil {
+				return err
+			}
+			if isFinalListingEvent(event.Type) {
+				return nil
+			}
+		}
+	}
+}
+
+// publishEvent pubblica un evento del listing; gli errori sono solo loggati
+// perche' lo stato nel DB resta la fonte di verita'.
+func (s *Server) publishEvent(ctx context.Context, listing Listing, eventType marketv1.ListingEventType, amount int64, userID string) {
+	if s.events == nil {
+		return
+	}
+	event := &marketv1.ListingEvent{
+		ListingId:      listing.ID,
+		Type:           eventType,
+		Amount:         amount,
+		UserId:         userID,
+		ExpiresAtUnix:  listing.ExpiresAtUnix,
+		OccurredAtUnix: time.Now().Unix(),
+	}
+	// Il publish non deve fallire se la richiesta originale e' gia' stata cancellata.
+	ctx = context.WithoutCancel(ctx)
+	if err := s.events.Publish(ctx, event); err != nil {
+		s.logger.Warn("pubblicazione evento listing fallita", "error", err, "listing_id", listing.ID, "type", eventType)
+	}
+}
+
+// publishOutbid avvisa il best bidder precedente (se presente) che e' stato superato.
+func (s *Server) publishOutbid(ctx context.Context, listing Listing, newBid int64) {
+	if s.events == nil || listing.BestBidderClubID == nil {
+		return
+	}
+	userID, err := s.userIDForClub(ctx, *listing.BestBidderClubID)
+	if err != nil {
+		s.logger.Warn("bidder superato non risolto", "error", err, "listing_id", listing.ID)
+	}
+	s.publishEvent(ctx, listing, marketv1.ListingEventType_LISTING_EVENT_TYPE_OUTBID, newBid, userID)
+}
+
+// closedListingEvent ritorna l'evento finale per un listing gia' chiuso nel DB (nil se ACTIVE).
+func closedListingEvent(listing Listing) *marketv1.ListingEvent {
+	event := &marketv1.ListingEvent{
+		ListingId:      listing.ID,
+		ExpiresAtUnix:  listing.ExpiresAtUnix,
+		OccurredAtUnix: time.Now().Unix(),
+	}
+	switch listing.Status {
+	case listingStatusSold:
+		event.Type = marketv1.ListingEventType_LISTING_EVENT_TYPE_SOLD
+		if listing.BestBid != nil {
+			event.Amount = *listing.BestBid
+		}
+	case listingStatusExpired:
+		event.Type = marketv1.ListingEventType_LISTING_EVENT_TYPE_EXPIRED
+	case listingStatusCancelled:
+		event.Type = marketv1.ListingEventType_LISTING_EVENT_TYPE_CANCELLED
+	default:
+		return nil
+	}
+	return event
+}
+
+// isFinalListingEvent indica gli eventi dopo i quali il listing non cambia piu'.
+func isFinalListingEvent(eventType marketv1.ListingEventType) bool {
+	switch eventType {
+	case marketv1.ListingEventType_LISTING_EVENT_TYPE_SOLD,
+		marketv1.ListingEventType_LISTING_EVENT_TYPE_EXPIRED,
+		marketv1.ListingEventType_LISTING_EVENT_TYPE_CANCELLED:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,173 @@
+package market
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	clubv1 "UltimateTeamX/proto/club/v1"
+	marketv1 "UltimateTeamX/proto/market/v1"
+	"google.golang.org/grpc"
+)
+
+// Test suite per eventi dei listing e WatchListing.
+
+// fakeEventBus registra gli eventi pubblicati e consegna quelli preparati ai watcher.
+type fakeEventBus struct {
+	published []*marketv1.ListingEvent
+	incoming  chan *marketv1.ListingEvent
+	closed    bool
+}
+
+func (b *fakeEventBus) Publish(_ context.Context, event *marketv1.ListingEvent) error {
+	b.published = append(b.published, event)
+	return nil
+}
+
+func (b *fakeEventBus) Subscribe(_ context.Context, _ string) (<-chan *marketv1.ListingEvent, func(), error) {
+	return b.incoming, func() { b.closed = true }, nil
+}
+
+// fakeWatchStream simula lo stream server di WatchListing.
+type fakeWatchStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	sent []*marketv1.ListingEvent
+}
+
+func (s *fakeWatchStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *fakeWatchStream) Send(event *marketv1.ListingEvent) error {
+	s.sent = append(s.sent, event)
+	return nil
+}
+
+func TestPlaceBidPublishesBidAndOutbid(t *testing.T) {
+	previousBid := int64(1200)
+	previousBidder := "club-previous"
+	repo := &fakeRepo{
+		listing: Listing{
+			ID:               "listing-1",
+			Status:           listingStatusActive,
+			StartPrice:       1000,
+			ExpiresAtUnix:    time.Now().Add(time.Hour).Unix(),
+			BestBid:          &previousBid,
+			BestBidderClubID: &previousBidder,
+		},
+	}
+	club := &fakeClub{
+		getMyClubResp: &clubv1.GetMyClubResponse{ClubId: "club-bidder"},
+		clubOwners:    map[string]string{"club-previous": "previous-user"},
+	}
+	bus := &fakeEventBus{}
+	server := NewServer(slog.Default(), repo, club, &fakeLock{token: "token", ok: true}, WithEventBus(bus))
+
+	_, err := server.PlaceBid(context.Background(), &marketv1.PlaceBidRequest{
+		ListingId:    "11111111-1111-1111-1111-111111111111",
+		BidderUserId: "22222222-2222-2222-2222-222222222222",
+		BidAmount:    1500,
+	})
+	if err != nil {
+		t.Fatalf("expected success, got error: %v", err)
+	}
+	if len(bus.published) != 2 {
+		t.Fatalf("expected 2 events, got %d", len(bus.published))
+	}
+	bid, outbid := bus.published[0], bus.published[1]
+	if bid.Type != marketv1.ListingEventType_LISTING_EVENT_TYPE_BID || bid.Amount != 1500 || bid.UserId != "22222222-2222-2222-2222-222222222222" {
+		t.Fatalf("unexpected bid event: %+v", bid)
+	}
+	if outbid.Type != marketv1.ListingEventType_LISTING_EVENT_TYPE_OUTBID || outbid.UserId != "previous-user" {
+		t.Fatalf("unexpected outbid event: %+v", outbid)
+	}
+}
+
+func TestWatchListingStreamsUntilFinalEvent(t *testing.T) {
+	repo := &fakeRepo{
+		listing: Listing{
+			ID:            "listing-1",
+			Status:        listingStatusActive,
+			ExpiresAtUnix: time.Now().Add(time.Hour).Unix(),
+		},
+	}
+	bus := &fakeEventBus{incoming: make(chan *marketv1.ListingEvent, 3)}
+	bus.incoming <- &marketv1.ListingEvent{ListingId: "listing-1", Type: marketv1.ListingEventType_LISTING_EVENT_TYPE_BID, Amount: 1500}
+	bus.incoming <- &marketv1.ListingEvent{ListingId: "listing-1", Type: marketv1.ListingEventType_LISTING_EVENT_TYPE_SOLD, Amount: 2000}
+	bus.incoming <- &marketv1.ListingEvent{ListingId: "listing-1", Type: marketv1.ListingEventType_LISTING_EVENT_TYPE_BID, Amount: 9999}
+	server := NewServer(slog.Default(), repo, &fakeClub{}, nil, WithEventBus(bus))
+	stream := &fakeWatchStream{ctx: context.Background()}
+
+	if err := server.WatchListing(&marketv1.WatchListingRequest{ListingId: "11111111-1111-1111-1111-111111111111"}, stream); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(stream.sent) != 2 || stream.sent[1].Type != marketv1.ListingEventType_LISTING_EVENT_TYPE_SOLD {
+		t.Fatalf("expected stream to stop after SOLD, got %+v", stream.sent)
+	}
+	if !bus.closed {
+		t.Fatalf("expected subscription to be closed")
+	}
+}
+
+func TestWatchListingClosedListingSendsFinalEvent(t *testing.T) {
+	repo := &fakeRepo{
+		listing: Listing{
+			ID:            "listing-1",
+			Status:        listingStatusCancelled,
+			ExpiresAtUnix: time.Now().Add(time.Hour).Unix(),
+		},
+	}
+	bus := &fakeEventBus{incoming: make(chan *marketv1.ListingEvent)}
+	server := NewServer(slog.Default(), repo, &fakeClub{}, nil, WithEventBus(bus))
+	stream := &fakeWatchStream{ctx: context.Background()}
+
+	if err := server.WatchListing(&marketv1.WatchListingRequest{ListingId: "11111111-1111-1111-1111-111111111111"}, stream); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(stream.sent) != 1 || stream.sent[0].Type != marketv1.ListingEventType_LISTING_EVENT_TYPE_CANCELLED {
+		t.Fatalf("expected single CANCELLED event, got %+v", stream.sent)
+	}
+}
+
+func TestPlaceBidSoftCloseExtensionPublished(t *testing.T) {
+	expiresAt := time.Now().Add(10 * time.Second).Unix()
+	repo := &fakeRepo{
+		listing: Listing{
+			ID:            "listing-1",
+			Status:        listingStatusActive,
+			StartPrice:    1000,
+			ExpiresAtUnix: expiresAt,
+		},
+		insertExpiresAt: expiresAt + 30,
+	}
+	bus := &fakeEventBus{}
+	server := NewServer(slog.Default(), repo, &fakeClub{}, &fakeLock{token: "token", ok: true},
+		WithEventBus(bus),
+		WithSoftClose(time.Minute, 30*time.Second, 5),
+	)
+
+	_, err := server.PlaceBid(context.Background(), &marketv1.PlaceBidRequest{
+		ListingId:    "11111111-1111-1111-1111-111111111111",
+		BidderUserId: "22222222-2222-2222-2222-222222222222",
+		BidAmount:    1500,
+	})
+	if err != nil {
+		t.Fatalf("expected success, got error: %v", err)
+	}
+	want := SoftClose{Window: time.Minute, Extension: 30 * time.Second, MaxExtensions: 5}
+	if repo.lastInsert.softClose != want {
+		t.Fatalf("expected soft-close config to reach the repo, got %+v", repo.lastInsert.softClose)
+	}
+	if len(bus.published) != 2 {
+		t.Fatalf("expected BID and EXTENDED events, got %d", len(bus.published))
+	}
+	extended := bus.published[1]
+	if extended.Type != marketv1.ListingEventType_LISTING_EVENT_TYPE_EXTENDED || extended.ExpiresAtUnix != expiresAt+30 {
+		t.Fatalf("unexpected extension event: %+v", extended)
+	}
+	if bus.published[0].ExpiresAtUnix != expiresAt+30 {
+		t.Fatalf("expected BID event to carry the new expires_at")
+	}
+}
@@ -0,0 +1,197 @@
+package market
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	clubv1 "UltimateTeamX/proto/club/v1"
+	marketv1 "UltimateTeamX/proto/market/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ExpiryWorker chiude periodicamente i listing scaduti (SOLD al best bidder o EXPIRED).
+// E' sicuro con piu' repliche: ogni listing e' gestito sotto il lock Redis del listing
+// e gli update di stato sono condizionati a status = 'ACTIVE'.
+type ExpiryWorker struct {
+	logger    *slog.Logger
+	server    *Server
+	interval  time.Duration
+	batchSize int
+}
+
+// NewExpiryWorker riusa repo, club client e lock del server gRPC.
+func NewExpiryWorker(logger *slog.Logger, server *Server, interval time.Duration, batchSize int) *ExpiryWorker {
+	return &ExpiryWorker{logger: logger, server: server, interval: interval, batchSize: batchSize}
+}
+
+// Run esegue un giro ad ogni tick finche' il context non viene chiuso.
+func (w *ExpiryWorker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	w.logger.Info("expiry worker avviato", "interval", w.interval, "batch_size", w.batchSize)
+	for {
+		if _, err := w.RunOnce(ctx); err != nil && ctx.Err() == nil {
+			w.logger.Error("errore giro expiry worker", "error", err)
+		}
+		select {
+		case <-ctx.Done():
+			w.logger.Info("expiry worker fermato")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce processa un batch di listing scaduti e ritorna quanti ne ha chiusi.
+// I listing non chiusi (lock occupato, errori club-svc) restano ACTIVE e vengono ripresi al giro dopo.
+func (w *ExpiryWorker) RunOnce(ctx context.Context) (int, error) {
+	ids, err := w.server.repo.ListExpiredListingIDs(ctx, w.batchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	closed := 0
+	for _, id := range ids {
+		if ctx.Err() != nil {
+			return closed, ctx.Err()
+		}
+		done, err := w.server.closeExpiredListing(ctx, id)
+		if err != nil {
+			w.logger.Warn("chiusura listing scaduto fallita", "error", err, "listing_id", id)
+			continue
+		}
+		if done {
+			closed++
+		}
+	}
+	return closed, nil
+}
+
+// closeExpiredListing chiude un listing scaduto sotto lock Redis.
+// Ritorna false senza errore se il listing e' gestito da un'altra replica o non e' piu' da chiudere.
+func (s *Server) closeExpiredListing(ctx context.Context, listingID string) (bool, error) {
+	if s.locker == nil {
+		return false, errors.New("redis lock not configured")
+	}
+
+	// 1) Lock del listing: esclude PlaceBid/BuyNow e le altre repliche.
+	unlock, err := s.acquireListingLock(ctx, listingID)
+	if err != nil {
+		if status.Code(err) == codes.FailedPrecondition {
+			return false, nil
+		}
+		return false, err
+	}
+	defer unlock()
+
+	// 2) Ricarica sotto lock: lo stato potrebbe essere cambiato dopo la query batch.
+	listing, err := s.repo.GetListing(ctx, listingID)
+	if err != nil {
+		return false, err
+	}
+	if listing.Status != listingStatusActive || listing.ExpiresAtUnix > time.Now().Unix() {
+		return false, nil
+	}
+
+	// 3) Nessuna offerta: sblocca la carta e chiude come EXPIRED.
+	if listing.BestBid == nil || listing.BestBidderClubID == nil {
+		return true, s.expireListing(ctx, listing)
+	}
+
+	// 4) Riserva non raggiunta: nessuna vendita, hold dei bidder rilasciati.
+	if !reserveMet(listing) {
+		return true, s.expireUnderReserve(ctx, listing)
+	}
+
+	// 5) Con offerte: settlement verso il best bidder e chiusura come SOLD.
+	return true, s.settleAuction(ctx, listing)
+}
+
+// reserveMet indica se il best_bid raggiunge la riserva (sempre vero senza riserva).
+func reserveMet(listing Listing) bool {
+	if listing.ReservePrice == nil {
+		return true
+	}
+	return listing.BestBid != nil && *listing.BestBid >= *listing.ReservePrice
+}
+
+// expireUnderReserve chiude come EXPIRED un'asta con offerte sotto la riserva.
+func (s *Server) expireUnderReserve(ctx context.Context, listing Listing) error {
+	s.logger.Info("riserva non raggiunta", "listing_id", listing.ID, "best_bid", *listing.BestBid)
+	return s.expireWithBids(ctx, listing)
+}
+
+// expireWithBids chiude come EXPIRED un'asta con offerte ma senza vendita, rilasciando
+// gli hold di tutti i bidder (vincitore incluso) e il lock carta.
+// Gli hold sono rilasciati prima dell'update: su errore il giro successivo ripete tutto.
+func (s *Server) expireWithBids(ctx context.Context, listing Listing) error {
+	holdIDs, err := s.repo.ListBidHoldIDs(ctx, listing.ID)
+	if err != nil {
+		return err
+	}
+	for _, holdID := range holdIDs {
+		if _, err := s.club.ReleaseCreditHold(ctx, &clubv1.ReleaseCreditHoldRequest{HoldId: holdID}); err != nil {
+			return err
+		}
+	}
+	return s.expireListing(ctx, listing)
+}
+
+// expireListing rilascia il lock carta e marca il listing EXPIRED.
+// Il rilascio avviene prima dell'update: dopo un crash il giro successivo lo ripete.
+func (s *Server) expireListing(ctx context.Context, listing Listing) error {
+	if err := s.releaseCardLocks(ctx, listing); err != nil {
+		return err
+	}
+
+	if err := s.repo.MarkListingExpired(ctx, listing.ID); err != nil && !errors.Is(err, ErrListingNotActive) {
+		return err
+	}
+	s.publishEvent(ctx, listing, marketv1.ListingEventType_LISTING_EVENT_TYPE_EXPIRED, 0, "")
+	s.logger.Info("listing scaduto senza vendita", "listing_id", listing.ID)
+	return nil
+}
+
+// settleAuction regola l'asta al best bidder e marca il listing SOLD.
+// SettleTrade usa il listing_id come trade_id: dopo un crash il retry non regola due volte.
+// Un FailedPrecondition chiude l'asta come EXPIRED; gli altri errori la lasciano ACTIVE
+// per il giro successivo.
+func (s *Server) settleAuction(ctx context.Context, listing Listing) error {
+	buyerClubID := *listing.BestBidderClubID
+	price := *listing.BestBid
+
+	sellerUserID, err := s.userIDForClub(ctx, listing.SellerClubID)
+	if err != nil {
+		return err
+	}
+	buyerUserID, err := s.userIDForClub(ctx, buyerClubID)
+	if err != nil {
+		return err
+	}
+	holdID, err := s.repo.GetHoldIDForBid(ctx, listing.ID, buyerClubID, price)
+	if err != nil {
+		return err
+	}
+
+	settle := s.tradeSettlement(listing, sellerUserID, buyerUserID, holdID, price)
+	if _, err := s.club.SettleTrade(ctx, settle); err != nil {
+		// Rifiuto definitivo (buyer che possiede gia' il giocatore, hold o lock carta non
+		// piu' attivi): ripetere non serve, l'asta chiude senza vendita.
+		if status.Code(err) == codes.FailedPrecondition {
+			s.logger.Warn("settlement asta rifiutato, listing chiuso senza vendita", "error", status.Convert(err).Message(), "listing_id", listing.ID, "buyer_club_id", buyerClubID)
+			return s.expireWithBids(ctx, listing)
+		}
+		return err
+	}
+
+	if err := s.repo.MarkListingSold(ctx, tradeRecord(settle, listing, buyerClubID)); err != nil && !errors.Is(err, ErrListingNotActive) {
+		return err
+	}
+	s.publishEvent(ctx, listing, marketv1.ListingEventType_LISTING_EVENT_TYPE_SOLD, price, buyerUserID)
+	s.logger.Info("asta chiusa", "listing_id", listing.ID, "buyer_club_id", buyerClubID, "price", price, "tax", settle.TaxAmount)
+	return nil
+}
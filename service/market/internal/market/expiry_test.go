@@ -0,0 +1,211 @@
+package market
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Test suite per il worker di chiusura dei listing scaduti.
+
+func TestExpiryWorkerSettlesBestBidder(t *testing.T) {
+	bestBid := int64(1500)
+	bestBidder := "club-bidder"
+	repo := &fakeRepo{
+		expiredIDs: []string{"listing-1"},
+		listing: Listing{
+			ID:               "listing-1",
+			SellerClubID:     "club-seller",
+			UserCardID:       "card-1",
+			Status:           listingStatusActive,
+			StartPrice:       1000,
+			ExpiresAtUnix:    time.Now().Add(-time.Minute).Unix(),
+			BestBid:          &bestBid,
+			BestBidderClubID: &bestBidder,
+			LockID:           "lock-1",
+		},
+		holdIDForBid: "hold-best",
+	}
+	club := &fakeClub{clubOwners: map[string]string{
+		"club-seller": "seller-user",
+		"club-bidder": "bidder-user",
+	}}
+	server := NewServer(slog.Default(), repo, club, &fakeLock{token: "token", ok: true})
+	worker := NewExpiryWorker(slog.Default(), server, time.Second, 10)
+
+	closed, err := worker.RunOnce(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if closed != 1 {
+		t.Fatalf("expected 1 closed listing, got %d", closed)
+	}
+	if club.settleCalls != 1 {
+		t.Fatalf("expected SettleTrade to be called once, got %d", club.settleCalls)
+	}
+	if club.lastSettle.BuyerUserId != "bidder-user" || club.lastSettle.SellerUserId != "seller-user" {
+		t.Fatalf("unexpected settle users: %+v", club.lastSettle)
+	}
+	if club.lastSettle.HoldId != "hold-best" || club.lastSettle.TradeId != "listing-1" || club.lastSettle.Amount != bestBid || club.lastSettle.CardLockId != "lock-1" {
+		t.Fatalf("unexpected settle payload: %+v", club.lastSettle)
+	}
+	if repo.soldCalls != 1 || repo.lastSold.buyerClubID != bestBidder {
+		t.Fatalf("expected listing to be marked sold")
+	}
+	if club.releaseCalls != 0 {
+		t.Fatalf("did not expect card lock release on sale")
+	}
+}
+
+func TestExpiryWorkerExpiresWithoutBids(t *testing.T) {
+	repo := &fakeRepo{
+		expiredIDs: []string{"listing-1"},
+		listing: Listing{
+			ID:            "listing-1",
+			SellerClubID:  "club-seller",
+			Status:        listingStatusActive,
+			StartPrice:    1000,
+			ExpiresAtUnix: time.Now().Add(-time.Minute).Unix(),
+			LockID:        "lock-1",
+		},
+	}
+	club := &fakeClub{}
+	server := NewServer(slog.Default(), repo, club, &fakeLock{token: "token", ok: true})
+	worker := NewExpiryWorker(slog.Default(), server, time.Second, 10)
+
+	if _, err := worker.RunOnce(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if repo.expiredCalls != 1 {
+		t.Fatalf("expected listing to be marked expired")
+	}
+	if club.releaseCalls != 1 || club.releaseLastLockID != "lock-1" {
+		t.Fatalf("expected ReleaseCardLock with lock-1")
+	}
+	if club.settleCalls != 0 {
+		t.Fatalf("did not expect SettleTrade")
+	}
+}
+
+func TestExpiryWorkerExpiresBelowReserve(t *testing.T) {
+	bestBid := int64(1500)
+	reserve := int64(2000)
+	bestBidder := "club-bidder"
+	repo := &fakeRepo{
+		expiredIDs: []string{"listing-1"},
+		listing: Listing{
+			ID:               "listing-1",
+			SellerClubID:     "club-seller",
+			Status:           listingStatusActive,
+			StartPrice:       1000,
+			ReservePrice:     &reserve,
+			ExpiresAtUnix:    time.Now().Add(-time.Minute).Unix(),
+			BestBid:          &bestBid,
+			BestBidderClubID: &bestBidder,
+			LockID:           "lock-1",
+		},
+		bidHoldIDs: []string{"hold-1", "hold-2"},
+	}
+	club := &fakeClub{}
+	server := NewServer(slog.Default(), repo, club, &fakeLock{token: "token", ok: true})
+	worker := NewExpiryWorker(slog.Default(), server, time.Second, 10)
+
+	if _, err := worker.RunOnce(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if club.settleCalls != 0 || repo.soldCalls != 0 {
+		t.Fatalf("did not expect a sale below reserve")
+	}
+	if repo.expiredCalls != 1 || club.releaseCalls != 1 {
+		t.Fatalf("expected listing expired and card lock released")
+	}
+	if club.releaseHoldCalls != 2 {
+		t.Fatalf("expected all bid holds to be released, got %d", club.releaseHoldCalls)
+	}
+}
+
+func TestExpiryWorkerSkipsLockedListing(t *testing.T) {
+	repo := &fakeRepo{expiredIDs: []string{"listing-1"}}
+	club := &fakeClub{}
+	server := NewServer(slog.Default(), repo, club, &fakeLock{ok: false})
+	worker := NewExpiryWorker(slog.Default(), server, time.Second, 10)
+
+	closed, err := worker.RunOnce(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if closed != 0 || repo.expiredCalls != 0 || repo.soldCalls != 0 {
+		t.Fatalf("expected listing to be left for another replica")
+	}
+}
+
+func TestExpiryWorkerKeepsListingActiveOnSettleError(t *testing.T) {
+	bestBid := int64(1500)
+	bestBidder := "club-bidder"
+	repo := &fakeRepo{
+		expiredIDs: []string{"listing-1"},
+		listing: Listing{
+			ID:               "listing-1",
+			SellerClubID:     "club-seller",
+			Status:           listingStatusActive,
+			ExpiresAtUnix:    time.Now().Add(-time.Minute).Unix(),
+			BestBid:          &bestBid,
+			BestBidderClubID: &bestBidder,
+		},
+	}
+	club := &fakeClub{settleErr: errors.New("club down")}
+	server := NewServer(slog.Default(), repo, club, &fakeLock{token: "token", ok: true})
+	worker := NewExpiryWorker(slog.Default(), server, time.Second, 10)
+
+	closed, err := worker.RunOnce(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if closed != 0 || repo.soldCalls != 0 {
+		t.Fatalf("expected listing to stay ACTIVE for retry")
+	}
+}
+
+// Caso: club-svc rifiuta il settlement in modo definitivo (es. il buyer possiede gia'
+// il giocatore): l'asta chiude EXPIRED, hold dei bidder e lock carta rilasciati.
+func TestExpiryWorkerExpiresListingOnSettleRejected(t *testing.T) {
+	bestBid := int64(1500)
+	bestBidder := "club-bidder"
+	repo := &fakeRepo{
+		expiredIDs: []string{"listing-1"},
+		listing: Listing{
+			ID:               "listing-1",
+			SellerClubID:     "club-seller",
+			UserCardID:       "card-1",
+			Status:           listingStatusActive,
+			ExpiresAtUnix:    time.Now().Add(-time.Minute).Unix(),
+			BestBid:          &bestBid,
+			BestBidderClubID: &bestBidder,
+			LockID:           "lock-1",
+		},
+		holdIDForBid: "hold-best",
+		bidHoldIDs:   []string{"hold-best"},
+	}
+	club := &fakeClub{settleErr: status.Error(codes.FailedPrecondition, "buyer already owns this player")}
+	server := NewServer(slog.Default(), repo, club, &fakeLock{token: "token", ok: true})
+	worker := NewExpiryWorker(slog.Default(), server, time.Second, 10)
+
+	closed, err := worker.RunOnce(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if closed != 1 || repo.expiredCalls != 1 || repo.soldCalls != 0 {
+		t.Fatalf("expected listing expired without sale, got closed=%d expired=%d sold=%d", closed, repo.expiredCalls, repo.soldCalls)
+	}
+	if club.releaseHoldCalls != 1 || club.releaseHoldID != "hold-best" {
+		t.Fatalf("expected winner hold to be released, got %d calls (%s)", club.releaseHoldCalls, club.releaseHoldID)
+	}
+	if club.releaseCalls != 1 || club.releaseLastLockID != "lock-1" {
+		t.Fatalf("expected card lock to be released, got %d calls (%s)", club.releaseCalls, club.releaseLastLockID)
+	}
+}
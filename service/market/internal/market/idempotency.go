@@ -0,0 +1,111 @@
+package market
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strings"
+
+	"UltimateTeamX/pkg/grpcx"
+	"UltimateTeamX/service/market/internal/idempotency"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// maxIdempotencyKeyLen limita la chiave scelta dal client (UUID o simili).
+const maxIdempotencyKeyLen = 128
+
+// WithIdempotency abilita le idempotency key sulle RPC mutative (CreateListing, PlaceBid, BuyNow, RelistListing).
+func WithIdempotency(store idempotency.Store) Option {
+	return func(s *Server) {
+		s.idempotency = store
+	}
+}
+
+// idempotent esegue fn una sola volta per (metodo, utente, chiave) e ripete la risposta salvata sui retry.
+// Senza store o senza chiave nei metadata la richiesta viene eseguita normalmente.
+// Le risposte di errore non vengono salvate: il client puo' ripetere con la stessa chiave.
+func idempotent[T proto.Message](ctx context.Context, s *Server, method, userID string, req proto.Message, replay T, fn func() (T, error)) (T, error) {
+	var zero T
+	key := idempotencyKeyFromContext(ctx)
+	if s.idempotency == nil || key == "" {
+		return fn()
+	}
+	if len(key) > maxIdempotencyKeyLen {
+		return zero, status.Error(codes.InvalidArgument, "idempotency key too long")
+	}
+
+	fingerprint, err := requestFingerprint(method, req)
+	if err != nil {
+		s.logger.Error("errore fingerprint richiesta", "error", err, "method", method)
+		return zero, status.Error(codes.Internal, "failed to check idempotency key")
+	}
+	storeKey := "idem:" + method + ":" + userID + ":" + key
+
+	// 1) Prenota la chiave o recupera la risposta gia' salvata.
+	result, err := s.idempotency.Begin(ctx, storeKey, fingerprint)
+	switch {
+	case errors.Is(err, idempotency.ErrMismatch):
+		return zero, status.Error(codes.FailedPrecondition, "idempotency key reused with different payload")
+	case errors.Is(err, idempotency.ErrInProgress):
+		return zero, status.Error(codes.Aborted, "request with same idempotency key in progress")
+	case err != nil:
+		s.logger.Error("errore lettura idempotency key", "error", err, "method", method)
+		return zero, status.Error(codes.Internal, "failed to check idempotency key")
+	}
+	if result.Replay {
+		if err := proto.Unmarshal(result.Response, replay); err != nil {
+			s.logger.Error("errore decodifica risposta salvata", "error", err, "method", method)
+			return zero, status.Error(codes.Internal, "failed to replay idempotent response")
+		}
+		s.logger.Info("risposta idempotente ripetuta", "method", method, "user_id", userID)
+		return replay, nil
+	}
+
+	// 2) Esegue la richiesta; su errore libera la chiave. Un retry dopo effetti gia'
+	// avvenuti non regola due volte: club-svc rifiuta lo stesso trade_id con un altro hold.
+	resp, err := fn()
+	if err != nil {
+		if abortErr := s.idempotency.Abort(ctx, storeKey); abortErr != nil {
+			s.logger.Warn("errore rilascio idempotency key", "error", abortErr, "method", method)
+		}
+		return zero, err
+	}
+
+	// 3) Salva la risposta. L'effetto e' gia' avvenuto: un errore qui viene solo loggato.
+	payload, err := proto.Marshal(resp)
+	if err == nil {
+		err = s.idempotency.Complete(ctx, storeKey, fingerprint, payload)
+	}
+	if err != nil {
+		s.logger.Warn("errore salvataggio risposta idempotente", "error", err, "method", method)
+	}
+	return resp, nil
+}
+
+// idempotencyKeyFromContext legge la chiave dai metadata gRPC in ingresso.
+func idempotencyKeyFromContext(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	values := md.Get(grpcx.IdempotencyKeyMetadataKey)
+	if len(values) == 0 {
+		return ""
+	}
+	return strings.TrimSpace(values[0])
+}
+
+// requestFingerprint calcola l'hash del payload (serializzazione deterministica) per riconoscere
+// chiavi riusate con richieste diverse.
+func requestFingerprint(method string, req proto.Message) (string, error) {
+	payload, err := proto.MarshalOptions{Deterministic: true}.Marshal(req)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(append([]byte(method+":"), payload...))
+	return hex.EncodeToString(sum[:]), nil
+}
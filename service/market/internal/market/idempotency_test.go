@@ -0,0 +1,128 @@
+package market
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"UltimateTeamX/pkg/grpcx"
+	clubv1 "UltimateTeamX/proto/club/v1"
+	marketv1 "UltimateTeamX/proto/market/v1"
+	"UltimateTeamX/service/market/internal/idempotency"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Test suite per le idempotency key sulle RPC mutative.
+
+// fakeIdempotencyStore replica in memoria la semantica dello store Redis.
+type fakeIdempotencyStore struct {
+	fingerprints map[string]string
+	responses    map[string][]byte
+}
+
+func newFakeIdempotencyStore() *fakeIdempotencyStore {
+	return &fakeIdempotencyStore{fingerprints: map[string]string{}, responses: map[string][]byte{}}
+}
+
+func (s *fakeIdempotencyStore) Begin(_ context.Context, key, fingerprint string) (idempotency.Result, error) {
+	saved, ok := s.fingerprints[key]
+	if !ok {
+		s.fingerprints[key] = fingerprint
+		return idempotency.Result{}, nil
+	}
+	if saved != fingerprint {
+		return idempotency.Result{}, idempotency.ErrMismatch
+	}
+	response, done := s.responses[key]
+	if !done {
+		return idempotency.Result{}, idempotency.ErrInProgress
+	}
+	return idempotency.Result{Replay: true, Response: response}, nil
+}
+
+func (s *fakeIdempotencyStore) Complete(_ context.Context, key, _ string, response []byte) error {
+	s.responses[key] = response
+	return nil
+}
+
+func (s *fakeIdempotencyStore) Abort(_ context.Context, key string) error {
+	delete(s.fingerprints, key)
+	return nil
+}
+
+func withIdempotencyKey(key string) context.Context {
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs(grpcx.IdempotencyKeyMetadataKey, key))
+}
+
+func TestCreateListingReplaysIdempotentResponse(t *testing.T) {
+	repo := &fakeRepo{}
+	server := NewServer(slog.Default(), repo, &fakeClub{}, nil, WithIdempotency(newFakeIdempotencyStore()))
+	req := &marketv1.CreateListingRequest{
+		SellerUserId:  "11111111-1111-1111-1111-111111111111",
+		UserCardId:    "22222222-2222-2222-2222-222222222222",
+		StartPrice:    1000,
+		ExpiresAtUnix: time.Now().Add(time.Hour).Unix(),
+	}
+
+	first, err := server.CreateListing(withIdempotencyKey("key-1"), req)
+	if err != nil {
+		t.Fatalf("expected success, got error: %v", err)
+	}
+	second, err := server.CreateListing(withIdempotencyKey("key-1"), req)
+	if err != nil {
+		t.Fatalf("expected replay, got error: %v", err)
+	}
+	if second.ListingId != first.ListingId {
+		t.Fatalf("expected same listing_id, got %s and %s", first.ListingId, second.ListingId)
+	}
+	if repo.createCalls != 1 {
+		t.Fatalf("expected listing to be created once, got %d", repo.createCalls)
+	}
+}
+
+func TestPlaceBidIdempotencyKeyReusedWithDifferentPayload(t *testing.T) {
+	repo := &fakeRepo{
+		listing: Listing{
+			ID:            "listing-1",
+			Status:        listingStatusActive,
+			StartPrice:    1000,
+			ExpiresAtUnix: time.Now().Add(time.Hour).Unix(),
+		},
+	}
+	club := &fakeClub{getMyClubResp: &clubv1.GetMyClubResponse{ClubId: "club-bidder"}}
+	server := NewServer(slog.Default(), repo, club, &fakeLock{token: "token", ok: true}, WithIdempotency(newFakeIdempotencyStore()))
+	req := &marketv1.PlaceBidRequest{
+		ListingId:    "11111111-1111-1111-1111-111111111111",
+		BidderUserId: "22222222-2222-2222-2222-222222222222",
+		BidAmount:    1500,
+	}
+
+	if _, err := server.PlaceBid(withIdempotencyKey("key-1"), req); err != nil {
+		t.Fatalf("expected success, got error: %v", err)
+	}
+	req.BidAmount = 1600
+	_, err := server.PlaceBid(withIdempotencyKey("key-1"), req)
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("expected FailedPrecondition, got %v", err)
+	}
+}
+
+func TestPlaceBidIdempotencyKeyReleasedOnError(t *testing.T) {
+	repo := &fakeRepo{}
+	server := NewServer(slog.Default(), repo, &fakeClub{}, &fakeLock{ok: false}, WithIdempotency(newFakeIdempotencyStore()))
+	req := &marketv1.PlaceBidRequest{
+		ListingId:    "11111111-1111-1111-1111-111111111111",
+		BidderUserId: "22222222-2222-2222-2222-222222222222",
+		BidAmount:    1500,
+	}
+
+	for attempt := 0; attempt < 2; attempt++ {
+		_, err := server.PlaceBid(withIdempotencyKey("key-1"), req)
+		if status.Code(err) != codes.FailedPrecondition {
+			t.Fatalf("attempt %d: expected lock error to be returned again, got %v", attempt, err)
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package market
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	commonv1 "UltimateTeamX/proto/common/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Codice dell'errore strutturato per i bid sotto il minimo.
+const errorCodeBidTooLow = "BID_TOO_LOW"
+
+// IncrementTier applica Increment ai best_bid sotto UpTo (0 = nessun limite superiore).
+type IncrementTier struct {
+	UpTo      int64
+	Increment int64
+}
+
+// BidIncrements e' la tabella dei rilanci minimi, ordinata per UpTo crescente.
+// Vuota equivale a un rilancio minimo di 1 credito.
+type BidIncrements []IncrementTier
+
+// WithBidIncrements imposta la tabella dei rilanci minimi applicata da PlaceBid.
+func WithBidIncrements(increments BidIncrements) Option {
+	return func(s *Server) {
+		s.bidIncrements = increments
+	}
+}
+
+// ParseBidIncrements legge la tabella nel formato "1000:50,10000:100,*:250":
+// sotto 1000 si rilancia di almeno 50, sotto 10000 di 100, oltre di 250.
+func ParseBidIncrements(value string) (BidIncrements, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil, nil
+	}
+
+	var increments BidIncrements
+	for _, entry := range strings.Split(value, ",") {
+		upTo, increment, ok := strings.Cut(strings.TrimSpace(entry), ":")
+		if !ok {
+			return nil, fmt.Errorf("tier %q: formato atteso soglia:rilancio", entry)
+		}
+		tier := IncrementTier{}
+		var err error
+		if tier.Increment, err = strconv.ParseInt(strings.TrimSpace(increment), 10, 64); err != nil || tier.Increment <= 0 {
+			return nil, fmt.Errorf("tier %q: rilancio non valido", entry)
+		}
+		if upTo = strings.TrimSpace(upTo); upTo != "*" {
+			if tier.UpTo, err = strconv.ParseInt(upTo, 10, 64); err != nil || tier.UpTo <= 0 {
+				return nil, fmt.Errorf("tier %q: soglia non valida", entry)
+			}
+		}
+
+		if n := len(increments); n > 0 {
+			last := increments[n-1]
+			if last.UpTo == 0 {
+				return nil, fmt.Errorf("tier %q: nessun tier ammesso dopo '*'", entry)
+			}
+			if tier.UpTo != 0 && tier.UpTo <= last.UpTo {
+				return nil, fmt.Errorf("tier %q: soglie non crescenti", entry)
+			}
+		}
+		increments = append(increments, tier)
+	}
+	return increments, nil
+}
+
+// MinIncrement ritorna il rilancio minimo sopra il best_bid indicato.
+// Oltre l'ultima soglia vale il rilancio dell'ultimo tier.
+func (b BidIncrements) MinIncrement(bestBid int64) int64 {
+	if len(b) == 0 {
+		return 1
+	}
+	for _, tier := range b {
+		if tier.UpTo == 0 || bestBid < tier.UpTo {
+			return tier.Increment
+		}
+	}
+	return b[len(b)-1].Increment
+}
+
+// nextValidBid ritorna l'offerta minima accettata sul listing.
+func (s *Server) nextValidBid(listing Listing) int64 {
+	if listing.BestBid == nil {
+		return listing.StartPrice
+	}
+	return *listing.BestBid + s.bidIncrements.MinIncrement(*listing.BestBid)
+}
+
+// bidTooLowError costruisce il FailedPrecondition con dettaglio common.v1.Error
+// contenente next_valid_bid, cosi' il client puo' proporre l'importo corretto.
+func bidTooLowError(message string, nextValidBid int64) error {
+	st := status.New(codes.FailedPrecondition, message)
+	detailed, err := st.WithDetails(&commonv1.Error{
+		Code:     errorCodeBidTooLow,
+		Message:  message,
+		Metadata: map[string]string{"next_valid_bid": strconv.FormatInt(nextValidBid, 10)},
+	})
+	if err != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
@@ -0,0 +1,90 @@
+package market
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	commonv1 "UltimateTeamX/proto/common/v1"
+	marketv1 "UltimateTeamX/proto/market/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Test suite per la tabella dei rilanci minimi.
+
+func TestParseBidIncrements(t *testing.T) {
+	increments, err := ParseBidIncrements("1000:50, 10000:100, *:250")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	cases := map[int64]int64{0: 50, 999: 50, 1000: 100, 9999: 100, 10000: 250, 1000000: 250}
+	for bestBid, want := range cases {
+		if got := increments.MinIncrement(bestBid); got != want {
+			t.Fatalf("best_bid %d: expected increment %d, got %d", bestBid, want, got)
+		}
+	}
+
+	if got := BidIncrements(nil).MinIncrement(5000); got != 1 {
+		t.Fatalf("expected default increment 1, got %d", got)
+	}
+	bounded, _ := ParseBidIncrements("1000:50")
+	if got := bounded.MinIncrement(5000); got != 50 {
+		t.Fatalf("expected last tier above the highest threshold, got %d", got)
+	}
+}
+
+func TestParseBidIncrementsInvalid(t *testing.T) {
+	for _, value := range []string{"1000", "1000:0", "abc:50", "10000:100,1000:50", "*:50,1000:100"} {
+		if _, err := ParseBidIncrements(value); err == nil {
+			t.Fatalf("expected error for %q", value)
+		}
+	}
+}
+
+func TestPlaceBidBelowMinimumIncrement(t *testing.T) {
+	bestBid := int64(1200)
+	bestBidder := "club-other"
+	repo := &fakeRepo{
+		listing: Listing{
+			ID:               "listing-1",
+			Status:           listingStatusActive,
+			StartPrice:       1000,
+			ExpiresAtUnix:    time.Now().Add(time.Hour).Unix(),
+			BestBid:          &bestBid,
+			BestBidderClubID: &bestBidder,
+		},
+	}
+	increments, _ := ParseBidIncrements("1000:50,10000:100,*:250")
+	club := &fakeClub{}
+	server := NewServer(slog.Default(), repo, club, &fakeLock{token: "token", ok: true}, WithBidIncrements(increments))
+
+	_, err := server.PlaceBid(context.Background(), &marketv1.PlaceBidRequest{
+		ListingId:    "11111111-1111-1111-1111-111111111111",
+		BidderUserId: "22222222-2222-2222-2222-222222222222",
+		BidAmount:    1250,
+	})
+	st, _ := status.FromError(err)
+	if st.Code() != codes.FailedPrecondition {
+		t.Fatalf("expected FailedPrecondition, got %v", err)
+	}
+	if len(st.Details()) != 1 {
+		t.Fatalf("expected one error detail, got %d", len(st.Details()))
+	}
+	detail, ok := st.Details()[0].(*commonv1.Error)
+	if !ok || detail.Code != errorCodeBidTooLow || detail.Metadata["next_valid_bid"] != "1300" {
+		t.Fatalf("unexpected error detail: %+v", st.Details()[0])
+	}
+	if repo.lastInsert.amount != 0 {
+		t.Fatalf("did not expect bid to be inserted")
+	}
+
+	if _, err := server.PlaceBid(context.Background(), &marketv1.PlaceBidRequest{
+		ListingId:    "11111111-1111-1111-1111-111111111111",
+		BidderUserId: "22222222-2222-2222-2222-222222222222",
+		BidAmount:    1300,
+	}); err != nil {
+		t.Fatalf("expected next valid bid to be accepted, got %v", err)
+	}
+}
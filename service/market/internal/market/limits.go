@@ -0,0 +1,107 @@
+package market
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	commonv1 "UltimateTeamX/proto/common/v1"
+	"UltimateTeamX/service/market/internal/ratelimit"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Codice dell'errore strutturato per i bid oltre il rate limit.
+const errorCodeRateLimited = "RATE_LIMITED"
+
+// Limits sono i limiti anti-abuso per club; 0 = nessun limite.
+type Limits struct {
+	// MaxActiveListings e' il numero massimo di listing ACTIVE per seller.
+	MaxActiveListings int
+	// MaxWinningBids e' il numero massimo di listing ACTIVE in cui il bidder e' in testa.
+	MaxWinningBids int
+}
+
+// WithLimits imposta i limiti per club applicati da CreateListing e PlaceBid.
+func WithLimits(limits Limits) Option {
+	return func(s *Server) {
+		s.limits = limits
+	}
+}
+
+// WithBidRateLimiter limita la frequenza dei PlaceBid per utente.
+func WithBidRateLimiter(limiter ratelimit.Limiter) Option {
+	return func(s *Server) {
+		s.bidLimiter = limiter
+	}
+}
+
+// checkActiveListingsLimit rifiuta un nuovo listing se il seller ha gia' troppi listing ACTIVE.
+// Il controllo non e' sotto lock: listing creati in parallelo possono superarlo di poco.
+func (s *Server) checkActiveListingsLimit(ctx context.Context, sellerClubID string) error {
+	if s.limits.MaxActiveListings <= 0 {
+		return nil
+	}
+	count, err := s.repo.CountActiveListingsBySeller(ctx, sellerClubID)
+	if err != nil {
+		s.logger.Error("errore conteggio listing attivi", "error", err, "seller_club_id", sellerClubID)
+		return status.Error(codes.Internal, "failed to check listing limit")
+	}
+	if count >= s.limits.MaxActiveListings {
+		return status.Error(codes.ResourceExhausted, "too many active listings")
+	}
+	return nil
+}
+
+// checkWinningBidsLimit rifiuta un bid che porterebbe il bidder in testa su troppi listing.
+// Rilanciare su un listing in cui si e' gia' in testa non conta come nuova posizione.
+func (s *Server) checkWinningBidsLimit(ctx context.Context, listing Listing, bidderClubID string) error {
+	if s.limits.MaxWinningBids <= 0 {
+		return nil
+	}
+	if listing.BestBidderClubID != nil && *listing.BestBidderClubID == bidderClubID {
+		return nil
+	}
+	count, err := s.repo.CountWinningListingsByBidder(ctx, bidderClubID)
+	if err != nil {
+		s.logger.Error("errore conteggio bid vincenti", "error", err, "bidder_club_id", bidderClubID)
+		return status.Error(codes.Internal, "failed to check bid limit")
+	}
+	if count >= s.limits.MaxWinningBids {
+		return status.Error(codes.ResourceExhausted, "too many winning bids")
+	}
+	return nil
+}
+
+// checkBidRate applica il rate limit Redis per utente.
+// Se Redis non risponde il bid passa: il limite e' anti-abuso, non una regola di consistenza.
+func (s *Server) checkBidRate(ctx context.Context, userID string) error {
+	if s.bidLimiter == nil {
+		return nil
+	}
+	allowed, retryAfter, err := s.bidLimiter.Allow(ctx, "ratelimit:bid:"+userID)
+	if err != nil {
+		s.logger.Warn("rate limit bid non disponibile", "error", err, "user_id", userID)
+		return nil
+	}
+	if !allowed {
+		return rateLimitedError("bid rate limit exceeded", retryAfter)
+	}
+	return nil
+}
+
+// rateLimitedError costruisce il ResourceExhausted con dettaglio common.v1.Error
+// contenente retry_after_seconds.
+func rateLimitedError(message string, retryAfter time.Duration) error {
+	seconds := max(int64(retryAfter.Round(time.Second)/time.Second), 1)
+	st := status.New(codes.ResourceExhausted, message)
+	detailed, err := st.WithDetails(&commonv1.Error{
+		Code:     errorCodeRateLimited,
+		Message:  message,
+		Metadata: map[string]string{"retry_after_seconds": strconv.FormatInt(seconds, 10)},
+	})
+	if err != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
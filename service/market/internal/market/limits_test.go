@@ -0,0 +1,124 @@
+package market
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	commonv1 "UltimateTeamX/proto/common/v1"
+	marketv1 "UltimateTeamX/proto/market/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Test suite per i limiti anti-abuso.
+
+// fakeLimiter simula il rate limit Redis.
+type fakeLimiter struct {
+	allowed bool
+	keys    []string
+}
+
+func (l *fakeLimiter) Allow(_ context.Context, key string) (bool, time.Duration, error) {
+	l.keys = append(l.keys, key)
+	return l.allowed, 20 * time.Second, nil
+}
+
+func limitsListing(sellerClubID string) Listing {
+	return Listing{
+		ID:            "listing-1",
+		SellerClubID:  sellerClubID,
+		Status:        listingStatusActive,
+		StartPrice:    1000,
+		ExpiresAtUnix: time.Now().Add(time.Hour).Unix(),
+	}
+}
+
+func placeTestBid(server *Server) error {
+	_, err := server.PlaceBid(context.Background(), &marketv1.PlaceBidRequest{
+		ListingId:    "11111111-1111-1111-1111-111111111111",
+		BidderUserId: "22222222-2222-2222-2222-222222222222",
+		BidAmount:    1000,
+	})
+	return err
+}
+
+func TestCreateListingActiveListingsLimit(t *testing.T) {
+	repo := &fakeRepo{activeListings: 5}
+	club := &fakeClub{}
+	server := NewServer(slog.Default(), repo, club, nil, WithLimits(Limits{MaxActiveListings: 5}))
+
+	_, err := server.CreateListing(context.Background(), &marketv1.CreateListingRequest{
+		SellerUserId:  "11111111-1111-1111-1111-111111111111",
+		UserCardId:    "22222222-2222-2222-2222-222222222222",
+		StartPrice:    1000,
+		ExpiresAtUnix: time.Now().Add(time.Hour).Unix(),
+	})
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("expected ResourceExhausted, got %v", err)
+	}
+	if repo.createCalls != 0 {
+		t.Fatalf("did not expect listing to be created")
+	}
+}
+
+func TestPlaceBidRejectsOwnListing(t *testing.T) {
+	repo := &fakeRepo{listing: limitsListing("club-1")}
+	club := &fakeClub{}
+	server := NewServer(slog.Default(), repo, club, &fakeLock{token: "token", ok: true})
+
+	if err := placeTestBid(server); status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("expected FailedPrecondition, got %v", err)
+	}
+	if repo.lastInsert.amount != 0 {
+		t.Fatalf("did not expect bid to be inserted")
+	}
+}
+
+func TestPlaceBidWinningBidsLimit(t *testing.T) {
+	repo := &fakeRepo{listing: limitsListing("club-seller"), winningListings: 3}
+	server := NewServer(slog.Default(), repo, &fakeClub{}, &fakeLock{token: "token", ok: true}, WithLimits(Limits{MaxWinningBids: 3}))
+
+	if err := placeTestBid(server); status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("expected ResourceExhausted, got %v", err)
+	}
+
+	// Gia' in testa sul listing: il rilancio non apre una nuova posizione.
+	bestBid := int64(1000)
+	bestBidder := "club-1"
+	repo.listing.BestBid = &bestBid
+	repo.listing.BestBidderClubID = &bestBidder
+	_, err := server.PlaceBid(context.Background(), &marketv1.PlaceBidRequest{
+		ListingId:    "11111111-1111-1111-1111-111111111111",
+		BidderUserId: "22222222-2222-2222-2222-222222222222",
+		BidAmount:    1100,
+	})
+	if err != nil {
+		t.Fatalf("expected raise on own winning listing to pass, got %v", err)
+	}
+}
+
+func TestPlaceBidRateLimited(t *testing.T) {
+	repo := &fakeRepo{listing: limitsListing("club-seller")}
+	limiter := &fakeLimiter{}
+	server := NewServer(slog.Default(), repo, &fakeClub{}, &fakeLock{token: "token", ok: true}, WithBidRateLimiter(limiter))
+
+	err := placeTestBid(server)
+	st, _ := status.FromError(err)
+	if st.Code() != codes.ResourceExhausted {
+		t.Fatalf("expected ResourceExhausted, got %v", err)
+	}
+	detail, ok := st.Details()[0].(*commonv1.Error)
+	if !ok || detail.Code != errorCodeRateLimited || detail.Metadata["retry_after_seconds"] != "20" {
+		t.Fatalf("unexpected error detail: %+v", st.Details())
+	}
+	if len(limiter.keys) != 1 || limiter.keys[0] != "ratelimit:bid:22222222-2222-2222-2222-222222222222" {
+		t.Fatalf("unexpected limiter keys: %v", limiter.keys)
+	}
+
+	limiter.allowed = true
+	if err := placeTestBid(server); err != nil {
+		t.Fatalf("expected bid within rate limit to pass, got %v", err)
+	}
+}
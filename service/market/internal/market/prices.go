@@ -0,0 +1,114 @@
+package market
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	marketv1 "UltimateTeamX/proto/market/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Limiti dello storico prezzi.
+const (
+	defaultPriceWindow = 7 * 24 * time.Hour
+	maxPriceWindow     = 90 * 24 * time.Hour
+	defaultPriceBucket = 24 * time.Hour
+	minPriceBucket     = time.Minute
+	maxPriceBuckets    = 1000
+)
+
+// GetPriceHistory ritorna i trade completati del giocatore aggregati in bucket
+// (apertura, chiusura, minimo, mediana, massimo e volume).
+func (s *Server) GetPriceHistory(ctx context.Context, req *marketv1.GetPriceHistoryRequest) (*marketv1.GetPriceHistoryResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "request is required")
+	}
+	if strings.TrimSpace(req.PlayerId) == "" {
+		return nil, status.Error(codes.InvalidArgument, "player_id is required")
+	}
+	if !isUUID(req.PlayerId) {
+		return nil, status.Error(codes.InvalidArgument, "player_id must be a valid UUID")
+	}
+	if req.WindowSeconds < 0 || req.BucketSeconds < 0 {
+		return nil, status.Error(codes.InvalidArgument, "window_seconds and bucket_seconds must not be negative")
+	}
+
+	window, bucket := defaultPriceWindow, defaultPriceBucket
+	if req.WindowSeconds > 0 {
+		window = time.Duration(req.WindowSeconds) * time.Second
+	}
+	if req.BucketSeconds > 0 {
+		bucket = time.Duration(req.BucketSeconds) * time.Second
+	}
+	if window > maxPriceWindow {
+		return nil, status.Error(codes.InvalidArgument, "window_seconds exceeds 90 days")
+	}
+	if bucket < minPriceBucket {
+		return nil, status.Error(codes.InvalidArgument, "bucket_seconds must be >= 60")
+	}
+	if window/bucket > maxPriceBuckets {
+		return nil, status.Error(codes.InvalidArgument, "too many buckets for window")
+	}
+
+	buckets, err := s.repo.PriceHistory(ctx, req.PlayerId, time.Now().Add(-window), bucket)
+	if err != nil {
+		s.logger.Error("errore lettura storico prezzi", "error", err, "player_id", req.PlayerId)
+		return nil, status.Error(codes.Internal, "failed to load price history")
+	}
+
+	resp := &marketv1.GetPriceHistoryResponse{Buckets: make([]*marketv1.PriceBucket, 0, len(buckets))}
+	for _, b := range buckets {
+		resp.Buckets = append(resp.Buckets, &marketv1.PriceBucket{
+			StartUnix:   b.StartUnix,
+			OpenPrice:   b.Open,
+			ClosePrice:  b.Close,
+			MinPrice:    b.Min,
+			MedianPrice: b.Median,
+			MaxPrice:    b.Max,
+			Volume:      b.Volume,
+		})
+	}
+	return resp, nil
+}
+
+// GetLowestBin ritorna il listing ACTIVE del giocatore con il buy_now_price piu' basso.
+// Riusa la ricerca: filtro su buy_now_price presente, ordinato per prezzo crescente.
+func (s *Server) GetLowestBin(ctx context.Context, req *marketv1.GetLowestBinRequest) (*marketv1.GetLowestBinResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "request is required")
+	}
+	if strings.TrimSpace(req.PlayerId) == "" {
+		return nil, status.Error(codes.InvalidArgument, "player_id is required")
+	}
+	if !isUUID(req.PlayerId) {
+		return nil, status.Error(codes.InvalidArgument, "player_id must be a valid UUID")
+	}
+
+	listings, total, err := s.repo.SearchListings(ctx, ListingFilter{
+		Status:     listingStatusActive,
+		PlayerID:   req.PlayerId,
+		PriceField: priceFieldBuyNow,
+		MinPrice:   1,
+		SortField:  sortFieldPrice,
+		Limit:      1,
+		Now:        time.Now(),
+	})
+	if err != nil {
+		s.logger.Error("errore lettura lowest bin", "error", err, "player_id", req.PlayerId)
+		return nil, status.Error(codes.Internal, "failed to load lowest bin")
+	}
+	if len(listings) == 0 || listings[0].BuyNowPrice == nil {
+		return &marketv1.GetLowestBinResponse{}, nil
+	}
+
+	lowest := listings[0]
+	return &marketv1.GetLowestBinResponse{
+		Found:         true,
+		ListingId:     lowest.ID,
+		BuyNowPrice:   *lowest.BuyNowPrice,
+		ExpiresAtUnix: lowest.ExpiresAtUnix,
+		ActiveCount:   total,
+	}, nil
+}
@@ -0,0 +1,92 @@
+package market
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	marketv1 "UltimateTeamX/proto/market/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Test suite per storico prezzi e lowest BIN.
+
+const testPlayerID = "55555555-5555-5555-5555-555555555555"
+
+func TestGetPriceHistoryDefaultsAndMapping(t *testing.T) {
+	repo := &fakeRepo{priceBuckets: []PriceBucket{
+		{StartUnix: 1700000000, Open: 1000, Close: 1200, Min: 900, Median: 1100, Max: 1300, Volume: 4},
+	}}
+	server := NewServer(slog.Default(), repo, &fakeClub{}, nil)
+
+	resp, err := server.GetPriceHistory(context.Background(), &marketv1.GetPriceHistoryRequest{PlayerId: testPlayerID})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if repo.lastPriceQuery.playerID != testPlayerID || repo.lastPriceQuery.bucket != defaultPriceBucket {
+		t.Fatalf("unexpected query: %+v", repo.lastPriceQuery)
+	}
+	if since := time.Since(repo.lastPriceQuery.since); since < defaultPriceWindow || since > defaultPriceWindow+time.Minute {
+		t.Fatalf("expected default window, got since %v ago", since)
+	}
+	if len(resp.Buckets) != 1 {
+		t.Fatalf("expected 1 bucket, got %d", len(resp.Buckets))
+	}
+	b := resp.Buckets[0]
+	if b.OpenPrice != 1000 || b.ClosePrice != 1200 || b.MinPrice != 900 || b.MedianPrice != 1100 || b.MaxPrice != 1300 || b.Volume != 4 {
+		t.Fatalf("unexpected bucket: %+v", b)
+	}
+}
+
+func TestGetPriceHistoryValidation(t *testing.T) {
+	server := NewServer(slog.Default(), &fakeRepo{}, &fakeClub{}, nil)
+
+	cases := []*marketv1.GetPriceHistoryRequest{
+		{PlayerId: "not-a-uuid"},
+		{PlayerId: testPlayerID, BucketSeconds: 30},
+		{PlayerId: testPlayerID, WindowSeconds: int64((91 * 24 * time.Hour).Seconds())},
+		{PlayerId: testPlayerID, WindowSeconds: int64((30 * 24 * time.Hour).Seconds()), BucketSeconds: 60},
+	}
+	for _, req := range cases {
+		if _, err := server.GetPriceHistory(context.Background(), req); status.Code(err) != codes.InvalidArgument {
+			t.Fatalf("expected InvalidArgument for %+v, got %v", req, err)
+		}
+	}
+}
+
+func TestGetLowestBin(t *testing.T) {
+	buyNow := int64(1800)
+	expiresAt := time.Now().Add(time.Hour).Unix()
+	repo := &fakeRepo{
+		searchResult: []Listing{{ID: "listing-1", BuyNowPrice: &buyNow, ExpiresAtUnix: expiresAt}},
+		searchTotal:  3,
+	}
+	server := NewServer(slog.Default(), repo, &fakeClub{}, nil)
+
+	resp, err := server.GetLowestBin(context.Background(), &marketv1.GetLowestBinRequest{PlayerId: testPlayerID})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !resp.Found || resp.ListingId != "listing-1" || resp.BuyNowPrice != buyNow || resp.ExpiresAtUnix != expiresAt || resp.ActiveCount != 3 {
+		t.Fatalf("unexpected response: %+v", resp)
+	}
+	filter := repo.lastFilter
+	if filter.Status != listingStatusActive || filter.PlayerID != testPlayerID || filter.PriceField != priceFieldBuyNow ||
+		filter.MinPrice != 1 || filter.SortField != sortFieldPrice || filter.SortDesc || filter.Limit != 1 {
+		t.Fatalf("unexpected filter: %+v", filter)
+	}
+}
+
+func TestGetLowestBinNotFound(t *testing.T) {
+	server := NewServer(slog.Default(), &fakeRepo{}, &fakeClub{}, nil)
+
+	resp, err := server.GetLowestBin(context.Background(), &marketv1.GetLowestBinRequest{PlayerId: testPlayerID})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.Found {
+		t.Fatalf("expected found=false, got %+v", resp)
+	}
+}
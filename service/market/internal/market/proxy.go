@@ -0,0 +1,123 @@
+package market
+
+import (
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// bidOutcome e' l'esito di un bid dopo le regole di proxy bidding.
+type bidOutcome struct {
+	// rows sono i bid da registrare in ordine (inclusi i rilanci automatici).
+	rows             []BidRow
+	bestBid          int64
+	bestBidderClubID string
+	bestMaxBid       *int64
+	// requesterWins indica se chi ha fatto il bid e' il nuovo best bidder.
+	requesterWins bool
+	// previousLeaderOutbid indica che il best bidder precedente e' stato superato.
+	previousLeaderOutbid bool
+}
+
+// resolveBid applica le regole di proxy bidding (stile eBay) sotto il lock del listing:
+//   - il tetto di chi rilancia e' max_bid (proxy) oppure l'importo del bid;
+//   - se supera il tetto del best bidder, vince al tetto avversario + rilancio minimo
+//     (mai oltre il proprio tetto); un bid normale vince al proprio importo;
+//   - altrimenti il best bidder resta in testa e rilancia in automatico fino a
+//     bid sfidante + rilancio minimo (mai oltre il proprio tetto);
+//   - a parita' di tetto vince chi e' arrivato prima (il best bidder corrente);
+//   - il best bidder che rilancia su se stesso alza solo il tetto (proxy) o il prezzo.
+//
+// holdID e' l'hold del richiedente (dimensionato sul tetto), leaderHoldID quello del best bidder.
+func (s *Server) resolveBid(listing Listing, bidderClubID, holdID, leaderHoldID string, amount, maxBid int64) (bidOutcome, error) {
+	proxy := maxBid > 0
+	ceiling := amount
+	var ceilingPtr *int64
+	if proxy {
+		ceiling = maxBid
+		ceilingPtr = &maxBid
+	}
+	nextValid := s.nextValidBid(listing)
+	if ceiling < nextValid {
+		if listing.BestBid == nil {
+			return bidOutcome{}, bidTooLowError("bid must be >= start_price", nextValid)
+		}
+		return bidOutcome{}, bidTooLowError("bid below minimum increment over best_bid", nextValid)
+	}
+	// Un bid proxy parte dal minimo valido; un bid normale dal proprio importo.
+	openingPrice := amount
+	if proxy {
+		openingPrice = nextValid
+	}
+
+	// 1) Primo bid del listing.
+	if listing.BestBid == nil || listing.BestBidderClubID == nil {
+		return bidOutcome{
+			rows:             []BidRow{{BidderClubID: bidderClubID, HoldID: holdID, Amount: openingPrice, MaxBid: ceilingPtr}},
+			bestBid:          openingPrice,
+			bestBidderClubID: bidderClubID,
+			bestMaxBid:       ceilingPtr,
+			requesterWins:    true,
+		}, nil
+	}
+
+	price := *listing.BestBid
+	leader := *listing.BestBidderClubID
+	leaderCeiling := price
+	if listing.BestMaxBid != nil && *listing.BestMaxBid > price {
+		leaderCeiling = *listing.BestMaxBid
+	}
+
+	// 2) Il best bidder rilancia su se stesso: alza il tetto senza far salire il prezzo.
+	if leader == bidderClubID {
+		if ceiling <= leaderCeiling {
+			return bidOutcome{}, status.Error(codes.FailedPrecondition, "bid must exceed your current max_bid")
+		}
+		newPrice := amount
+		if proxy {
+			newPrice = price
+		}
+		return bidOutcome{
+			rows:             []BidRow{{BidderClubID: bidderClubID, HoldID: holdID, Amount: newPrice, MaxBid: ceilingPtr}},
+			bestBid:          newPrice,
+			bestBidderClubID: bidderClubID,
+			bestMaxBid:       ceilingPtr,
+			requesterWins:    true,
+		}, nil
+	}
+
+	// 3) Lo sfidante supera il tetto del best bidder: il best bidder rilancia fino al
+	// proprio tetto (registrato per audit), poi lo sfidante passa in testa.
+	if ceiling > leaderCeiling {
+		newPrice := amount
+		if proxy {
+			newPrice = max(openingPrice, min(ceiling, leaderCeiling+s.bidIncrements.MinIncrement(leaderCeiling)))
+		}
+		var rows []BidRow
+		if leaderCeiling > price {
+			rows = append(rows, BidRow{BidderClubID: leader, HoldID: leaderHoldID, Amount: leaderCeiling, Auto: true})
+		}
+		rows = append(rows, BidRow{BidderClubID: bidderClubID, HoldID: holdID, Amount: newPrice, MaxBid: ceilingPtr})
+		return bidOutcome{
+			rows:                 rows,
+			bestBid:              newPrice,
+			bestBidderClubID:     bidderClubID,
+			bestMaxBid:           ceilingPtr,
+			requesterWins:        true,
+			previousLeaderOutbid: true,
+		}, nil
+	}
+
+	// 4) Il best bidder resta in testa (anche a parita' di tetto) con un rilancio automatico.
+	// L'hold del richiedente resta sul bid perdente (prova del bid per il recovery) e viene
+	// rilasciato dallo step HOLD_RELEASE della saga PLACE_BID.
+	newPrice := min(leaderCeiling, ceiling+s.bidIncrements.MinIncrement(ceiling))
+	return bidOutcome{
+		rows: []BidRow{
+			{BidderClubID: bidderClubID, HoldID: holdID, Amount: ceiling, MaxBid: ceilingPtr},
+			{BidderClubID: leader, HoldID: leaderHoldID, Amount: newPrice, Auto: true},
+		},
+		bestBid:          newPrice,
+		bestBidderClubID: leader,
+		bestMaxBid:       listing.BestMaxBid,
+	}, nil
+}
@@ -0,0 +1,147 @@
+package market
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	marketv1 "UltimateTeamX/proto/market/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Test suite per il proxy bidding.
+
+func proxyListing(bestBid, bestMaxBid int64, bestBidder string) Listing {
+	listing := Listing{
+		ID:            "listing-1",
+		Status:        listingStatusActive,
+		StartPrice:    1000,
+		ExpiresAtUnix: time.Now().Add(time.Hour).Unix(),
+	}
+	if bestBidder != "" {
+		listing.BestBid = &bestBid
+		listing.BestBidderClubID = &bestBidder
+	}
+	if bestMaxBid > 0 {
+		listing.BestMaxBid = &bestMaxBid
+	}
+	return listing
+}
+
+func TestResolveBidFirstProxyBidOpensAtStartPrice(t *testing.T) {
+	server := NewServer(slog.Default(), &fakeRepo{}, &fakeClub{}, &fakeLock{})
+
+	outcome, err := server.resolveBid(proxyListing(0, 0, ""), "club-a", "hold-a", "", 0, 5000)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if outcome.bestBid != 1000 || outcome.bestBidderClubID != "club-a" || !outcome.requesterWins {
+		t.Fatalf("unexpected outcome: %+v", outcome)
+	}
+	if outcome.bestMaxBid == nil || *outcome.bestMaxBid != 5000 {
+		t.Fatalf("expected max_bid 5000 to be stored")
+	}
+	if len(outcome.rows) != 1 || outcome.rows[0].Amount != 1000 {
+		t.Fatalf("unexpected rows: %+v", outcome.rows)
+	}
+}
+
+func TestResolveBidChallengerBeatsProxyCeiling(t *testing.T) {
+	increments, _ := ParseBidIncrements("10000:100,*:250")
+	server := NewServer(slog.Default(), &fakeRepo{}, &fakeClub{}, &fakeLock{}, WithBidIncrements(increments))
+
+	outcome, err := server.resolveBid(proxyListing(1000, 3000, "club-a"), "club-b", "hold-b", "hold-a", 0, 8000)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if outcome.bestBid != 3100 || outcome.bestBidderClubID != "club-b" || !outcome.requesterWins || !outcome.previousLeaderOutbid {
+		t.Fatalf("unexpected outcome: %+v", outcome)
+	}
+	if len(outcome.rows) != 2 {
+		t.Fatalf("expected leader auto bid and challenger bid, got %+v", outcome.rows)
+	}
+	if auto := outcome.rows[0]; !auto.Auto || auto.BidderClubID != "club-a" || auto.Amount != 3000 || auto.HoldID != "hold-a" {
+		t.Fatalf("unexpected leader auto bid: %+v", auto)
+	}
+}
+
+func TestResolveBidLeaderKeepsLeadOnTie(t *testing.T) {
+	increments, _ := ParseBidIncrements("10000:100,*:250")
+	server := NewServer(slog.Default(), &fakeRepo{}, &fakeClub{}, &fakeLock{}, WithBidIncrements(increments))
+
+	outcome, err := server.resolveBid(proxyListing(1000, 3000, "club-a"), "club-b", "hold-b", "hold-a", 3000, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if outcome.bestBid != 3000 || outcome.bestBidderClubID != "club-a" || outcome.requesterWins {
+		t.Fatalf("expected earlier proxy bidder to win the tie, got %+v", outcome)
+	}
+	if outcome.bestMaxBid == nil || *outcome.bestMaxBid != 3000 {
+		t.Fatalf("expected leader max_bid to be preserved")
+	}
+	if len(outcome.rows) != 2 || outcome.rows[0].BidderClubID != "club-b" || !outcome.rows[1].Auto {
+		t.Fatalf("unexpected rows: %+v", outcome.rows)
+	}
+}
+
+func TestResolveBidLeaderRaisesOwnCeiling(t *testing.T) {
+	server := NewServer(slog.Default(), &fakeRepo{}, &fakeClub{}, &fakeLock{})
+
+	outcome, err := server.resolveBid(proxyListing(1500, 3000, "club-a"), "club-a", "hold-a2", "hold-a", 0, 6000)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if outcome.bestBid != 1500 || *outcome.bestMaxBid != 6000 || !outcome.requesterWins || outcome.previousLeaderOutbid {
+		t.Fatalf("expected price unchanged with higher ceiling, got %+v", outcome)
+	}
+
+	_, err = server.resolveBid(proxyListing(1500, 3000, "club-a"), "club-a", "hold-a2", "hold-a", 0, 2000)
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("expected FailedPrecondition for lower ceiling, got %v", err)
+	}
+}
+
+func TestPlaceBidProxyLeaderOutbidsChallenger(t *testing.T) {
+	repo := &fakeRepo{
+		listing:      proxyListing(1000, 3000, "club-leader"),
+		holdIDForBid: "hold-leader",
+	}
+	club := &fakeClub{
+		clubOwners: map[string]string{"club-leader": "44444444-4444-4444-4444-444444444444"},
+	}
+	server := NewServer(slog.Default(), repo, club, &fakeLock{token: "token", ok: true})
+
+	resp, err := server.PlaceBid(context.Background(), &marketv1.PlaceBidRequest{
+		ListingId:    "11111111-1111-1111-1111-111111111111",
+		BidderUserId: "22222222-2222-2222-2222-222222222222",
+		BidAmount:    2000,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.Winning || resp.BestBid != 2001 || resp.BestBidderUserId != "44444444-4444-4444-4444-444444444444" {
+		t.Fatalf("unexpected response: %+v", resp)
+	}
+	if club.releaseHoldCalls != 1 || club.releaseHoldID != club.holdIDs[0] {
+		t.Fatalf("expected challenger hold to be released, got %q", club.releaseHoldID)
+	}
+	if len(repo.lastPlacement.Bids) != 2 || repo.lastPlacement.Bids[1].HoldID != "hold-leader" {
+		t.Fatalf("expected leader auto bid on its own hold, got %+v", repo.lastPlacement.Bids)
+	}
+}
+
+func TestPlaceBidMaxBidBelowAmount(t *testing.T) {
+	server := NewServer(slog.Default(), &fakeRepo{}, &fakeClub{}, &fakeLock{token: "token", ok: true})
+
+	_, err := server.PlaceBid(context.Background(), &marketv1.PlaceBidRequest{
+		ListingId:    "11111111-1111-1111-1111-111111111111",
+		BidderUserId: "22222222-2222-2222-2222-222222222222",
+		BidAmount:    2000,
+		MaxBid:       1500,
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument, got %v", err)
+	}
+}
@@ -0,0 +1,281 @@
+package market
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	clubv1 "UltimateTeamX/proto/club/v1"
+	marketv1 "UltimateTeamX/proto/market/v1"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Limiti di RelistAllExpired.
+const (
+	defaultRelistBatch = 50
+	maxRelistBatch     = 100
+)
+
+// relistTerms sono i valori richiesti per il nuovo listing (0 = valore del precedente).
+type relistTerms struct {
+	StartPrice      int64
+	BuyNowPrice     int64
+	ReservePrice    int64
+	DurationSeconds int64
+}
+
+// RelistListing rimette in vendita un listing scaduto senza vendita, collegando il nuovo
+// listing al precedente. Con idempotency key un retry ritorna lo stesso listing_id.
+func (s *Server) RelistListing(ctx context.Context, req *marketv1.RelistListingRequest) (*marketv1.RelistListingResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "request is required")
+	}
+	if strings.TrimSpace(req.ListingId) == "" {
+		return nil, status.Error(codes.InvalidArgument, "listing_id is required")
+	}
+	if strings.TrimSpace(req.SellerUserId) == "" {
+		return nil, status.Error(codes.InvalidArgument, "seller_user_id is required")
+	}
+	if !isUUID(req.ListingId) {
+		return nil, status.Error(codes.InvalidArgument, "listing_id must be a valid UUID")
+	}
+	if !isUUID(req.SellerUserId) {
+		return nil, status.Error(codes.InvalidArgument, "seller_user_id must be a valid UUID")
+	}
+	if req.StartPrice < 0 || req.BuyNowPrice < 0 || req.ReservePrice < 0 {
+		return nil, status.Error(codes.InvalidArgument, "prices cannot be negative")
+	}
+	if req.DurationSeconds < 0 {
+		return nil, status.Error(codes.InvalidArgument, "duration_seconds cannot be negative")
+	}
+
+	return idempotent(ctx, s, "RelistListing", req.SellerUserId, req, &marketv1.RelistListingResponse{}, func() (*marketv1.RelistListingResponse, error) {
+		sellerClub, err := s.clubForUser(ctx, req.SellerUserId)
+		if err != nil {
+			return nil, err
+		}
+		return s.relist(ctx, req.SellerUserId, sellerClub, req.ListingId, relistTerms{
+			StartPrice:      req.StartPrice,
+			BuyNowPrice:     req.BuyNowPrice,
+			ReservePrice:    req.ReservePrice,
+			DurationSeconds: req.DurationSeconds,
+		})
+	})
+}
+
+// RelistAllExpired rimette in vendita ai prezzi precedenti tutti i listing scaduti del club
+// (l'ultimo per carta). Gli errori sui singoli listing non fermano il batch.
+func (s *Server) RelistAllExpired(ctx context.Context, req *marketv1.RelistAllExpiredRequest) (*marketv1.RelistAllExpiredResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "request is required")
+	}
+	if strings.TrimSpace(req.SellerUserId) == "" {
+		return nil, status.Error(codes.InvalidArgument, "seller_user_id is required")
+	}
+	if !isUUID(req.SellerUserId) {
+		return nil, status.Error(codes.InvalidArgument, "seller_user_id must be a valid UUID")
+	}
+	if req.DurationSeconds < 0 {
+		return nil, status.Error(codes.InvalidArgument, "duration_seconds cannot be negative")
+	}
+	limit := int(req.Limit)
+	if limit <= 0 {
+		limit = defaultRelistBatch
+	}
+	if limit > maxRelistBatch {
+		return nil, status.Error(codes.InvalidArgument, "limit too large")
+	}
+
+	// 1) Risolve il club una volta sola per tutto il batch.
+	sellerClub, err := s.clubForUser(ctx, req.SellerUserId)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2) Listing da rimettere in vendita (ultimo listing per carta, scaduto senza vendita).
+	ids, err := s.repo.ListRelistableListingIDs(ctx, sellerClub.ClubId, limit)
+	if err != nil {
+		s.logger.Error("errore lettura listing scaduti", "error", err, "seller_club_id", sellerClub.ClubId)
+		return nil, status.Error(codes.Internal, "failed to list expired listings")
+	}
+
+	resp := &marketv1.RelistAllExpiredResponse{}
+	for _, id := range ids {
+		relisted, err := s.relist(ctx, req.SellerUserId, sellerClub, id, relistTerms{DurationSeconds: req.DurationSeconds})
+		if err != nil {
+			resp.Failed = append(resp.Failed, &marketv1.RelistFailure{
+				PreviousListingId: id,
+				Reason:            status.Convert(err).Message(),
+			})
+			continue
+		}
+		resp.Relisted = append(resp.Relisted, relisted)
+	}
+
+	s.logger.Info("relist listing scaduti", "seller_club_id", sellerClub.ClubId, "relisted", len(resp.Relisted), "failed", len(resp.Failed))
+	return resp, nil
+}
+
+// relist crea il nuovo listing sotto il lock Redis del precedente.
+// Se il precedente e' ancora ACTIVE (scaduto, senza offerte, non ancora chiuso dal worker)
+// il lock carta viene riusato; se e' gia' EXPIRED il lock era stato rilasciato e si
+// ripete LockCard con la stessa saga di CreateListing.
+func (s *Server) relist(ctx context.Context, sellerUserID string, sellerClub *clubv1.GetMyClubResponse, previousID string, terms relistTerms) (*marketv1.RelistListingResponse, error) {
+	if s.locker == nil {
+		return nil, status.Error(codes.Internal, "redis lock not configured")
+	}
+
+	// 1) Lock Redis del listing precedente: esclude il worker di scadenza e relist concorrenti.
+	unlock, err := s.acquireListingLock(ctx, previousID)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	// 2) Carica il precedente e verifica proprietario e stato.
+	previous, err := s.loadListing(ctx, previousID)
+	if err != nil {
+		return nil, err
+	}
+	if previous.SellerClubID != sellerClub.ClubId {
+		return nil, status.Error(codes.PermissionDenied, "listing does not belong to seller")
+	}
+	if previous.Kind == listingKindBundle {
+		return nil, status.Error(codes.FailedPrecondition, "bundle listings cannot be relisted")
+	}
+	reuseLock := false
+	switch previous.Status {
+	case listingStatusExpired:
+	case listingStatusActive:
+		if previous.ExpiresAtUnix > time.Now().Unix() {
+			return nil, status.Error(codes.FailedPrecondition, "listing still active")
+		}
+		if previous.BestBid != nil {
+			return nil, status.Error(codes.FailedPrecondition, "listing has bids")
+		}
+		reuseLock = previous.LockID != ""
+	default:
+		return nil, status.Error(codes.FailedPrecondition, "listing cannot be relisted")
+	}
+
+	// 3) Nessun altro listing ACTIVE per la stessa carta.
+	existingID, err := s.repo.ActiveListingByCard(ctx, previous.UserCardID)
+	if err != nil {
+		s.logger.Error("errore verifica listing attivo", "error", err)
+		return nil, status.Error(codes.Internal, "failed to check existing listing")
+	}
+	if existingID != "" && existingID != previous.ID {
+		return nil, status.Error(codes.AlreadyExists, "active listing already exists for card")
+	}
+
+	// 4) Condizioni del nuovo listing: i campi a 0 riusano quelli del precedente.
+	create := relistRequest(sellerUserID, previous, terms, time.Now())
+	if err := validateCreateListing(create); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	playerID := previous.PlayerID
+	if playerID == "" {
+		playerID = playerIDForCard(sellerClub, previous.UserCardID)
+	}
+	// Un precedente ancora ACTIVE e' gia' conteggiato nel limite e viene sostituito.
+	if previous.Status != listingStatusActive {
+		if err := s.checkActiveListingsLimit(ctx, sellerClub.ClubId); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.checkPriceBounds(ctx, playerID, "listing price", create.StartPrice, create.BuyNowPrice); err != nil {
+		return nil, err
+	}
+
+	listing := Listing{
+		ID:                uuid.NewString(),
+		SellerClubID:      sellerClub.ClubId,
+		UserCardID:        previous.UserCardID,
+		StartPrice:        create.StartPrice,
+		BuyNowPrice:       optionalPrice(create.BuyNowPrice),
+		ReservePrice:      optionalPrice(create.ReservePrice),
+		Status:            listingStatusActive,
+		ExpiresAtUnix:     create.ExpiresAtUnix,
+		LockID:            previous.LockID,
+		PlayerID:          playerID,
+		PreviousListingID: previous.ID,
+	}
+
+	// 5) Lock carta: riusato dal precedente o rinnovato in club-svc sotto saga.
+	var saga *Saga
+	if !reuseLock {
+		saga, err = s.startSaga(ctx, sagaKindCreateListing, listing.ID, sellerUserID)
+		if err != nil {
+			return nil, err
+		}
+		lockID, err := s.beginSagaStep(ctx, saga, sagaStepCardLock, compensationReleaseCardLock)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.lockCard(ctx, sellerUserID, previous.UserCardID, lockID); err != nil {
+			s.failSagaStep(ctx, saga, err)
+			return nil, err
+		}
+		listing.LockID = lockID
+	}
+
+	// 6) Chiude il precedente (se ancora ACTIVE) e inserisce il nuovo nella stessa transazione.
+	if err := s.repo.RelistListing(ctx, listing); err != nil {
+		if saga != nil {
+			_ = s.compensateSaga(ctx, saga)
+		}
+		if errors.Is(err, ErrAlreadyRelisted) {
+			return nil, status.Error(codes.AlreadyExists, "listing already relisted")
+		}
+		s.logger.Error("errore relist listing nel db", "error", err, "previous_listing_id", previous.ID)
+		return nil, status.Error(codes.Internal, "failed to relist listing")
+	}
+	if saga != nil {
+		s.finishSaga(ctx, saga, sagaStatusCompleted)
+	}
+	if previous.Status == listingStatusActive {
+		s.publishEvent(ctx, previous, marketv1.ListingEventType_LISTING_EVENT_TYPE_EXPIRED, 0, "")
+	}
+
+	s.logger.Info("listing rimesso in vendita", "listing_id", listing.ID, "previous_listing_id", previous.ID, "lock_reused", reuseLock)
+	return &marketv1.RelistListingResponse{
+		ListingId:         listing.ID,
+		PreviousListingId: previous.ID,
+		ExpiresAtUnix:     listing.ExpiresAtUnix,
+	}, nil
+}
+
+// relistRequest costruisce la CreateListingRequest equivalente al relist, cosi' il nuovo
+// listing passa dalle stesse validazioni di CreateListing.
+// La durata di default e' quella del precedente (estensioni soft-close incluse).
+func relistRequest(sellerUserID string, previous Listing, terms relistTerms, now time.Time) *marketv1.CreateListingRequest {
+	req := &marketv1.CreateListingRequest{
+		SellerUserId: sellerUserID,
+		UserCardId:   previous.UserCardID,
+		StartPrice:   previous.StartPrice,
+	}
+	if previous.BuyNowPrice != nil {
+		req.BuyNowPrice = *previous.BuyNowPrice
+	}
+	if previous.ReservePrice != nil {
+		req.ReservePrice = *previous.ReservePrice
+	}
+	if terms.StartPrice > 0 {
+		req.StartPrice = terms.StartPrice
+	}
+	if terms.BuyNowPrice > 0 {
+		req.BuyNowPrice = terms.BuyNowPrice
+	}
+	if terms.ReservePrice > 0 {
+		req.ReservePrice = terms.ReservePrice
+	}
+	duration := terms.DurationSeconds
+	if duration <= 0 {
+		duration = previous.ExpiresAtUnix - previous.CreatedAtUnix
+	}
+	req.ExpiresAtUnix = now.Unix() + duration
+	return req
+}
@@ -0,0 +1,171 @@
+package market
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	marketv1 "UltimateTeamX/proto/market/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Test suite per RelistListing e RelistAllExpired.
+
+const (
+	relistListingID = "11111111-1111-1111-1111-111111111111"
+	relistSellerID  = "22222222-2222-2222-2222-222222222222"
+)
+
+func expiredListing(status string) Listing {
+	buyNow := int64(5000)
+	now := time.Now()
+	return Listing{
+		ID:            relistListingID,
+		SellerClubID:  "club-1",
+		UserCardID:    "44444444-4444-4444-4444-444444444444",
+		PlayerID:      testPlayerID,
+		StartPrice:    1000,
+		BuyNowPrice:   &buyNow,
+		Status:        status,
+		CreatedAtUnix: now.Add(-2 * time.Hour).Unix(),
+		ExpiresAtUnix: now.Add(-time.Hour).Unix(),
+		LockID:        "lock-old",
+	}
+}
+
+// Caso: listing ACTIVE scaduto senza offerte, il lock carta passa al nuovo listing.
+func TestRelistListingReusesCardLock(t *testing.T) {
+	repo := &fakeRepo{listing: expiredListing(listingStatusActive)}
+	club := &fakeClub{}
+	server := NewServer(slog.Default(), repo, club, &fakeLock{token: "token", ok: true})
+
+	resp, err := server.RelistListing(context.Background(), &marketv1.RelistListingRequest{
+		ListingId:    relistListingID,
+		SellerUserId: relistSellerID,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if club.lockCalls != 0 {
+		t.Fatalf("expected card lock to be reused, got %d LockCard calls", club.lockCalls)
+	}
+	if len(repo.relisted) != 1 {
+		t.Fatalf("expected one relisted listing, got %d", len(repo.relisted))
+	}
+	relisted := repo.relisted[0]
+	if relisted.ID != resp.ListingId || relisted.PreviousListingID != relistListingID || relisted.LockID != "lock-old" {
+		t.Fatalf("unexpected relisted listing: %+v", relisted)
+	}
+	if relisted.StartPrice != 1000 || relisted.BuyNowPrice == nil || *relisted.BuyNowPrice != 5000 {
+		t.Fatalf("expected previous prices, got %+v", relisted)
+	}
+	// Stessa durata del precedente (1h).
+	if duration := resp.ExpiresAtUnix - time.Now().Unix(); duration < 3590 || duration > 3600 {
+		t.Fatalf("expected previous duration, got %ds", duration)
+	}
+}
+
+// Caso: listing gia' EXPIRED, nuovo lock carta e nuovi prezzi.
+func TestRelistListingRefreshesCardLock(t *testing.T) {
+	repo := &fakeRepo{listing: expiredListing(listingStatusExpired)}
+	club := &fakeClub{}
+	server := NewServer(slog.Default(), repo, club, &fakeLock{token: "token", ok: true})
+
+	_, err := server.RelistListing(context.Background(), &marketv1.RelistListingRequest{
+		ListingId:       relistListingID,
+		SellerUserId:    relistSellerID,
+		StartPrice:      800,
+		BuyNowPrice:     4000,
+		DurationSeconds: 600,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if club.lockCalls != 1 {
+		t.Fatalf("expected one LockCard call, got %d", club.lockCalls)
+	}
+	relisted := repo.relisted[0]
+	if relisted.LockID != club.lockIDs[0] || relisted.StartPrice != 800 || *relisted.BuyNowPrice != 4000 {
+		t.Fatalf("unexpected relisted listing: %+v", relisted)
+	}
+}
+
+// Caso: listing non rimettibile in vendita.
+func TestRelistListingRejected(t *testing.T) {
+	withBids := expiredListing(listingStatusActive)
+	bestBid := int64(1200)
+	withBids.BestBid = &bestBid
+	otherSeller := expiredListing(listingStatusExpired)
+	otherSeller.SellerClubID = "club-other"
+	sold := expiredListing(listingStatusSold)
+
+	cases := []struct {
+		name    string
+		listing Listing
+		code    codes.Code
+	}{
+		{"with bids", withBids, codes.FailedPrecondition},
+		{"other seller", otherSeller, codes.PermissionDenied},
+		{"sold", sold, codes.FailedPrecondition},
+	}
+	for _, tc := range cases {
+		repo := &fakeRepo{listing: tc.listing}
+		server := NewServer(slog.Default(), repo, &fakeClub{}, &fakeLock{token: "token", ok: true})
+		_, err := server.RelistListing(context.Background(), &marketv1.RelistListingRequest{
+			ListingId:    relistListingID,
+			SellerUserId: relistSellerID,
+		})
+		if status.Code(err) != tc.code {
+			t.Fatalf("%s: expected %v, got %v", tc.name, tc.code, err)
+		}
+		if len(repo.relisted) != 0 {
+			t.Fatalf("%s: did not expect relist", tc.name)
+		}
+	}
+}
+
+// Caso: listing gia' rimesso in vendita, il nuovo lock carta viene compensato.
+func TestRelistListingAlreadyRelisted(t *testing.T) {
+	repo := &fakeRepo{listing: expiredListing(listingStatusExpired), relistErr: ErrAlreadyRelisted}
+	club := &fakeClub{}
+	server := NewServer(slog.Default(), repo, club, &fakeLock{token: "token", ok: true})
+
+	_, err := server.RelistListing(context.Background(), &marketv1.RelistListingRequest{
+		ListingId:    relistListingID,
+		SellerUserId: relistSellerID,
+	})
+	if status.Code(err) != codes.AlreadyExists {
+		t.Fatalf("expected AlreadyExists, got %v", err)
+	}
+	if club.releaseCalls != 1 || club.releaseLastLockID != club.lockIDs[0] {
+		t.Fatalf("expected new card lock to be released, got %d calls (%s)", club.releaseCalls, club.releaseLastLockID)
+	}
+}
+
+// Caso: relist di tutti i listing scaduti, gli errori non fermano il batch.
+func TestRelistAllExpired(t *testing.T) {
+	repo := &fakeRepo{
+		listing:       expiredListing(listingStatusExpired),
+		relistableIDs: []string{relistListingID, "55555555-5555-5555-5555-555555555555"},
+	}
+	server := NewServer(slog.Default(), repo, &fakeClub{}, &fakeLock{token: "token", ok: true})
+
+	resp, err := server.RelistAllExpired(context.Background(), &marketv1.RelistAllExpiredRequest{SellerUserId: relistSellerID})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(resp.Relisted) != 2 || len(resp.Failed) != 0 {
+		t.Fatalf("expected 2 relisted, got %+v", resp)
+	}
+
+	repo.relistErr = ErrAlreadyRelisted
+	resp, err = server.RelistAllExpired(context.Background(), &marketv1.RelistAllExpiredRequest{SellerUserId: relistSellerID})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(resp.Relisted) != 0 || len(resp.Failed) != 2 || resp.Failed[0].Reason != "listing already relisted" {
+		t.Fatalf("expected 2 failures, got %+v", resp)
+	}
+}
@@ -3,14 +3,26 @@ package market
 import (
 	"context"
 	"database/sql"
+	"errors"
+	"fmt"
 	"log/slog"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 )
 
 // ErrNotFound indica che la risorsa non esiste.
 var ErrNotFound = sql.ErrNoRows
 
+// ErrListingNotActive indica che il listing ha gia' lasciato lo stato ACTIVE
+// (per i bid: anche un listing ACTIVE con expires_at gia' passato).
+var ErrListingNotActive = errors.New("listing not active")
+
+// ErrAlreadyRelisted indica che il listing e' gia' stato rimesso in vendita.
+var ErrAlreadyRelisted = errors.New("listing already relisted")
+
 // Repo gestisce le query SQL per il market.
 type Repo struct {
 	db *sql.DB
@@ -27,6 +39,29 @@ type Listing struct {
 	ExpiresAtUnix    int64
 	BestBid          *int64
 	BestBidderClubID *string
+	LockID           string
+	// PlayerID e' il giocatore della carta, risolto da club-svc alla creazione (vuoto per i listing storici).
+	PlayerID      string
+	CreatedAtUnix int64
+	// ExtensionCount conta le estensioni soft-close gia' applicate.
+	ExtensionCount int
+	// BestMaxBid e' il tetto proxy del best bidder: non va mai esposto agli altri utenti.
+	BestMaxBid *int64
+	// ReservePrice e' la riserva nascosta del seller (nil = nessuna riserva).
+	ReservePrice *int64
+	// PreviousListingID e' il listing scaduto da cui e' stato rimesso in vendita (vuoto se nuovo).
+	PreviousListingID string
+	// Kind e' SINGLE o BUNDLE; per i BUNDLE Items contiene tutte le carte del lotto
+	// (caricate da GetListing, non da SearchListings) e UserCardID e' la prima.
+	Kind  string
+	Items []ListingItem
+}
+
+// ListingItem e' una carta di un listing BUNDLE con il proprio lock in club-svc.
+type ListingItem struct {
+	UserCardID string
+	PlayerID   string
+	LockID     string
 }
 
 // NewRepo collega il repository a una connessione SQL.
@@ -35,11 +70,17 @@ func NewRepo(db *sql.DB) *Repo {
 }
 
 // ActiveListingByCard ritorna l'ID del listing attivo per la carta, o vuoto se non c'è.
+// Considera anche le carte vendute dentro un bundle.
 func (r *Repo) ActiveListingByCard(ctx context.Context, userCardID string) (string, error) {
 	const query = `
 SELECT id
 FROM listings
 WHERE user_card_id = $1 AND status = 'ACTIVE'
+UNION ALL
+SELECT l.id
+FROM listing_items i
+JOIN listings l ON l.id = i.listing_id
+WHERE i.user_card_id = $1 AND l.status = 'ACTIVE'
 LIMIT 1`
 
 	var id string
@@ -55,7 +96,49 @@ LIMIT 1`
 }
 
 // CreateListing inserisce un nuovo listing in stato ACTIVE.
+// Per i bundle listing e carte del lotto sono scritti nella stessa transazione.
 func (r *Repo) CreateListing(ctx context.Context, listing Listing) error {
+	if len(listing.Items) == 0 {
+		if err := insertListing(ctx, r.db, listing); err != nil {
+			slog.Error("errore insert listing", "error", err, "listing_id", listing.ID)
+			return err
+		}
+		return nil
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	if err := insertListing(ctx, tx, listing); err != nil {
+		slog.Error("errore insert listing", "error", err, "listing_id", listing.ID)
+		return err
+	}
+
+	const insertItem = `
+INSERT INTO listing_items (listing_id, position, user_card_id, player_id, lock_id)
+VALUES ($1,$2,$3,$4,$5)`
+
+	for i, item := range listing.Items {
+		if _, err := tx.ExecContext(ctx, insertItem, listing.ID, i, item.UserCardID, nullableText(item.PlayerID), item.LockID); err != nil {
+			slog.Error("errore insert carta bundle", "error", err, "listing_id", listing.ID, "user_card_id", item.UserCardID)
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// execer astrae *sql.DB e *sql.Tx per le scritture condivise.
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+// insertListing inserisce una riga listings (nuovo listing o relist).
+func insertListing(ctx context.Context, db execer, listing Listing) error {
 	const query = `
 INSERT INTO listings (
   id,
@@ -67,10 +150,20 @@ INSERT INTO listings (
   best_bidder_club_id,
   status,
   expires_at,
+  lock_id,
+  player_id,
+  reserve_price,
+  previous_listing_id,
+  kind,
   created_at
-) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,to_timestamp($9),now())`
+) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,to_timestamp($9),$10,$11,$12,$13,$14,now())`
+
+	kind := listing.Kind
+	if kind == "" {
+		kind = "SINGLE"
+	}
 
-	_, err := r.db.ExecContext(
+	_, err := db.ExecContext(
 		ctx,
 		query,
 		listing.ID,
@@ -82,17 +175,87 @@ INSERT INTO listings (
 		nullString(listing.BestBidderClubID),
 		listing.Status,
 		listing.ExpiresAtUnix,
+		nullableText(listing.LockID),
+		nullableText(listing.PlayerID),
+		nullInt64(listing.ReservePrice),
+		nullableText(listing.PreviousListingID),
+		kind,
 	)
+	return err
+}
+
+// RelistListing inserisce il nuovo listing collegato a listing.PreviousListingID.
+// Nella stessa transazione chiude come EXPIRED il precedente se e' ancora ACTIVE
+// senza offerte (scaduto ma non ancora processato dal worker), cosi' il lock carta
+// passa al nuovo listing senza che il worker lo rilasci.
+func (r *Repo) RelistListing(ctx context.Context, listing Listing) error {
+	tx, err := r.db.BeginTx(ctx, nil)
 	if err != nil {
-		slog.Error("errore insert listing", "error", err, "listing_id", listing.ID)
+		return err
 	}
-	return err
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	const closePrevious = `
+UPDATE listings
+SET status = 'EXPIRED'
+WHERE id = $1 AND status = 'ACTIVE' AND best_bid IS NULL`
+
+	if _, err := tx.ExecContext(ctx, closePrevious, listing.PreviousListingID); err != nil {
+		slog.Error("errore chiusura listing precedente", "error", err, "listing_id", listing.PreviousListingID)
+		return err
+	}
+	if err := insertListing(ctx, tx, listing); err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+			return ErrAlreadyRelisted
+		}
+		slog.Error("errore insert relist", "error", err, "listing_id", listing.ID, "previous_listing_id", listing.PreviousListingID)
+		return err
+	}
+	return tx.Commit()
 }
 
-// GetListing carica il listing completo dal DB.
-func (r *Repo) GetListing(ctx context.Context, listingID string) (Listing, error) {
+// ListRelistableListingIDs ritorna i listing del seller da rimettere in vendita:
+// per ogni carta conta solo l'ultimo listing SINGLE, se EXPIRED o ACTIVE scaduto senza offerte.
+func (r *Repo) ListRelistableListingIDs(ctx context.Context, sellerClubID string, limit int) ([]string, error) {
 	const query = `
-SELECT
+SELECT id
+FROM (
+  SELECT DISTINCT ON (user_card_id) id, status, expires_at, best_bid
+  FROM listings
+  WHERE seller_club_id = $1 AND kind = 'SINGLE'
+  ORDER BY user_card_id, created_at DESC
+) latest
+WHERE status = 'EXPIRED'
+   OR (status = 'ACTIVE' AND expires_at <= now() AND best_bid IS NULL)
+ORDER BY expires_at
+LIMIT $2`
+
+	rows, err := r.db.QueryContext(ctx, query, sellerClubID, limit)
+	if err != nil {
+		slog.Error("errore lettura listing da rimettere in vendita", "error", err, "seller_club_id", sellerClubID)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// listingColumns e' la proiezione condivisa da GetListing e SearchListings (vedi scanListing).
+const listingColumns = `
   id,
   seller_club_id,
   user_card_id,
@@ -101,16 +264,34 @@ SELECT
   best_bid,
   best_bidder_club_id,
   status,
-  EXTRACT(EPOCH FROM expires_at)::bigint
-FROM listings
-WHERE id = $1`
+  EXTRACT(EPOCH FROM expires_at)::bigint,
+  lock_id,
+  player_id,
+  EXTRACT(EPOCH FROM created_at)::bigint,
+  extension_count,
+  best_max_bid,
+  reserve_price,
+  previous_listing_id,
+  kind`
+
+// rowScanner astrae *sql.Row e *sql.Rows.
+type rowScanner interface {
+	Scan(dest ...any) error
+}
 
+// scanListing legge una riga prodotta da listingColumns.
+func scanListing(row rowScanner) (Listing, error) {
 	var listing Listing
 	var buyNow sql.NullInt64
 	var bestBid sql.NullInt64
 	var bestBidder sql.NullString
+	var lockID sql.NullString
+	var playerID sql.NullString
+	var bestMaxBid sql.NullInt64
+	var reservePrice sql.NullInt64
+	var previousListingID sql.NullString
 
-	err := r.db.QueryRowContext(ctx, query, listingID).Scan(
+	if err := row.Scan(
 		&listing.ID,
 		&listing.SellerClubID,
 		&listing.UserCardID,
@@ -120,7 +301,37 @@ WHERE id = $1`
 		&bestBidder,
 		&listing.Status,
 		&listing.ExpiresAtUnix,
-	)
+		&lockID,
+		&playerID,
+		&listing.CreatedAtUnix,
+		&listing.ExtensionCount,
+		&bestMaxBid,
+		&reservePrice,
+		&previousListingID,
+		&listing.Kind,
+	); err != nil {
+		return Listing{}, err
+	}
+
+	listing.BuyNowPrice = nullInt64Ptr(buyNow)
+	listing.BestBid = nullInt64Ptr(bestBid)
+	listing.BestBidderClubID = nullStringPtr(bestBidder)
+	listing.LockID = lockID.String
+	listing.PlayerID = playerID.String
+	listing.BestMaxBid = nullInt64Ptr(bestMaxBid)
+	listing.ReservePrice = nullInt64Ptr(reservePrice)
+	listing.PreviousListingID = previousListingID.String
+	return listing, nil
+}
+
+// GetListing carica il listing completo dal DB.
+func (r *Repo) GetListing(ctx context.Context, listingID string) (Listing, error) {
+	query := `
+SELECT` + listingColumns + `
+FROM listings
+WHERE id = $1`
+
+	listing, err := scanListing(r.db.QueryRowContext(ctx, query, listingID))
 	if err == sql.ErrNoRows {
 		return Listing{}, ErrNotFound
 	}
@@ -128,25 +339,223 @@ WHERE id = $1`
 		slog.Error("errore lettura listing", "error", err, "listing_id", listingID)
 		return Listing{}, err
 	}
+	if listing.Kind == "BUNDLE" {
+		listing.Items, err = r.listingItems(ctx, listingID)
+		if err != nil {
+			slog.Error("errore lettura carte bundle", "error", err, "listing_id", listingID)
+			return Listing{}, err
+		}
+	}
+	return listing, nil
+}
 
-	listing.BuyNowPrice = nullInt64Ptr(buyNow)
-	listing.BestBid = nullInt64Ptr(bestBid)
-	listing.BestBidderClubID = nullStringPtr(bestBidder)
+// listingItems legge le carte di un bundle nell'ordine di inserimento.
+func (r *Repo) listingItems(ctx context.Context, listingID string) ([]ListingItem, error) {
+	const query = `
+SELECT user_card_id, player_id, lock_id
+FROM listing_items
+WHERE listing_id = $1
+ORDER BY position`
 
-	return listing, nil
+	rows, err := r.db.QueryContext(ctx, query, listingID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var items []ListingItem
+	for rows.Next() {
+		var item ListingItem
+		var playerID sql.NullString
+		if err := rows.Scan(&item.UserCardID, &playerID, &item.LockID); err != nil {
+			return nil, err
+		}
+		item.PlayerID = playerID.String
+		items = append(items, item)
+	}
+	return items, rows.Err()
+}
+
+// Campi prezzo e ordinamento ammessi da SearchListings.
+const (
+	priceFieldCurrent = "current"
+	priceFieldStart   = "start"
+	priceFieldBuyNow  = "buy_now"
+	priceFieldBestBid = "best_bid"
+
+	sortFieldExpiresAt = "expires_at"
+	sortFieldPrice     = "price"
+	sortFieldCreatedAt = "created_at"
+)
+
+// priceColumns mappa i campi prezzo su espressioni SQL fisse (mai input utente).
+var priceColumns = map[string]string{
+	priceFieldCurrent: "COALESCE(best_bid, start_price)",
+	priceFieldStart:   "start_price",
+	priceFieldBuyNow:  "buy_now_price",
+	priceFieldBestBid: "best_bid",
+}
+
+// ListingFilter descrive filtri, ordinamento e pagina di SearchListings.
+// I valori zero disattivano il filtro corrispondente.
+type ListingFilter struct {
+	// Status filtra per stato; EXPIRED include gli ACTIVE gia' scaduti, ACTIVE li esclude.
+	Status       string
+	PriceField   string
+	MinPrice     int64
+	MaxPrice     int64
+	SellerClubID string
+	PlayerID     string
+	// EndingBefore limita ai listing con expires_at entro l'istante indicato.
+	EndingBefore time.Time
+	SortField    string
+	SortDesc     bool
+	Limit        int
+	Offset       int
+	Now          time.Time
+}
+
+// SearchListings ritorna una pagina di listing filtrati e il totale dei risultati.
+func (r *Repo) SearchListings(ctx context.Context, filter ListingFilter) ([]Listing, int64, error) {
+	priceColumn, ok := priceColumns[filter.PriceField]
+	if !ok {
+		priceColumn = priceColumns[priceFieldCurrent]
+	}
+
+	var where []string
+	var args []any
+	arg := func(value any) string {
+		args = append(args, value)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	switch filter.Status {
+	case "":
+	case listingStatusActive:
+		where = append(where, "status = 'ACTIVE' AND expires_at > "+arg(filter.Now))
+	case listingStatusExpired:
+		where = append(where, "(status = 'EXPIRED' OR (status = 'ACTIVE' AND expires_at <= "+arg(filter.Now)+"))")
+	default:
+		where = append(where, "status = "+arg(filter.Status))
+	}
+	if filter.MinPrice > 0 {
+		where = append(where, priceColumn+" >= "+arg(filter.MinPrice))
+	}
+	if filter.MaxPrice > 0 {
+		where = append(where, priceColumn+" <= "+arg(filter.MaxPrice))
+	}
+	if filter.SellerClubID != "" {
+		where = append(where, "seller_club_id = "+arg(filter.SellerClubID))
+	}
+	if filter.PlayerID != "" {
+		where = append(where, "player_id = "+arg(filter.PlayerID))
+	}
+	if !filter.EndingBefore.IsZero() {
+		where = append(where, "expires_at <= "+arg(filter.EndingBefore))
+	}
+
+	whereClause := ""
+	if len(where) > 0 {
+		whereClause = "\nWHERE " + strings.Join(where, "\n  AND ")
+	}
+
+	// 1) Totale dei risultati per la paginazione.
+	var total int64
+	countQuery := "SELECT COUNT(*)\nFROM listings" + whereClause
+	if err := r.db.QueryRowContext(ctx, countQuery, args...).Scan(&total); err != nil {
+		slog.Error("errore conteggio ricerca listing", "error", err)
+		return nil, 0, err
+	}
+	if total == 0 {
+		return nil, 0, nil
+	}
+
+	// 2) Pagina ordinata; id come tie-break per un ordine stabile tra pagine.
+	orderColumn := "expires_at"
+	switch filter.SortField {
+	case sortFieldPrice:
+		orderColumn = priceColumn
+	case sortFieldCreatedAt:
+		orderColumn = "created_at"
+	}
+	direction := "ASC"
+	if filter.SortDesc {
+		direction = "DESC"
+	}
+	query := "SELECT" + listingColumns + "\nFROM listings" + whereClause +
+		"\nORDER BY " + orderColumn + " " + direction + " NULLS LAST, id " + direction +
+		"\nLIMIT " + arg(filter.Limit) + " OFFSET " + arg(filter.Offset)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		slog.Error("errore ricerca listing", "error", err)
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var listings []Listing
+	for rows.Next() {
+		listing, err := scanListing(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		listings = append(listings, listing)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+	return listings, total, nil
+}
+
+// SoftClose configura l'anti-sniping: un bid negli ultimi Window sposta expires_at
+// avanti di Extension, al massimo MaxExtensions volte. Window o Extension a zero lo disattivano.
+type SoftClose struct {
+	Window        time.Duration
+	Extension     time.Duration
+	MaxExtensions int
 }
 
-// InsertBidAndUpdateListing inserisce il bid e aggiorna il best_bid in transazione.
-func (r *Repo) InsertBidAndUpdateListing(ctx context.Context, listingID, bidderClubID, holdID string, amount int64) (string, error) {
+// BidRow e' un bid da registrare; Auto marca i rilanci automatici del proxy bidding.
+type BidRow struct {
+	BidderClubID string
+	HoldID       string
+	Amount       int64
+	MaxBid       *int64
+	Auto         bool
+}
+
+// BidPlacement descrive i bid da inserire e il nuovo stato d'asta del listing.
+type BidPlacement struct {
+	ListingID        string
+	Bids             []BidRow
+	BestBid          int64
+	BestBidderClubID string
+	// BestMaxBid e' il tetto (nascosto) del best bidder; nil per i bid normali.
+	BestMaxBid *int64
+	SoftClose  SoftClose
+}
+
+// BidResult riporta i bid inseriti e la scadenza del listing dopo l'eventuale estensione.
+type BidResult struct {
+	BidIDs         []string
+	ExpiresAtUnix  int64
+	ExtensionCount int
+}
+
+// InsertBidAndUpdateListing inserisce i bid e aggiorna il best_bid in transazione.
+// L'estensione soft-close e' calcolata nello stesso UPDATE, quindi e' atomica con il bid.
+// Un bid arrivato dopo expires_at (es. dopo il round-trip dell'hold) non aggiorna nulla
+// e ritorna ErrListingNotActive: un'asta gia' chiusa non puo' essere estesa.
+func (r *Repo) InsertBidAndUpdateListing(ctx context.Context, placement BidPlacement) (BidResult, error) {
 	tx, err := r.db.BeginTx(ctx, nil)
 	if err != nil {
-		return "", err
+		return BidResult{}, err
 	}
 	defer func() {
 		_ = tx.Rollback()
 	}()
 
-	bidID := uuid.NewString()
+	// clock_timestamp() distingue l'ordine dei bid inseriti nella stessa transazione.
 	const insertBid = `
 INSERT INTO bids (
   id,
@@ -154,26 +563,66 @@ INSERT INTO bids (
   bidder_club_id,
   amount,
   hold_id,
+  max_bid,
+  auto_bid,
   created_at
-) VALUES ($1,$2,$3,$4,$5,now())`
+) VALUES ($1,$2,$3,$4,$5,$6,$7,clock_timestamp())`
 
-	if _, err := tx.ExecContext(ctx, insertBid, bidID, listingID, bidderClubID, amount, holdID); err != nil {
-		return "", err
+	var result BidResult
+	for _, bid := range placement.Bids {
+		bidID := uuid.NewString()
+		if _, err := tx.ExecContext(ctx, insertBid, bidID, placement.ListingID, bid.BidderClubID, bid.Amount, nullableText(bid.HoldID), nullInt64(bid.MaxBid), bid.Auto); err != nil {
+			return BidResult{}, err
+		}
+		result.BidIDs = append(result.BidIDs, bidID)
 	}
 
+	// Le espressioni di SET leggono i valori precedenti all'update.
 	const updateListing = `
 UPDATE listings
 SET best_bid = $1,
-    best_bidder_club_id = $2
-WHERE id = $3`
-	if _, err := tx.ExecContext(ctx, updateListing, amount, bidderClubID, listingID); err != nil {
-		return "", err
+    best_bidder_club_id = $2,
+    best_max_bid = $7,
+    expires_at = CASE
+      WHEN $4::float8 > 0 AND extension_count < $5::int AND expires_at - now() <= make_interval(secs => $3::float8)
+        THEN expires_at + make_interval(secs => $4::float8)
+      ELSE expires_at
+    END,
+    extension_count = CASE
+      WHEN $4::float8 > 0 AND extension_count < $5::int AND expires_at - now() <= make_interval(secs => $3::float8)
+        THEN extension_count + 1
+      ELSE extension_count
+    END
+WHERE id = $6 AND status = 'ACTIVE' AND expires_at > now()
+RETURNING EXTRACT(EPOCH FROM expires_at)::bigint, extension_count`
+
+	window := placement.SoftClose.Window.Seconds()
+	extension := placement.SoftClose.Extension.Seconds()
+	if window <= 0 {
+		extension = 0
+	}
+	err = tx.QueryRowContext(
+		ctx,
+		updateListing,
+		placement.BestBid,
+		placement.BestBidderClubID,
+		window,
+		extension,
+		placement.SoftClose.MaxExtensions,
+		placement.ListingID,
+		nullInt64(placement.BestMaxBid),
+	).Scan(&result.ExpiresAtUnix, &result.ExtensionCount)
+	if err == sql.ErrNoRows {
+		return BidResult{}, ErrListingNotActive
+	}
+	if err != nil {
+		return BidResult{}, err
 	}
 
 	if err := tx.Commit(); err != nil {
-		return "", err
+		return BidResult{}, err
 	}
-	return bidID, nil
+	return result, nil
 }
 
 // GetHoldIDForBid ritorna l'hold_id del bid specifico (se presente).
@@ -199,6 +648,397 @@ LIMIT 1`
 	return holdID.String, nil
 }
 
+// ListBidHoldIDs ritorna gli hold distinti dei bid del listing.
+func (r *Repo) ListBidHoldIDs(ctx context.Context, listingID string) ([]string, error) {
+	const query = `
+SELECT DISTINCT hold_id
+FROM bids
+WHERE listing_id = $1 AND hold_id IS NOT NULL`
+
+	rows, err := r.db.QueryContext(ctx, query, listingID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var holdIDs []string
+	for rows.Next() {
+		var holdID string
+		if err := rows.Scan(&holdID); err != nil {
+			return nil, err
+		}
+		holdIDs = append(holdIDs, holdID)
+	}
+	return holdIDs, rows.Err()
+}
+
+// HasBidWithHold indica se esiste un bid registrato con l'hold indicato.
+func (r *Repo) HasBidWithHold(ctx context.Context, holdID string) (bool, error) {
+	const query = `
+SELECT EXISTS (
+  SELECT 1
+  FROM bids
+  WHERE hold_id = $1
+)`
+
+	var exists bool
+	if err := r.db.QueryRowContext(ctx, query, holdID).Scan(&exists); err != nil {
+		return false, err
+	}
+	return exists, nil
+}
+
+// Bid mappa una riga di bids con lo stato del listing necessario a derivare l'esito.
+type Bid struct {
+	ID            string
+	ListingID     string
+	BidderClubID  string
+	Amount        int64
+	CreatedAtUnix int64
+	// Auto indica un rilancio automatico del proxy bidding.
+	Auto bool
+	// Stato del listing alla lettura.
+	ListingStatus           string
+	ListingBestBid          *int64
+	ListingBestBidderClubID *string
+}
+
+// ListBidsByListing ritorna una pagina dei bid del listing, dal piu' recente, e il totale.
+func (r *Repo) ListBidsByListing(ctx context.Context, listingID string, limit, offset int) ([]Bid, int64, error) {
+	return r.listBids(ctx, "b.listing_id = $1", listingID, limit, offset)
+}
+
+// ListBidsByBidder ritorna una pagina dei bid del club, dal piu' recente, e il totale.
+func (r *Repo) ListBidsByBidder(ctx context.Context, bidderClubID string, limit, offset int) ([]Bid, int64, error) {
+	return r.listBids(ctx, "b.bidder_club_id = $1", bidderClubID, limit, offset)
+}
+
+// listBids esegue conteggio e pagina per un filtro fisso (bids_listing_id_idx o bids_bidder_club_id_idx).
+func (r *Repo) listBids(ctx context.Context, where string, value string, limit, offset int) ([]Bid, int64, error) {
+	var total int64
+	countQuery := `
+SELECT COUNT(*)
+FROM bids b
+WHERE ` + where
+	if err := r.db.QueryRowContext(ctx, countQuery, value).Scan(&total); err != nil {
+		slog.Error("errore conteggio bid", "error", err)
+		return nil, 0, err
+	}
+	if total == 0 {
+		return nil, 0, nil
+	}
+
+	query := `
+SELECT
+  b.id,
+  b.listing_id,
+  b.bidder_club_id,
+  b.amount,
+  EXTRACT(EPOCH FROM b.created_at)::bigint,
+  b.auto_bid,
+  l.status,
+  l.best_bid,
+  l.best_bidder_club_id
+FROM bids b
+JOIN listings l ON l.id = b.listing_id
+WHERE ` + where + `
+ORDER BY b.created_at DESC, b.id DESC
+LIMIT $2 OFFSET $3`
+
+	rows, err := r.db.QueryContext(ctx, query, value, limit, offset)
+	if err != nil {
+		slog.Error("errore lettura bid", "error", err)
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var bids []Bid
+	for rows.Next() {
+		var bid Bid
+		var bestBid sql.NullInt64
+		var bestBidder sql.NullString
+		if err := rows.Scan(
+			&bid.ID,
+			&bid.ListingID,
+			&bid.BidderClubID,
+			&bid.Amount,
+			&bid.CreatedAtUnix,
+			&bid.Auto,
+			&bid.ListingStatus,
+			&bestBid,
+			&bestBidder,
+		); err != nil {
+			return nil, 0, err
+		}
+		bid.ListingBestBid = nullInt64Ptr(bestBid)
+		bid.ListingBestBidderClubID = nullStringPtr(bestBidder)
+		bids = append(bids, bid)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+	return bids, total, nil
+}
+
+// Trade e' una vendita completata registrata nella tabella trades.
+type Trade struct {
+	ListingID    string
+	SellerClubID string
+	BuyerClubID  string
+	PlayerID     string
+	// Price e' il lordo pagato dal buyer, Tax la quota trattenuta al seller.
+	Price int64
+	Tax   int64
+}
+
+// MarkListingSold chiude il listing come SOLD registrando buyer e prezzo finale
+// e scrive il trade nella stessa transazione.
+func (r *Repo) MarkListingSold(ctx context.Context, trade Trade) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	const updateListing = `
+UPDATE listings
+SET status = 'SOLD',
+    best_bid = $1,
+    best_bidder_club_id = $2
+WHERE id = $3 AND status = 'ACTIVE'`
+
+	res, err := tx.ExecContext(ctx, updateListing, trade.Price, trade.BuyerClubID, trade.ListingID)
+	if err != nil {
+		slog.Error("errore update listing sold", "error", err, "listing_id", trade.ListingID)
+		return err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrListingNotActive
+	}
+
+	const insertTrade = `
+INSERT INTO trades (
+  id,
+  listing_id,
+  seller_club_id,
+  buyer_club_id,
+  price,
+  tax,
+  player_id,
+  created_at
+) VALUES ($1,$2,$3,$4,$5,$6,$7,now())
+ON CONFLICT (listing_id) DO NOTHING`
+
+	if _, err := tx.ExecContext(ctx, insertTrade, uuid.NewString(), trade.ListingID, trade.SellerClubID, trade.BuyerClubID, trade.Price, trade.Tax, nullableText(trade.PlayerID)); err != nil {
+		slog.Error("errore insert trade", "error", err, "listing_id", trade.ListingID)
+		return err
+	}
+	return tx.Commit()
+}
+
+// PriceBucket aggrega i trade di un giocatore in un intervallo di tempo.
+type PriceBucket struct {
+	StartUnix int64
+	Open      int64
+	Close     int64
+	Min       int64
+	Median    int64
+	Max       int64
+	Volume    int64
+}
+
+// PriceHistory aggrega i trade del giocatore da since in bucket di ampiezza bucket.
+// I bucket senza trade non vengono restituiti.
+func (r *Repo) PriceHistory(ctx context.Context, playerID string, since time.Time, bucket time.Duration) ([]PriceBucket, error) {
+	const query = `
+SELECT
+  bucket_start,
+  (array_agg(price ORDER BY created_at, id))[1],
+  (array_agg(price ORDER BY created_at DESC, id DESC))[1],
+  MIN(price),
+  percentile_disc(0.5) WITHIN GROUP (ORDER BY price),
+  MAX(price),
+  COUNT(*)
+FROM (
+  SELECT
+    id,
+    price,
+    created_at,
+    (floor(EXTRACT(EPOCH FROM created_at) / $3::bigint) * $3::bigint)::bigint AS bucket_start
+  FROM trades
+  WHERE player_id = $1 AND created_at >= $2
+) t
+GROUP BY bucket_start
+ORDER BY bucket_start ASC`
+
+	rows, err := r.db.QueryContext(ctx, query, playerID, since, int64(bucket.Seconds()))
+	if err != nil {
+		slog.Error("errore lettura storico prezzi", "error", err, "player_id", playerID)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var buckets []PriceBucket
+	for rows.Next() {
+		var b PriceBucket
+		if err := rows.Scan(&b.StartUnix, &b.Open, &b.Close, &b.Min, &b.Median, &b.Max, &b.Volume); err != nil {
+			return nil, err
+		}
+		buckets = append(buckets, b)
+	}
+	return buckets, rows.Err()
+}
+
+// GetPriceBounds ritorna l'override admin del giocatore (ErrNotFound se assente).
+// Un lato a 0 significa nessun limite.
+func (r *Repo) GetPriceBounds(ctx context.Context, playerID string) (PriceBounds, error) {
+	const query = `
+SELECT min_price, max_price
+FROM player_price_bounds
+WHERE player_id = $1`
+
+	var minPrice, maxPrice sql.NullInt64
+	err := r.db.QueryRowContext(ctx, query, playerID).Scan(&minPrice, &maxPrice)
+	if err == sql.ErrNoRows {
+		return PriceBounds{}, ErrNotFound
+	}
+	if err != nil {
+		return PriceBounds{}, err
+	}
+	return PriceBounds{Min: minPrice.Int64, Max: maxPrice.Int64, Source: priceBoundsSourceAdmin}, nil
+}
+
+// UpsertPriceBounds salva l'override admin del giocatore.
+func (r *Repo) UpsertPriceBounds(ctx context.Context, playerID string, bounds PriceBounds) error {
+	const query = `
+INSERT INTO player_price_bounds (player_id, min_price, max_price, updated_at)
+VALUES ($1,$2,$3,now())
+ON CONFLICT (player_id) DO UPDATE
+SET min_price = EXCLUDED.min_price,
+    max_price = EXCLUDED.max_price,
+    updated_at = now()`
+
+	_, err := r.db.ExecContext(ctx, query, playerID, nullInt64(optionalPrice(bounds.Min)), nullInt64(optionalPrice(bounds.Max)))
+	return err
+}
+
+// DeletePriceBounds rimuove l'override admin; ritorna false se non esisteva.
+func (r *Repo) DeletePriceBounds(ctx context.Context, playerID string) (bool, error) {
+	res, err := r.db.ExecContext(ctx, `DELETE FROM player_price_bounds WHERE player_id = $1`, playerID)
+	if err != nil {
+		return false, err
+	}
+	affected, err := res.RowsAffected()
+	return affected > 0, err
+}
+
+// TradePriceStats ritorna mediana e numero dei trade del giocatore da since.
+func (r *Repo) TradePriceStats(ctx context.Context, playerID string, since time.Time) (int64, int64, error) {
+	const query = `
+SELECT
+  COALESCE(percentile_disc(0.5) WITHIN GROUP (ORDER BY price), 0),
+  COUNT(*)
+FROM trades
+WHERE player_id = $1 AND created_at >= $2`
+
+	var median, count int64
+	err := r.db.QueryRowContext(ctx, query, playerID, since).Scan(&median, &count)
+	return median, count, err
+}
+
+// CountActiveListingsBySeller conta i listing ACTIVE del seller (inclusi gli scaduti
+// non ancora chiusi dal worker, che tengono ancora la carta bloccata).
+func (r *Repo) CountActiveListingsBySeller(ctx context.Context, sellerClubID string) (int, error) {
+	const query = `
+SELECT COUNT(*)
+FROM listings
+WHERE seller_club_id = $1 AND status = 'ACTIVE'`
+
+	var count int
+	err := r.db.QueryRowContext(ctx, query, sellerClubID).Scan(&count)
+	return count, err
+}
+
+// CountWinningListingsByBidder conta i listing ACTIVE in cui il club e' best bidder.
+func (r *Repo) CountWinningListingsByBidder(ctx context.Context, bidderClubID string) (int, error) {
+	const query = `
+SELECT COUNT(*)
+FROM listings
+WHERE best_bidder_club_id = $1 AND status = 'ACTIVE'`
+
+	var count int
+	err := r.db.QueryRowContext(ctx, query, bidderClubID).Scan(&count)
+	return count, err
+}
+
+// ListExpiredListingIDs ritorna i listing ACTIVE con expires_at passato, dal piu' vecchio.
+func (r *Repo) ListExpiredListingIDs(ctx context.Context, limit int) ([]string, error) {
+	const query = `
+SELECT id
+FROM listings
+WHERE status = 'ACTIVE' AND expires_at <= now()
+ORDER BY expires_at ASC
+LIMIT $1`
+
+	rows, err := r.db.QueryContext(ctx, query, limit)
+	if err != nil {
+		slog.Error("errore query listing scaduti", "error", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// MarkListingExpired chiude il listing come EXPIRED se e' ancora ACTIVE.
+func (r *Repo) MarkListingExpired(ctx context.Context, listingID string) error {
+	return r.closeActiveListing(ctx, listingID, "EXPIRED")
+}
+
+// MarkListingCancelled chiude il listing come CANCELLED se e' ancora ACTIVE.
+func (r *Repo) MarkListingCancelled(ctx context.Context, listingID string) error {
+	return r.closeActiveListing(ctx, listingID, "CANCELLED")
+}
+
+// closeActiveListing cambia lo stato solo se il listing e' ancora ACTIVE.
+func (r *Repo) closeActiveListing(ctx context.Context, listingID, status string) error {
+	const query = `
+UPDATE listings
+SET status = $1
+WHERE id = $2 AND status = 'ACTIVE'`
+
+	res, err := r.db.ExecContext(ctx, query, status, listingID)
+	if err != nil {
+		slog.Error("errore update stato listing", "error", err, "listing_id", listingID, "status", status)
+		return err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrListingNotActive
+	}
+	return nil
+}
+
 // nullInt64 prepara valori numerici opzionali per SQL.
 func nullInt64(value *int64) sql.NullInt64 {
 	if value == nil {
@@ -215,6 +1055,14 @@ func nullString(value *string) sql.NullString {
 	return sql.NullString{String: *value, Valid: true}
 }
 
+// nullableText converte una stringa vuota in SQL NULL.
+func nullableText(value string) sql.NullString {
+	if value == "" {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: value, Valid: true}
+}
+
 func nullInt64Ptr(value sql.NullInt64) *int64 {
 	if !value.Valid {
 		return nil
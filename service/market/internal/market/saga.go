@@ -0,0 +1,440 @@
+package market
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	clubv1 "UltimateTeamX/proto/club/v1"
+	marketv1 "UltimateTeamX/proto/market/v1"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Tipi, stati e step delle saga cross-service del market.
+const (
+	sagaKindCreateListing = "CREATE_LISTING"
+	sagaKindPlaceBid      = "PLACE_BID"
+	sagaKindBuyNow        = "BUY_NOW"
+	sagaKindCancelListing = "CANCEL_LISTING"
+
+	sagaStatusStarted     = "STARTED"
+	sagaStatusCompleted   = "COMPLETED"
+	sagaStatusCompensated = "COMPENSATED"
+
+	sagaStepCardLock   = "CARD_LOCK"
+	sagaStepCreditHold = "CREDIT_HOLD"
+	sagaStepSettle     = "SETTLE"
+
+	// Rilasci da eseguire a flusso completato: non hanno compensazione.
+	sagaStepLockRelease = "LOCK_RELEASE"
+	sagaStepHoldRelease = "HOLD_RELEASE"
+
+	compensationReleaseCardLock   = "RELEASE_CARD_LOCK"
+	compensationReleaseCreditHold = "RELEASE_CREDIT_HOLD"
+	compensationNone              = "NONE"
+)
+
+// Saga descrive un flusso cross-service e gli step gia' eseguiti in club-svc.
+type Saga struct {
+	ID        string
+	Kind      string
+	ListingID string
+	UserID    string
+	Status    string
+	Steps     []SagaStep
+}
+
+// SagaStep e' un'azione eseguita in club-svc con la relativa compensazione.
+// Gli step di rilascio (LOCK_RELEASE, HOLD_RELEASE) sono Completed quando eseguiti.
+type SagaStep struct {
+	ID           string
+	Step         string
+	RefID        string
+	Compensation string
+	Compensated  bool
+	Completed    bool
+}
+
+// SagaLog persiste le saga nel market DB.
+type SagaLog interface {
+	StartSaga(ctx context.Context, saga Saga) error
+	AddSagaStep(ctx context.Context, sagaID string, step SagaStep) error
+	CompensateSagaStep(ctx context.Context, stepID string) error
+	CompleteSagaStep(ctx context.Context, stepID string) error
+	FinishSaga(ctx context.Context, sagaID, status string) error
+	ListStaleSagas(ctx context.Context, before time.Time, limit int) ([]Saga, error)
+}
+
+// WithSagaLog abilita la persistenza delle saga; senza, il server compensa solo in memoria.
+func WithSagaLog(log SagaLog) Option {
+	return func(s *Server) {
+		s.sagas = log
+	}
+}
+
+// noopSagaLog e' il default quando il saga log non e' configurato.
+type noopSagaLog struct{}
+
+func (noopSagaLog) StartSaga(context.Context, Saga) error               { return nil }
+func (noopSagaLog) AddSagaStep(context.Context, string, SagaStep) error { return nil }
+func (noopSagaLog) CompensateSagaStep(context.Context, string) error    { return nil }
+func (noopSagaLog) CompleteSagaStep(context.Context, string) error      { return nil }
+func (noopSagaLog) FinishSaga(context.Context, string, string) error    { return nil }
+func (noopSagaLog) ListStaleSagas(context.Context, time.Time, int) ([]Saga, error) {
+	return nil, nil
+}
+
+// stepRef ritorna lo step del tipo richiesto (nil se assente).
+func (saga *Saga) stepRef(step string) *SagaStep {
+	for i := range saga.Steps {
+		if saga.Steps[i].Step == step {
+			return &saga.Steps[i]
+		}
+	}
+	return nil
+}
+
+// startSaga registra l'inizio del flusso prima di qualunque chiamata a club-svc.
+func (s *Server) startSaga(ctx context.Context, kind, listingID, userID string) (*Saga, error) {
+	saga := &Saga{
+		ID:        uuid.NewString(),
+		Kind:      kind,
+		ListingID: listingID,
+		UserID:    userID,
+		Status:    sagaStatusStarted,
+	}
+	if err := s.sagas.StartSaga(ctx, *saga); err != nil {
+		s.logger.Error("errore avvio saga", "error", err, "kind", kind, "listing_id", listingID)
+		return nil, status.Error(codes.Internal, "failed to start saga")
+	}
+	return saga, nil
+}
+
+// recordSagaStep registra uno step prima della chiamata a club-svc.
+// Se la registrazione fallisce lo step non entra nella saga: club-svc non va chiamato.
+func (s *Server) recordSagaStep(ctx context.Context, saga *Saga, step, refID, compensation string) error {
+	sagaStep := SagaStep{
+		ID:           uuid.NewString(),
+		Step:         step,
+		RefID:        refID,
+		Compensation: compensation,
+	}
+	if err := s.sagas.AddSagaStep(ctx, saga.ID, sagaStep); err != nil {
+		s.logger.Error("errore registrazione step saga", "error", err, "saga_id", saga.ID, "step", step)
+		return err
+	}
+	saga.Steps = append(saga.Steps, sagaStep)
+	return nil
+}
+
+// recordReleaseSteps registra i rilasci da eseguire a flusso completato (hold superati,
+// lock carta e hold di un listing chiuso) prima della scrittura nel market DB: se la
+// scrittura avviene li esegue completeSaga (o il recovery), se la saga viene compensata
+// non servono piu'. In errore la saga e' gia' compensata.
+func (s *Server) recordReleaseSteps(ctx context.Context, saga *Saga, step string, refIDs ...string) error {
+	for _, refID := range refIDs {
+		if refID == "" {
+			continue
+		}
+		if err := s.recordSagaStep(ctx, saga, step, refID, compensationNone); err != nil {
+			_ = s.compensateSaga(ctx, saga)
+			return status.Error(codes.Internal, "failed to record saga step")
+		}
+	}
+	return nil
+}
+
+// beginSagaStep genera l'id del lock o dell'hold e registra lo step prima della chiamata
+// a club-svc, che usa lo stesso id: se la risposta si perde (crash, timeout) il recovery
+// conosce gia' cosa compensare. In errore la saga e' gia' compensata.
+func (s *Server) beginSagaStep(ctx context.Context, saga *Saga, step, compensation string) (string, error) {
+	refID := uuid.NewString()
+	if err := s.recordSagaStep(ctx, saga, step, refID, compensation); err != nil {
+		_ = s.compensateSaga(ctx, saga)
+		return "", status.Error(codes.Internal, "failed to record saga step")
+	}
+	return refID, nil
+}
+
+// failSagaStep compensa la saga dopo il fallimento della chiamata per l'ultimo step.
+// Un rifiuto esplicito di club-svc non ha creato nulla: lo step e' segnato compensato
+// senza chiamate. Su errori transitori il lock o l'hold potrebbe esistere e viene
+// rilasciato con gli altri step.
+func (s *Server) failSagaStep(ctx context.Context, saga *Saga, err error) {
+	if clubRejected(err) && len(saga.Steps) > 0 {
+		last := &saga.Steps[len(saga.Steps)-1]
+		last.Compensated = true
+		if err := s.sagas.CompensateSagaStep(ctx, last.ID); err != nil {
+			s.logger.Warn("errore aggiornamento step saga", "error", err, "saga_id", saga.ID, "step", last.Step)
+		}
+	}
+	_ = s.compensateSaga(ctx, saga)
+}
+
+// clubRejected indica un errore con cui club-svc ha rifiutato la richiesta senza effetti.
+func clubRejected(err error) bool {
+	switch status.Code(err) {
+	case codes.InvalidArgument, codes.NotFound, codes.AlreadyExists, codes.PermissionDenied,
+		codes.FailedPrecondition, codes.Unauthenticated:
+		return true
+	default:
+		return false
+	}
+}
+
+// markStepCompensated segna uno step come annullato senza azioni in club-svc.
+func (s *Server) markStepCompensated(ctx context.Context, saga *Saga, step string) {
+	sagaStep := saga.stepRef(step)
+	if sagaStep == nil {
+		return
+	}
+	sagaStep.Compensated = true
+	if err := s.sagas.CompensateSagaStep(ctx, sagaStep.ID); err != nil {
+		s.logger.Warn("errore aggiornamento step saga", "error", err, "saga_id", saga.ID, "step", step)
+	}
+}
+
+// finishSaga chiude la saga; in errore resta STARTED e la riprende il recovery loop.
+func (s *Server) finishSaga(ctx context.Context, saga *Saga, sagaStatus string) {
+	saga.Status = sagaStatus
+	if err := s.sagas.FinishSaga(ctx, saga.ID, sagaStatus); err != nil {
+		s.logger.Warn("errore chiusura saga", "error", err, "saga_id", saga.ID, "status", sagaStatus)
+	}
+}
+
+// completeSaga esegue i rilasci registrati e non ancora fatti, poi chiude la saga
+// COMPLETED. Se un rilascio fallisce la saga resta STARTED e il recovery loop lo
+// ripete: i rilasci sono idempotenti sull'id e NotFound vale come gia' rilasciato.
+func (s *Server) completeSaga(ctx context.Context, saga *Saga) error {
+	for i := range saga.Steps {
+		step := &saga.Steps[i]
+		if step.Completed || step.Compensated {
+			continue
+		}
+		var err error
+		switch step.Step {
+		case sagaStepLockRelease:
+			_, err = s.club.ReleaseCardLock(ctx, &clubv1.ReleaseCardLockRequest{LockId: step.RefID})
+		case sagaStepHoldRelease:
+			_, err = s.club.ReleaseCreditHold(ctx, &clubv1.ReleaseCreditHoldRequest{HoldId: step.RefID})
+		default:
+			continue
+		}
+		if err != nil && status.Code(err) != codes.NotFound {
+			s.logger.Warn("rilascio lasciato al recovery saga", "error", err, "saga_id", saga.ID, "step", step.Step, "ref_id", step.RefID)
+			return err
+		}
+		step.Completed = true
+		if err := s.sagas.CompleteSagaStep(ctx, step.ID); err != nil {
+			s.logger.Warn("errore aggiornamento step saga", "error", err, "saga_id", saga.ID, "step", step.Step)
+		}
+	}
+	s.finishSaga(ctx, saga, sagaStatusCompleted)
+	return nil
+}
+
+// compensateSaga annulla in ordine inverso gli step non ancora compensati.
+// Se una compensazione fallisce la saga resta STARTED per il recovery loop.
+// NotFound da club-svc vale come compensato: lo step era registrato ma il lock
+// o l'hold non e' mai stato creato.
+func (s *Server) compensateSaga(ctx context.Context, saga *Saga) error {
+	for i := len(saga.Steps) - 1; i >= 0; i-- {
+		step := &saga.Steps[i]
+		if step.Compensated || step.Completed {
+			continue
+		}
+		switch step.Compensation {
+		case compensationReleaseCardLock:
+			if _, err := s.club.ReleaseCardLock(ctx, &clubv1.ReleaseCardLockRequest{LockId: step.RefID}); err != nil && status.Code(err) != codes.NotFound {
+				s.logger.Warn("compensazione lock carta fallita", "error", err, "saga_id", saga.ID, "lock_id", step.RefID)
+				return err
+			}
+		case compensationReleaseCreditHold:
+			if _, err := s.club.ReleaseCreditHold(ctx, &clubv1.ReleaseCreditHoldRequest{HoldId: step.RefID}); err != nil && status.Code(err) != codes.NotFound {
+				s.logger.Warn("compensazione hold crediti fallita", "error", err, "saga_id", saga.ID, "hold_id", step.RefID)
+				return err
+			}
+		}
+		step.Compensated = true
+		if err := s.sagas.CompensateSagaStep(ctx, step.ID); err != nil {
+			s.logger.Warn("errore aggiornamento step saga", "error", err, "saga_id", saga.ID, "step", step.Step)
+		}
+	}
+	s.finishSaga(ctx, saga, sagaStatusCompensated)
+	return nil
+}
+
+// SagaRecoveryWorker riprende le saga rimaste STARTED oltre staleAfter (crash o errori
+// di compensazione) e le completa o le compensa in base allo stato del market DB.
+type SagaRecoveryWorker struct {
+	logger     *slog.Logger
+	server     *Server
+	interval   time.Duration
+	staleAfter time.Duration
+	batchSize  int
+}
+
+// NewSagaRecoveryWorker riusa saga log, repo, club client e lock del server gRPC.
+func NewSagaRecoveryWorker(logger *slog.Logger, server *Server, interval, staleAfter time.Duration, batchSize int) *SagaRecoveryWorker {
+	return &SagaRecoveryWorker{
+		logger:     logger,
+		server:     server,
+		interval:   interval,
+		staleAfter: staleAfter,
+		batchSize:  batchSize,
+	}
+}
+
+// Run esegue un giro ad ogni tick finche' il context non viene chiuso.
+func (w *SagaRecoveryWorker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	w.logger.Info("saga recovery avviato", "interval", w.interval, "stale_after", w.staleAfter)
+	for {
+		if _, err := w.RunOnce(ctx); err != nil && ctx.Err() == nil {
+			w.logger.Error("errore giro saga recovery", "error", err)
+		}
+		select {
+		case <-ctx.Done():
+			w.logger.Info("saga recovery fermato")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce processa un batch di saga bloccate e ritorna quante ne ha chiuse.
+func (w *SagaRecoveryWorker) RunOnce(ctx context.Context) (int, error) {
+	sagas, err := w.server.sagas.ListStaleSagas(ctx, time.Now().Add(-w.staleAfter), w.batchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	recovered := 0
+	for i := range sagas {
+		if ctx.Err() != nil {
+			return recovered, ctx.Err()
+		}
+		done, err := w.server.recoverSaga(ctx, &sagas[i])
+		if err != nil {
+			w.logger.Warn("recovery saga fallito", "error", err, "saga_id", sagas[i].ID, "kind", sagas[i].Kind)
+			continue
+		}
+		if done {
+			recovered++
+		}
+	}
+	return recovered, nil
+}
+
+// recoverSaga decide sotto lock del listing se completare o compensare la saga.
+func (s *Server) recoverSaga(ctx context.Context, saga *Saga) (bool, error) {
+	if s.locker == nil {
+		return false, errors.New("redis lock not configured")
+	}
+	unlock, err := s.acquireListingLock(ctx, saga.ListingID)
+	if err != nil {
+		if status.Code(err) == codes.FailedPrecondition {
+			return false, nil
+		}
+		return false, err
+	}
+	defer unlock()
+
+	switch saga.Kind {
+	case sagaKindCreateListing:
+		// Il listing inserito e' la prova che il flusso e' arrivato in fondo.
+		_, err := s.repo.GetListing(ctx, saga.ListingID)
+		if err == nil {
+			s.finishSaga(ctx, saga, sagaStatusCompleted)
+			return true, nil
+		}
+		if !errors.Is(err, ErrNotFound) {
+			return false, err
+		}
+		return true, s.compensateSaga(ctx, saga)
+
+	case sagaKindPlaceBid:
+		// Il bid con l'hold registrato e' la prova che il flusso e' arrivato in fondo:
+		// restano da eseguire i rilasci degli hold superati.
+		if hold := saga.stepRef(sagaStepCreditHold); hold != nil {
+			exists, err := s.repo.HasBidWithHold(ctx, hold.RefID)
+			if err != nil {
+				return false, err
+			}
+			if exists {
+				return true, s.completeSaga(ctx, saga)
+			}
+		}
+		return true, s.compensateSaga(ctx, saga)
+
+	case sagaKindCancelListing:
+		// Il listing CANCELLED e' la prova che il ritiro e' avvenuto: restano i rilasci.
+		listing, err := s.repo.GetListing(ctx, saga.ListingID)
+		if err != nil {
+			return false, err
+		}
+		if listing.Status == listingStatusCancelled {
+			return true, s.completeSaga(ctx, saga)
+		}
+		return true, s.compensateSaga(ctx, saga)
+
+	case sagaKindBuyNow:
+		return s.recoverBuyNow(ctx, saga)
+
+	default:
+		return false, errors.New("unknown saga kind: " + saga.Kind)
+	}
+}
+
+// recoverBuyNow ripete il settlement (idempotente sul trade_id) se era stato avviato,
+// altrimenti rilascia l'hold del buyer. A vendita avvenuta esegue i rilasci in sospeso.
+func (s *Server) recoverBuyNow(ctx context.Context, saga *Saga) (bool, error) {
+	settle := saga.stepRef(sagaStepSettle)
+	hold := saga.stepRef(sagaStepCreditHold)
+	if settle == nil || settle.Compensated || hold == nil {
+		return true, s.compensateSaga(ctx, saga)
+	}
+
+	listing, err := s.repo.GetListing(ctx, saga.ListingID)
+	if err != nil {
+		return false, err
+	}
+	buyerClubID, err := s.clubIDForUser(ctx, saga.UserID)
+	if err != nil {
+		return false, err
+	}
+
+	switch {
+	case listing.Status == listingStatusSold && listing.BestBidderClubID != nil && *listing.BestBidderClubID == buyerClubID:
+		return true, s.completeSaga(ctx, saga)
+	case listing.Status != listingStatusActive || listing.BuyNowPrice == nil:
+		s.markStepCompensated(ctx, saga, sagaStepSettle)
+		return true, s.compensateSaga(ctx, saga)
+	}
+
+	sellerUserID, err := s.userIDForClub(ctx, listing.SellerClubID)
+	if err != nil {
+		return false, err
+	}
+	price := *listing.BuyNowPrice
+	settleReq := s.tradeSettlement(listing, sellerUserID, saga.UserID, hold.RefID, price)
+	if _, err := s.club.SettleTrade(ctx, settleReq); err != nil {
+		if status.Code(err) == codes.FailedPrecondition {
+			s.markStepCompensated(ctx, saga, sagaStepSettle)
+			return true, s.compensateSaga(ctx, saga)
+		}
+		return false, err
+	}
+
+	if err := s.repo.MarkListingSold(ctx, tradeRecord(settleReq, listing, buyerClubID)); err != nil && !errors.Is(err, ErrListingNotActive) {
+		return false, err
+	}
+	s.publishEvent(ctx, listing, marketv1.ListingEventType_LISTING_EVENT_TYPE_SOLD, price, saga.UserID)
+	s.logger.Info("saga buy now completata dal recovery", "saga_id", saga.ID, "listing_id", listing.ID)
+	return true, s.completeSaga(ctx, saga)
+}
@@ -0,0 +1,167 @@
+package market
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// StartSaga inserisce una saga in stato STARTED.
+func (r *Repo) StartSaga(ctx context.Context, saga Saga) error {
+	const query = `
+INSERT INTO sagas (
+  id,
+  kind,
+  listing_id,
+  user_id,
+  status,
+  created_at,
+  updated_at
+) VALUES ($1,$2,$3,$4,'STARTED',now(),now())`
+
+	_, err := r.db.ExecContext(ctx, query, saga.ID, saga.Kind, saga.ListingID, saga.UserID)
+	if err != nil {
+		slog.Error("errore insert saga", "error", err, "saga_id", saga.ID)
+	}
+	return err
+}
+
+// AddSagaStep registra uno step eseguito in club-svc e aggiorna updated_at della saga.
+func (r *Repo) AddSagaStep(ctx context.Context, sagaID string, step SagaStep) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	const insertStep = `
+INSERT INTO saga_steps (
+  id,
+  saga_id,
+  step,
+  ref_id,
+  compensation,
+  created_at
+) VALUES ($1,$2,$3,$4,$5,now())`
+	if _, err := tx.ExecContext(ctx, insertStep, step.ID, sagaID, step.Step, step.RefID, step.Compensation); err != nil {
+		slog.Error("errore insert step saga", "error", err, "saga_id", sagaID, "step", step.Step)
+		return err
+	}
+
+	const touchSaga = `
+UPDATE sagas
+SET updated_at = now()
+WHERE id = $1`
+	if _, err := tx.ExecContext(ctx, touchSaga, sagaID); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// CompensateSagaStep segna lo step come compensato (idempotente).
+func (r *Repo) CompensateSagaStep(ctx context.Context, stepID string) error {
+	const query = `
+UPDATE saga_steps
+SET compensated_at = now()
+WHERE id = $1 AND compensated_at IS NULL`
+
+	_, err := r.db.ExecContext(ctx, query, stepID)
+	return err
+}
+
+// CompleteSagaStep segna lo step di rilascio come eseguito (idempotente).
+func (r *Repo) CompleteSagaStep(ctx context.Context, stepID string) error {
+	const query = `
+UPDATE saga_steps
+SET completed_at = now()
+WHERE id = $1 AND completed_at IS NULL`
+
+	_, err := r.db.ExecContext(ctx, query, stepID)
+	return err
+}
+
+// FinishSaga porta la saga da STARTED allo stato finale.
+func (r *Repo) FinishSaga(ctx context.Context, sagaID, status string) error {
+	const query = `
+UPDATE sagas
+SET status = $1,
+    updated_at = now()
+WHERE id = $2 AND status = 'STARTED'`
+
+	_, err := r.db.ExecContext(ctx, query, status, sagaID)
+	if err != nil {
+		slog.Error("errore chiusura saga", "error", err, "saga_id", sagaID, "status", status)
+	}
+	return err
+}
+
+// ListStaleSagas ritorna le saga STARTED non aggiornate da prima di before, con i loro step.
+func (r *Repo) ListStaleSagas(ctx context.Context, before time.Time, limit int) ([]Saga, error) {
+	const query = `
+SELECT id, kind, listing_id, user_id, status
+FROM sagas
+WHERE status = 'STARTED' AND updated_at <= $1
+ORDER BY updated_at ASC
+LIMIT $2`
+
+	rows, err := r.db.QueryContext(ctx, query, before, limit)
+	if err != nil {
+		slog.Error("errore query saga bloccate", "error", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var sagas []Saga
+	index := make(map[string]int)
+	for rows.Next() {
+		var saga Saga
+		if err := rows.Scan(&saga.ID, &saga.Kind, &saga.ListingID, &saga.UserID, &saga.Status); err != nil {
+			return nil, err
+		}
+		index[saga.ID] = len(sagas)
+		sagas = append(sagas, saga)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(sagas) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]string, 0, len(sagas))
+	for _, saga := range sagas {
+		ids = append(ids, saga.ID)
+	}
+
+	const stepsQuery = `
+SELECT id, saga_id, step, ref_id, compensation, compensated_at IS NOT NULL, completed_at IS NOT NULL
+FROM saga_steps
+WHERE saga_id = ANY($1)
+ORDER BY created_at ASC`
+
+	stepRows, err := r.db.QueryContext(ctx, stepsQuery, pq.Array(ids))
+	if err != nil {
+		slog.Error("errore query step saga", "error", err)
+		return nil, err
+	}
+	defer stepRows.Close()
+
+	for stepRows.Next() {
+		var step SagaStep
+		var sagaID string
+		if err := stepRows.Scan(&step.ID, &sagaID, &step.Step, &step.RefID, &step.Compensation, &step.Compensated, &step.Completed); err != nil {
+			return nil, err
+		}
+		i := index[sagaID]
+		sagas[i].Steps = append(sagas[i].Steps, step)
+	}
+	if err := stepRows.Err(); err != nil {
+		return nil, err
+	}
+	return sagas, nil
+}
@@ -0,0 +1,490 @@
+package market
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	clubv1 "UltimateTeamX/proto/club/v1"
+	marketv1 "UltimateTeamX/proto/market/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Test suite per saga log e recovery loop.
+
+// fakeSagaLog tiene le saga in memoria.
+type fakeSagaLog struct {
+	sagas       map[string]*Saga
+	compensated map[string]bool
+	completed   map[string]bool
+	stale       []Saga
+}
+
+func newFakeSagaLog() *fakeSagaLog {
+	return &fakeSagaLog{sagas: map[string]*Saga{}, compensated: map[string]bool{}, completed: map[string]bool{}}
+}
+
+func (l *fakeSagaLog) StartSaga(_ context.Context, saga Saga) error {
+	l.sagas[saga.ID] = &saga
+	return nil
+}
+
+func (l *fakeSagaLog) AddSagaStep(_ context.Context, sagaID string, step SagaStep) error {
+	l.sagas[sagaID].Steps = append(l.sagas[sagaID].Steps, step)
+	return nil
+}
+
+func (l *fakeSagaLog) CompensateSagaStep(_ context.Context, stepID string) error {
+	l.compensated[stepID] = true
+	return nil
+}
+
+func (l *fakeSagaLog) CompleteSagaStep(_ context.Context, stepID string) error {
+	l.completed[stepID] = true
+	return nil
+}
+
+func (l *fakeSagaLog) FinishSaga(_ context.Context, sagaID, status string) error {
+	if saga, ok := l.sagas[sagaID]; ok {
+		saga.Status = status
+	}
+	return nil
+}
+
+func (l *fakeSagaLog) ListStaleSagas(_ context.Context, _ time.Time, _ int) ([]Saga, error) {
+	return l.stale, nil
+}
+
+// only ritorna l'unica saga registrata.
+func (l *fakeSagaLog) only(t *testing.T) *Saga {
+	t.Helper()
+	if len(l.sagas) != 1 {
+		t.Fatalf("expected 1 saga, got %d", len(l.sagas))
+	}
+	for _, saga := range l.sagas {
+		return saga
+	}
+	return nil
+}
+
+func TestCreateListingSagaCompleted(t *testing.T) {
+	sagas := newFakeSagaLog()
+	club := &fakeClub{}
+	server := NewServer(slog.Default(), &fakeRepo{}, club, nil, WithSagaLog(sagas))
+
+	_, err := server.CreateListing(context.Background(), &marketv1.CreateListingRequest{
+		SellerUserId:  "11111111-1111-1111-1111-111111111111",
+		UserCardId:    "22222222-2222-2222-2222-222222222222",
+		StartPrice:    1000,
+		ExpiresAtUnix: time.Now().Add(time.Hour).Unix(),
+	})
+	if err != nil {
+		t.Fatalf("expected success, got error: %v", err)
+	}
+	saga := sagas.only(t)
+	if saga.Kind != sagaKindCreateListing || saga.Status != sagaStatusCompleted {
+		t.Fatalf("unexpected saga: %+v", saga)
+	}
+	if len(saga.Steps) != 1 || saga.Steps[0].Step != sagaStepCardLock || saga.Steps[0].RefID != club.lockIDs[0] {
+		t.Fatalf("expected CARD_LOCK step with the LockCard lock_id, got %+v", saga.Steps)
+	}
+}
+
+func TestCreateListingSagaCompensatedOnInsertError(t *testing.T) {
+	sagas := newFakeSagaLog()
+	club := &fakeClub{}
+	server := NewServer(slog.Default(), &fakeRepo{createErr: errors.New("db down")}, club, nil, WithSagaLog(sagas))
+
+	_, err := server.CreateListing(context.Background(), &marketv1.CreateListingRequest{
+		SellerUserId:  "11111111-1111-1111-1111-111111111111",
+		UserCardId:    "22222222-2222-2222-2222-222222222222",
+		StartPrice:    1000,
+		ExpiresAtUnix: time.Now().Add(time.Hour).Unix(),
+	})
+	if status.Code(err) != codes.Internal {
+		t.Fatalf("expected Internal, got %v", err)
+	}
+	saga := sagas.only(t)
+	if saga.Status != sagaStatusCompensated {
+		t.Fatalf("expected COMPENSATED, got %s", saga.Status)
+	}
+	if !sagas.compensated[saga.Steps[0].ID] || club.releaseLastLockID != club.lockIDs[0] {
+		t.Fatalf("expected card lock step to be compensated")
+	}
+}
+
+// Caso: LockCard senza risposta (errore transitorio). Lo step era gia' registrato con
+// il lock_id passato a club-svc, quindi il lock eventualmente creato viene rilasciato.
+func TestCreateListingSagaStepRecordedBeforeLockCard(t *testing.T) {
+	sagas := newFakeSagaLog()
+	club := &fakeClub{lockErr: status.Error(codes.Unavailable, "club down")}
+	server := NewServer(slog.Default(), &fakeRepo{}, club, nil, WithSagaLog(sagas))
+
+	req := &marketv1.CreateListingRequest{
+		SellerUserId:  "11111111-1111-1111-1111-111111111111",
+		UserCardId:    "22222222-2222-2222-2222-222222222222",
+		StartPrice:    1000,
+		ExpiresAtUnix: time.Now().Add(time.Hour).Unix(),
+	}
+	if _, err := server.CreateListing(context.Background(), req); status.Code(err) != codes.Unavailable {
+		t.Fatalf("expected Unavailable, got %v", err)
+	}
+	saga := sagas.only(t)
+	if len(saga.Steps) != 1 || saga.Steps[0].RefID == "" || saga.Steps[0].RefID != club.lockIDs[0] {
+		t.Fatalf("expected CARD_LOCK step with the requested lock_id, got %+v (requested %v)", saga.Steps, club.lockIDs)
+	}
+	if saga.Status != sagaStatusCompensated || club.releaseCalls != 1 || club.releaseLastLockID != club.lockIDs[0] {
+		t.Fatalf("expected requested lock to be released, got %s with %d calls", saga.Status, club.releaseCalls)
+	}
+
+	// Un rifiuto esplicito non ha creato il lock: nessun rilascio.
+	sagas = newFakeSagaLog()
+	club = &fakeClub{lockErr: status.Error(codes.FailedPrecondition, "card locked")}
+	server = NewServer(slog.Default(), &fakeRepo{}, club, nil, WithSagaLog(sagas))
+	if _, err := server.CreateListing(context.Background(), req); status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("expected FailedPrecondition, got %v", err)
+	}
+	if saga := sagas.only(t); saga.Status != sagaStatusCompensated || !sagas.compensated[saga.Steps[0].ID] || club.releaseCalls != 0 {
+		t.Fatalf("expected step compensated without release, got %s with %d calls", saga.Status, club.releaseCalls)
+	}
+}
+
+func TestSagaRecoveryCompensatesMissingListing(t *testing.T) {
+	sagas := newFakeSagaLog()
+	saga := Saga{
+		ID:        "saga-1",
+		Kind:      sagaKindCreateListing,
+		ListingID: "listing-1",
+		Status:    sagaStatusStarted,
+		Steps: []SagaStep{
+			{ID: "step-1", Step: sagaStepCardLock, RefID: "lock-9", Compensation: compensationReleaseCardLock},
+		},
+	}
+	sagas.sagas[saga.ID] = &saga
+	sagas.stale = []Saga{saga}
+	club := &fakeClub{}
+	server := NewServer(slog.Default(), &fakeRepo{getListingErr: ErrNotFound}, club, &fakeLock{token: "token", ok: true}, WithSagaLog(sagas))
+
+	recovered, err := NewSagaRecoveryWorker(slog.Default(), server, time.Second, time.Minute, 10).RunOnce(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if recovered != 1 {
+		t.Fatalf("expected 1 recovered saga, got %d", recovered)
+	}
+	if club.releaseCalls != 1 || club.releaseLastLockID != "lock-9" {
+		t.Fatalf("expected ReleaseCardLock with lock-9")
+	}
+	if sagas.sagas["saga-1"].Status != sagaStatusCompensated {
+		t.Fatalf("expected COMPENSATED, got %s", sagas.sagas["saga-1"].Status)
+	}
+}
+
+func TestSagaRecoveryCompletesPlacedBid(t *testing.T) {
+	sagas := newFakeSagaLog()
+	saga := Saga{
+		ID:        "saga-1",
+		Kind:      sagaKindPlaceBid,
+		ListingID: "listing-1",
+		Status:    sagaStatusStarted,
+		Steps: []SagaStep{
+			{ID: "step-1", Step: sagaStepCreditHold, RefID: "hold-1", Compensation: compensationReleaseCreditHold},
+		},
+	}
+	sagas.sagas[saga.ID] = &saga
+	sagas.stale = []Saga{saga}
+	club := &fakeClub{}
+	repo := &fakeRepo{bidHolds: map[string]bool{"hold-1": true}}
+	server := NewServer(slog.Default(), repo, club, &fakeLock{token: "token", ok: true}, WithSagaLog(sagas))
+
+	if _, err := NewSagaRecoveryWorker(slog.Default(), server, time.Second, time.Minute, 10).RunOnce(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if club.releaseHoldCalls != 0 {
+		t.Fatalf("did not expect hold release for a persisted bid")
+	}
+	if sagas.sagas["saga-1"].Status != sagaStatusCompleted {
+		t.Fatalf("expected COMPLETED, got %s", sagas.sagas["saga-1"].Status)
+	}
+}
+
+func TestSagaRecoveryReplaysBuyNowSettle(t *testing.T) {
+	buyNow := int64(2000)
+	sagas := newFakeSagaLog()
+	saga := Saga{
+		ID:        "saga-1",
+		Kind:      sagaKindBuyNow,
+		ListingID: "listing-1",
+		UserID:    "22222222-2222-2222-2222-222222222222",
+		Status:    sagaStatusStarted,
+		Steps: []SagaStep{
+			{ID: "step-1", Step: sagaStepCreditHold, RefID: "hold-buy", Compensation: compensationReleaseCreditHold},
+			{ID: "step-2", Step: sagaStepSettle, RefID: "listing-1", Compensation: compensationNone},
+		},
+	}
+	sagas.sagas[saga.ID] = &saga
+	sagas.stale = []Saga{saga}
+	repo := &fakeRepo{
+		listing: Listing{
+			ID:           "listing-1",
+			SellerClubID: "club-seller",
+			UserCardID:   "card-1",
+			Status:       listingStatusActive,
+			BuyNowPrice:  &buyNow,
+			LockID:       "lock-1",
+		},
+	}
+	club := &fakeClub{getMyClubResp: &clubv1.GetMyClubResponse{ClubId: "club-buyer"}}
+	server := NewServer(slog.Default(), repo, club, &fakeLock{token: "token", ok: true}, WithSagaLog(sagas))
+
+	if _, err := NewSagaRecoveryWorker(slog.Default(), server, time.Second, time.Minute, 10).RunOnce(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if club.settleCalls != 1 || club.lastSettle.TradeId != "listing-1" || club.lastSettle.HoldId != "hold-buy" {
+		t.Fatalf("expected SettleTrade replay, got %+v", club.lastSettle)
+	}
+	if repo.soldCalls != 1 || repo.lastSold.buyerClubID != "club-buyer" {
+		t.Fatalf("expected listing to be marked sold")
+	}
+	if sagas.sagas["saga-1"].Status != sagaStatusCompleted {
+		t.Fatalf("expected COMPLETED, got %s", sagas.sagas["saga-1"].Status)
+	}
+}
+
+// Caso: il rilascio dell'hold del best bidder superato fallisce dopo l'insert del bid.
+// Lo step HOLD_RELEASE e' gia' registrato: la saga resta STARTED e il recovery lo ripete.
+func TestPlaceBidSagaLeavesLeaderHoldReleaseToRecovery(t *testing.T) {
+	prevBid := int64(1200)
+	prevBidder := "prev-bidder"
+	sagas := newFakeSagaLog()
+	repo := &fakeRepo{
+		listing: Listing{
+			ID:               "listing-1",
+			Status:           listingStatusActive,
+			StartPrice:       1000,
+			ExpiresAtUnix:    time.Now().Add(time.Hour).Unix(),
+			BestBid:          &prevBid,
+			BestBidderClubID: &prevBidder,
+		},
+		holdIDForBid: "hold-prev",
+	}
+	club := &fakeClub{
+		getMyClubResp:  &clubv1.GetMyClubResponse{ClubId: "club-bidder"},
+		releaseHoldErr: status.Error(codes.Unavailable, "club down"),
+	}
+	server := NewServer(slog.Default(), repo, club, &fakeLock{token: "token", ok: true}, WithSagaLog(sagas))
+
+	if _, err := server.PlaceBid(context.Background(), &marketv1.PlaceBidRequest{
+		ListingId:    "11111111-1111-1111-1111-111111111111",
+		BidderUserId: "22222222-2222-2222-2222-222222222222",
+		BidAmount:    1500,
+	}); err != nil {
+		t.Fatalf("expected success, got error: %v", err)
+	}
+	saga := sagas.only(t)
+	release := saga.stepRef(sagaStepHoldRelease)
+	if release == nil || release.RefID != "hold-prev" {
+		t.Fatalf("expected HOLD_RELEASE step for hold-prev, got %+v", saga.Steps)
+	}
+	if saga.Status != sagaStatusStarted {
+		t.Fatalf("expected saga to stay STARTED, got %s", saga.Status)
+	}
+
+	// Il recovery trova il bid e completa il rilascio invece di chiudere la saga.
+	club.releaseHoldErr = nil
+	repo.bidHolds = map[string]bool{club.holdIDs[0]: true}
+	sagas.stale = []Saga{*saga}
+	if _, err := NewSagaRecoveryWorker(slog.Default(), server, time.Second, time.Minute, 10).RunOnce(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(club.releasedHoldIDs) != 1 || club.releasedHoldIDs[0] != "hold-prev" {
+		t.Fatalf("expected recovery to release hold-prev, got %v", club.releasedHoldIDs)
+	}
+	if !sagas.completed[release.ID] || saga.Status != sagaStatusCompleted {
+		t.Fatalf("expected release step completed and saga COMPLETED, got %s", saga.Status)
+	}
+}
+
+// Caso: l'insert del bid fallisce. Il rilascio registrato non serve piu': l'hold del
+// best bidder resta e viene rilasciato solo quello nuovo.
+func TestPlaceBidSagaSkipsLeaderHoldReleaseOnInsertError(t *testing.T) {
+	prevBid := int64(1200)
+	prevBidder := "prev-bidder"
+	sagas := newFakeSagaLog()
+	repo := &fakeRepo{
+		listing: Listing{
+			ID:               "listing-1",
+			Status:           listingStatusActive,
+			StartPrice:       1000,
+			ExpiresAtUnix:    time.Now().Add(time.Hour).Unix(),
+			BestBid:          &prevBid,
+			BestBidderClubID: &prevBidder,
+		},
+		holdIDForBid: "hold-prev",
+		insertErr:    ErrListingNotActive,
+	}
+	club := &fakeClub{getMyClubResp: &clubv1.GetMyClubResponse{ClubId: "club-bidder"}}
+	server := NewServer(slog.Default(), repo, club, &fakeLock{token: "token", ok: true}, WithSagaLog(sagas))
+
+	if _, err := server.PlaceBid(context.Background(), &marketv1.PlaceBidRequest{
+		ListingId:    "11111111-1111-1111-1111-111111111111",
+		BidderUserId: "22222222-2222-2222-2222-222222222222",
+		BidAmount:    1500,
+	}); status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("expected FailedPrecondition, got %v", err)
+	}
+	saga := sagas.only(t)
+	if saga.Status != sagaStatusCompensated {
+		t.Fatalf("expected COMPENSATED, got %s", saga.Status)
+	}
+	if len(club.releasedHoldIDs) != 1 || club.releasedHoldIDs[0] != club.holdIDs[0] {
+		t.Fatalf("expected only the new hold to be released, got %v", club.releasedHoldIDs)
+	}
+}
+
+func TestSagaRecoveryFinishesCancelledListingReleases(t *testing.T) {
+	sagas := newFakeSagaLog()
+	saga := Saga{
+		ID:        "saga-1",
+		Kind:      sagaKindCancelListing,
+		ListingID: "listing-1",
+		Status:    sagaStatusStarted,
+		Steps: []SagaStep{
+			{ID: "step-1", Step: sagaStepLockRelease, RefID: "lock-1", Compensation: compensationNone, Completed: true},
+			{ID: "step-2", Step: sagaStepHoldRelease, RefID: "hold-best", Compensation: compensationNone},
+		},
+	}
+	sagas.sagas[saga.ID] = &saga
+	sagas.stale = []Saga{saga}
+	repo := &fakeRepo{listing: Listing{ID: "listing-1", Status: listingStatusCancelled}}
+	club := &fakeClub{}
+	server := NewServer(slog.Default(), repo, club, &fakeLock{token: "token", ok: true}, WithSagaLog(sagas))
+
+	if _, err := NewSagaRecoveryWorker(slog.Default(), server, time.Second, time.Minute, 10).RunOnce(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if club.releaseCalls != 0 {
+		t.Fatalf("did not expect the completed card lock release to be repeated")
+	}
+	if len(club.releasedHoldIDs) != 1 || club.releasedHoldIDs[0] != "hold-best" || !sagas.completed["step-2"] {
+		t.Fatalf("expected pending hold release, got %v", club.releasedHoldIDs)
+	}
+	if sagas.sagas["saga-1"].Status != sagaStatusCompleted {
+		t.Fatalf("expected COMPLETED, got %s", sagas.sagas["saga-1"].Status)
+	}
+
+	// Listing ancora ACTIVE: il ritiro non e' avvenuto e i rilasci non servono.
+	saga.Status = sagaStatusStarted
+	saga.Steps[0].Completed = false
+	sagas.stale = []Saga{saga}
+	repo.listing.Status = listingStatusActive
+	club = &fakeClub{}
+	server = NewServer(slog.Default(), repo, club, &fakeLock{token: "token", ok: true}, WithSagaLog(sagas))
+	if _, err := NewSagaRecoveryWorker(slog.Default(), server, time.Second, time.Minute, 10).RunOnce(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if club.releaseCalls != 0 || club.releaseHoldCalls != 0 {
+		t.Fatalf("did not expect releases for an active listing")
+	}
+	if sagas.sagas["saga-1"].Status != sagaStatusCompensated {
+		t.Fatalf("expected COMPENSATED, got %s", sagas.sagas["saga-1"].Status)
+	}
+}
+
+func TestCancelListingSagaRecordsReleasesBeforeUpdate(t *testing.T) {
+	bestBid := int64(2000)
+	bestBidder := "club-bidder"
+	sagas := newFakeSagaLog()
+	repo := &fakeRepo{
+		listing: Listing{
+			ID:               "listing-1",
+			SellerClubID:     "club-seller",
+			Status:           listingStatusActive,
+			ExpiresAtUnix:    time.Now().Add(time.Hour).Unix(),
+			BestBid:          &bestBid,
+			BestBidderClubID: &bestBidder,
+			LockID:           "lock-1",
+		},
+		holdIDForBid: "hold-best",
+		cancelledErr: errors.New("db down"),
+	}
+	club := &fakeClub{getMyClubResp: &clubv1.GetMyClubResponse{ClubId: "club-seller"}}
+	server := NewServer(slog.Default(), repo, club, &fakeLock{token: "token", ok: true}, WithCancelPenaltyBps(500), WithSagaLog(sagas))
+
+	if _, err := server.CancelListing(context.Background(), cancelRequest()); status.Code(err) != codes.Internal {
+		t.Fatalf("expected Internal, got %v", err)
+	}
+	saga := sagas.only(t)
+	if saga.Kind != sagaKindCancelListing || len(saga.Steps) != 2 ||
+		saga.Steps[0].Step != sagaStepLockRelease || saga.Steps[0].RefID != "lock-1" ||
+		saga.Steps[1].Step != sagaStepHoldRelease || saga.Steps[1].RefID != "hold-best" {
+		t.Fatalf("expected LOCK_RELEASE and HOLD_RELEASE steps, got %+v", saga.Steps)
+	}
+	if saga.Status != sagaStatusCompensated || club.releaseCalls != 0 || club.releaseHoldCalls != 0 {
+		t.Fatalf("expected no releases for a listing still active, got %s", saga.Status)
+	}
+
+	// Update riuscito: i rilasci vengono eseguiti e la saga chiusa.
+	sagas = newFakeSagaLog()
+	repo.cancelledErr = nil
+	server = NewServer(slog.Default(), repo, club, &fakeLock{token: "token", ok: true}, WithCancelPenaltyBps(500), WithSagaLog(sagas))
+	if _, err := server.CancelListing(context.Background(), cancelRequest()); err != nil {
+		t.Fatalf("expected success, got error: %v", err)
+	}
+	saga = sagas.only(t)
+	if saga.Status != sagaStatusCompleted || club.releaseLastLockID != "lock-1" || club.releaseHoldID != "hold-best" {
+		t.Fatalf("expected releases and COMPLETED saga, got %s", saga.Status)
+	}
+}
+
+// Caso: il proxy del best bidder supera il richiedente. L'hold del richiedente resta sul
+// bid perdente e il suo rilascio e' uno step della saga, ripetuto dal recovery.
+func TestPlaceBidSagaReleasesChallengerHoldOutbidByProxy(t *testing.T) {
+	sagas := newFakeSagaLog()
+	repo := &fakeRepo{
+		listing:      proxyListing(1000, 3000, "club-leader"),
+		holdIDForBid: "hold-leader",
+	}
+	club := &fakeClub{
+		clubOwners:     map[string]string{"club-leader": "44444444-4444-4444-4444-444444444444"},
+		releaseHoldErr: status.Error(codes.Unavailable, "club down"),
+	}
+	server := NewServer(slog.Default(), repo, club, &fakeLock{token: "token", ok: true}, WithSagaLog(sagas))
+
+	resp, err := server.PlaceBid(context.Background(), &marketv1.PlaceBidRequest{
+		ListingId:    "11111111-1111-1111-1111-111111111111",
+		BidderUserId: "22222222-2222-2222-2222-222222222222",
+		BidAmount:    2000,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.Winning {
+		t.Fatalf("expected challenger to be outbid by the proxy")
+	}
+	saga := sagas.only(t)
+	release := saga.stepRef(sagaStepHoldRelease)
+	if release == nil || release.RefID != club.holdIDs[0] {
+		t.Fatalf("expected HOLD_RELEASE step for the challenger hold, got %+v", saga.Steps)
+	}
+	if saga.Status != sagaStatusStarted {
+		t.Fatalf("expected saga to stay STARTED, got %s", saga.Status)
+	}
+
+	// Il bid perdente esiste con l'hold del richiedente: il recovery completa il rilascio.
+	club.releaseHoldErr = nil
+	repo.bidHolds = map[string]bool{club.holdIDs[0]: true}
+	sagas.stale = []Saga{*saga}
+	if _, err := NewSagaRecoveryWorker(slog.Default(), server, time.Second, time.Minute, 10).RunOnce(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(club.releasedHoldIDs) != 1 || club.releasedHoldIDs[0] != club.holdIDs[0] {
+		t.Fatalf("expected recovery to release the challenger hold, got %v", club.releasedHoldIDs)
+	}
+	if !sagas.completed[release.ID] || saga.Status != sagaStatusCompleted {
+		t.Fatalf("expected release step completed and saga COMPLETED, got %s", saga.Status)
+	}
+}
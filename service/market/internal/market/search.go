@@ -0,0 +1,188 @@
+package market
+
+import (
+	"context"
+	"time"
+
+	commonv1 "UltimateTeamX/proto/common/v1"
+	marketv1 "UltimateTeamX/proto/market/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Limiti di paginazione condivisi dalle RPC di lista.
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+)
+
+// SearchListings cerca i listing con filtri, ordinamento e paginazione a pagine (common.v1).
+// Senza status ritorna listing in qualsiasi stato; ACTIVE esclude quelli gia' scaduti.
+func (s *Server) SearchListings(ctx context.Context, req *marketv1.SearchListingsRequest) (*marketv1.SearchListingsResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "request is required")
+	}
+	if req.MinPrice < 0 || req.MaxPrice < 0 {
+		return nil, status.Error(codes.InvalidArgument, "price range must not be negative")
+	}
+	if req.MaxPrice > 0 && req.MinPrice > req.MaxPrice {
+		return nil, status.Error(codes.InvalidArgument, "min_price must be <= max_price")
+	}
+	if req.EndingWithinSeconds < 0 {
+		return nil, status.Error(codes.InvalidArgument, "ending_within_seconds must not be negative")
+	}
+	if req.SellerUserId != "" && !isUUID(req.SellerUserId) {
+		return nil, status.Error(codes.InvalidArgument, "seller_user_id must be a valid UUID")
+	}
+	if req.PlayerId != "" && !isUUID(req.PlayerId) {
+		return nil, status.Error(codes.InvalidArgument, "player_id must be a valid UUID")
+	}
+
+	now := time.Now()
+	filter := ListingFilter{
+		MinPrice: req.MinPrice,
+		MaxPrice: req.MaxPrice,
+		PlayerID: req.PlayerId,
+		Now:      now,
+	}
+
+	var ok bool
+	if filter.Status, ok = searchStatusFilter(req.Status); !ok {
+		return nil, status.Error(codes.InvalidArgument, "unsupported status")
+	}
+	if filter.PriceField, ok = searchPriceField(req.PriceField); !ok {
+		return nil, status.Error(codes.InvalidArgument, "unsupported price_field")
+	}
+	if err := applySearchSort(&filter, req.Sort); err != nil {
+		return nil, err
+	}
+	page, pageSize := normalizePage(req.Pagination)
+	filter.Limit = int(pageSize)
+	filter.Offset = int((page - 1) * pageSize)
+	if req.EndingWithinSeconds > 0 {
+		filter.EndingBefore = now.Add(time.Duration(req.EndingWithinSeconds) * time.Second)
+	}
+
+	// 1) Risolve il seller in club_id via club-svc.
+	if req.SellerUserId != "" {
+		sellerClubID, err := s.clubIDForUser(ctx, req.SellerUserId)
+		if err != nil {
+			return nil, err
+		}
+		filter.SellerClubID = sellerClubID
+	}
+
+	// 2) Query paginata sul DB market.
+	listings, total, err := s.repo.SearchListings(ctx, filter)
+	if err != nil {
+		s.logger.Error("errore ricerca listing", "error", err)
+		return nil, status.Error(codes.Internal, "failed to search listings")
+	}
+
+	// 3) Risolve seller e best bidder in user_id, una sola chiamata per club.
+	resolve := s.clubUserResolver(ctx)
+
+	resp := &marketv1.SearchListingsResponse{
+		Listings:   make([]*marketv1.Listing, 0, len(listings)),
+		TotalCount: total,
+		Page:       page,
+		PageSize:   pageSize,
+	}
+	for _, listing := range listings {
+		sellerUserID, err := resolve(listing.SellerClubID)
+		if err != nil {
+			return nil, err
+		}
+		item := &marketv1.Listing{
+			ListingId:      listing.ID,
+			SellerUserId:   sellerUserID,
+			UserCardId:     listing.UserCardID,
+			PlayerId:       listing.PlayerID,
+			StartPrice:     listing.StartPrice,
+			ExpiresAtUnix:  listing.ExpiresAtUnix,
+			CreatedAtUnix:  listing.CreatedAtUnix,
+			Status:         listingStatusToProto(listing, now),
+			ExtensionCount: int32(listing.ExtensionCount),
+			Kind:           listingKindToProto(listing),
+		}
+		if listing.BuyNowPrice != nil {
+			item.BuyNowPrice = *listing.BuyNowPrice
+		}
+		if listing.BestBid != nil {
+			item.BestBid = *listing.BestBid
+		}
+		if listing.BestBidderClubID != nil {
+			if item.BestBidderUserId, err = resolve(*listing.BestBidderClubID); err != nil {
+				return nil, err
+			}
+		}
+		resp.Listings = append(resp.Listings, item)
+	}
+	return resp, nil
+}
+
+// searchStatusFilter converte l'enum in stato DB ("" = nessun filtro).
+func searchStatusFilter(value marketv1.ListingStatus) (string, bool) {
+	switch value {
+	case marketv1.ListingStatus_LISTING_STATUS_UNSPECIFIED:
+		return "", true
+	case marketv1.ListingStatus_LISTING_STATUS_ACTIVE:
+		return listingStatusActive, true
+	case marketv1.ListingStatus_LISTING_STATUS_SOLD:
+		return listingStatusSold, true
+	case marketv1.ListingStatus_LISTING_STATUS_EXPIRED:
+		return listingStatusExpired, true
+	case marketv1.ListingStatus_LISTING_STATUS_CANCELLED:
+		return listingStatusCancelled, true
+	default:
+		return "", false
+	}
+}
+
+// searchPriceField converte l'enum nel campo prezzo del repo.
+func searchPriceField(value marketv1.PriceField) (string, bool) {
+	switch value {
+	case marketv1.PriceField_PRICE_FIELD_UNSPECIFIED:
+		return priceFieldCurrent, true
+	case marketv1.PriceField_PRICE_FIELD_START:
+		return priceFieldStart, true
+	case marketv1.PriceField_PRICE_FIELD_BUY_NOW:
+		return priceFieldBuyNow, true
+	case marketv1.PriceField_PRICE_FIELD_BEST_BID:
+		return priceFieldBestBid, true
+	default:
+		return "", false
+	}
+}
+
+// applySearchSort valida il sort; di default ordina per expires_at crescente (in scadenza prima).
+func applySearchSort(filter *ListingFilter, sort *commonv1.Sort) error {
+	filter.SortField = sortFieldExpiresAt
+	if sort == nil {
+		return nil
+	}
+	switch sort.Field {
+	case "", sortFieldExpiresAt:
+	case sortFieldPrice, sortFieldCreatedAt:
+		filter.SortField = sort.Field
+	default:
+		return status.Error(codes.InvalidArgument, "sort.field must be expires_at, price or created_at")
+	}
+	filter.SortDesc = sort.Order == commonv1.SortOrder_SORT_ORDER_DESC
+	return nil
+}
+
+// normalizePage normalizza la paginazione: pagine da 1, page_size di default e massimo.
+func normalizePage(pagination *commonv1.Pagination) (uint32, uint32) {
+	page, pageSize := uint32(1), uint32(defaultPageSize)
+	if pagination == nil {
+		return page, pageSize
+	}
+	if pagination.Page > 0 {
+		page = pagination.Page
+	}
+	if pagination.PageSize > 0 {
+		pageSize = min(pagination.PageSize, maxPageSize)
+	}
+	return page, pageSize
+}
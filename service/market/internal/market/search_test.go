@@ -0,0 +1,142 @@
+package market
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	clubv1 "UltimateTeamX/proto/club/v1"
+	commonv1 "UltimateTeamX/proto/common/v1"
+	marketv1 "UltimateTeamX/proto/market/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Test suite per la ricerca dei listing.
+
+func TestSearchListingsMapsFiltersAndResolvesUsers(t *testing.T) {
+	bestBid := int64(1500)
+	bestBidder := "club-bidder"
+	repo := &fakeRepo{
+		searchResult: []Listing{
+			{
+				ID:               "listing-1",
+				SellerClubID:     "club-seller",
+				UserCardID:       "card-1",
+				PlayerID:         "player-1",
+				StartPrice:       1000,
+				Status:           listingStatusActive,
+				ExpiresAtUnix:    time.Now().Add(time.Hour).Unix(),
+				BestBid:          &bestBid,
+				BestBidderClubID: &bestBidder,
+			},
+			{
+				ID:            "listing-2",
+				SellerClubID:  "club-seller",
+				Status:        listingStatusActive,
+				ExpiresAtUnix: time.Now().Add(2 * time.Hour).Unix(),
+			},
+		},
+		searchTotal: 42,
+	}
+	club := &fakeClub{
+		getMyClubResp: &clubv1.GetMyClubResponse{ClubId: "club-seller"},
+		clubOwners: map[string]string{
+			"club-seller": "seller-user",
+			"club-bidder": "bidder-user",
+		},
+	}
+	server := NewServer(slog.Default(), repo, club, nil)
+
+	resp, err := server.SearchListings(context.Background(), &marketv1.SearchListingsRequest{
+		Status:              marketv1.ListingStatus_LISTING_STATUS_ACTIVE,
+		PriceField:          marketv1.PriceField_PRICE_FIELD_BUY_NOW,
+		MinPrice:            500,
+		MaxPrice:            5000,
+		SellerUserId:        "11111111-1111-1111-1111-111111111111",
+		EndingWithinSeconds: 600,
+		Pagination:          &commonv1.Pagination{Page: 3, PageSize: 10},
+		Sort:                &commonv1.Sort{Field: "price", Order: commonv1.SortOrder_SORT_ORDER_DESC},
+	})
+	if err != nil {
+		t.Fatalf("expected success, got error: %v", err)
+	}
+
+	filter := repo.lastFilter
+	if filter.Status != listingStatusActive || filter.PriceField != priceFieldBuyNow || filter.MinPrice != 500 || filter.MaxPrice != 5000 {
+		t.Fatalf("unexpected filter: %+v", filter)
+	}
+	if filter.SellerClubID != "club-seller" || filter.EndingBefore.IsZero() {
+		t.Fatalf("expected seller and ending-soon filters, got %+v", filter)
+	}
+	if filter.SortField != sortFieldPrice || !filter.SortDesc || filter.Limit != 10 || filter.Offset != 20 {
+		t.Fatalf("unexpected sort/page: %+v", filter)
+	}
+	if resp.TotalCount != 42 || resp.Page != 3 || resp.PageSize != 10 || len(resp.Listings) != 2 {
+		t.Fatalf("unexpected response: %+v", resp)
+	}
+	first := resp.Listings[0]
+	if first.SellerUserId != "seller-user" || first.BestBidderUserId != "bidder-user" || first.PlayerId != "player-1" {
+		t.Fatalf("unexpected listing: %+v", first)
+	}
+}
+
+func TestSearchListingsDefaults(t *testing.T) {
+	repo := &fakeRepo{}
+	server := NewServer(slog.Default(), repo, &fakeClub{}, nil)
+
+	resp, err := server.SearchListings(context.Background(), &marketv1.SearchListingsRequest{
+		Pagination: &commonv1.Pagination{PageSize: 1000},
+	})
+	if err != nil {
+		t.Fatalf("expected success, got error: %v", err)
+	}
+	filter := repo.lastFilter
+	if filter.Status != "" || filter.PriceField != priceFieldCurrent || filter.SortField != sortFieldExpiresAt || filter.SortDesc {
+		t.Fatalf("unexpected default filter: %+v", filter)
+	}
+	if resp.Page != 1 || resp.PageSize != maxPageSize || filter.Offset != 0 {
+		t.Fatalf("expected first page capped at %d, got page=%d size=%d", maxPageSize, resp.Page, resp.PageSize)
+	}
+}
+
+func TestSearchListingsValidation(t *testing.T) {
+	server := NewServer(slog.Default(), &fakeRepo{}, &fakeClub{}, nil)
+
+	requests := []*marketv1.SearchListingsRequest{
+		{MinPrice: 2000, MaxPrice: 1000},
+		{PlayerId: "not-a-uuid"},
+		{Sort: &commonv1.Sort{Field: "seller_club_id"}},
+	}
+	for _, req := range requests {
+		_, err := server.SearchListings(context.Background(), req)
+		if status.Code(err) != codes.InvalidArgument {
+			t.Fatalf("expected InvalidArgument for %+v, got %v", req, err)
+		}
+	}
+}
+
+func TestCreateListingStoresPlayerID(t *testing.T) {
+	repo := &fakeRepo{}
+	club := &fakeClub{getMyClubResp: &clubv1.GetMyClubResponse{
+		ClubId: "club-seller",
+		Cards: []*clubv1.Card{
+			{Id: "22222222-2222-2222-2222-222222222222", PlayerId: "33333333-3333-3333-3333-333333333333"},
+		},
+	}}
+	server := NewServer(slog.Default(), repo, club, nil)
+
+	_, err := server.CreateListing(context.Background(), &marketv1.CreateListingRequest{
+		SellerUserId:  "11111111-1111-1111-1111-111111111111",
+		UserCardId:    "22222222-2222-2222-2222-222222222222",
+		StartPrice:    1000,
+		ExpiresAtUnix: time.Now().Add(time.Hour).Unix(),
+	})
+	if err != nil {
+		t.Fatalf("expected success, got error: %v", err)
+	}
+	if repo.createdListing.PlayerID != "33333333-3333-3333-3333-333333333333" {
+		t.Fatalf("expected player_id to be stored, got %q", repo.createdListing.PlayerID)
+	}
+}
@@ -10,15 +10,28 @@ import (
 	"UltimateTeamX/pkg/grpcx"
 	clubv1 "UltimateTeamX/proto/club/v1"
 	marketv1 "UltimateTeamX/proto/market/v1"
+	"UltimateTeamX/service/market/internal/idempotency"
 	"UltimateTeamX/service/market/internal/lock"
+	"UltimateTeamX/service/market/internal/ratelimit"
 	"github.com/google/uuid"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 )
 
-// Stato usato per le listing attive nel market DB.
-const listingStatusActive = "ACTIVE"
+// Stati usati per le listing nel market DB.
+const (
+	listingStatusActive    = "ACTIVE"
+	listingStatusSold      = "SOLD"
+	listingStatusExpired   = "EXPIRED"
+	listingStatusCancelled = "CANCELLED"
+)
+
+// Tipi di listing: carta singola o lotto di carte (bundle).
+const (
+	listingKindSingle = "SINGLE"
+	listingKindBundle = "BUNDLE"
+)
 
 // Server implementa l'interfaccia gRPC MarketService.
 // Integra il club-svc per risolvere club_id e gestire lock/hold.
@@ -28,6 +41,46 @@ type Server struct {
 	repo   ListingRepo
 	club   clubv1.ClubServiceClient
 	locker lock.Manager
+	sagas  SagaLog
+	// idempotency salva le risposte delle RPC mutative per idempotency key (nil = disabilitato).
+	idempotency idempotency.Store
+	// events pubblica gli eventi dei listing per WatchListing (nil = disabilitato).
+	events EventBus
+	// softClose estende le aste sui bid dell'ultimo momento (zero = disabilitato).
+	softClose SoftClose
+	// bidIncrements e' la tabella dei rilanci minimi (vuota = +1 credito).
+	bidIncrements BidIncrements
+	// cancelPenaltyBps e' la penale (basis point del best_bid) per ritirare un listing con offerte.
+	cancelPenaltyBps int64
+	// sellerTaxBps e' la tassa (basis point del prezzo) trattenuta al seller su ogni vendita.
+	sellerTaxBps int64
+	// limits sono i limiti anti-abuso per club (0 = nessun limite).
+	limits Limits
+	// bidLimiter limita la frequenza dei PlaceBid per utente (nil = nessun limite).
+	bidLimiter ratelimit.Limiter
+	// priceBounds configura il range di prezzo derivato dai trade recenti.
+	priceBounds PriceBoundsConfig
+	// adminToken abilita le RPC admin (vuoto = disabilitate).
+	adminToken string
+}
+
+// Option configura le regole opzionali del Server.
+type Option func(*Server)
+
+// WithCancelPenaltyBps abilita il ritiro dei listing con offerte applicando una penale
+// in basis point del best_bid; con 0 il ritiro e' ammesso solo senza offerte.
+func WithCancelPenaltyBps(bps int64) Option {
+	return func(s *Server) {
+		s.cancelPenaltyBps = bps
+	}
+}
+
+// WithSoftClose abilita l'anti-sniping: un bid negli ultimi window sposta la scadenza
+// avanti di extension, al massimo maxExtensions volte per listing.
+func WithSoftClose(window, extension time.Duration, maxExtensions int) Option {
+	return func(s *Server) {
+		s.softClose = SoftClose{Window: window, Extension: extension, MaxExtensions: maxExtensions}
+	}
 }
 
 // ListingRepo is the minimal persistence interface used by the server.
@@ -35,23 +88,55 @@ type ListingRepo interface {
 	ActiveListingByCard(ctx context.Context, userCardID string) (string, error)
 	CreateListing(ctx context.Context, listing Listing) error
 	GetListing(ctx context.Context, listingID string) (Listing, error)
-	InsertBidAndUpdateListing(ctx context.Context, listingID, bidderClubID, holdID string, amount int64) (string, error)
+	InsertBidAndUpdateListing(ctx context.Context, placement BidPlacement) (BidResult, error)
 	GetHoldIDForBid(ctx context.Context, listingID, bidderClubID string, amount int64) (string, error)
+	ListBidHoldIDs(ctx context.Context, listingID string) ([]string, error)
+	MarkListingSold(ctx context.Context, trade Trade) error
+	MarkListingExpired(ctx context.Context, listingID string) error
+	ListExpiredListingIDs(ctx context.Context, limit int) ([]string, error)
+	MarkListingCancelled(ctx context.Context, listingID string) error
+	HasBidWithHold(ctx context.Context, holdID string) (bool, error)
+	SearchListings(ctx context.Context, filter ListingFilter) ([]Listing, int64, error)
+	ListBidsByListing(ctx context.Context, listingID string, limit, offset int) ([]Bid, int64, error)
+	ListBidsByBidder(ctx context.Context, bidderClubID string, limit, offset int) ([]Bid, int64, error)
+	PriceHistory(ctx context.Context, playerID string, since time.Time, bucket time.Duration) ([]PriceBucket, error)
+	CountActiveListingsBySeller(ctx context.Context, sellerClubID string) (int, error)
+	CountWinningListingsByBidder(ctx context.Context, bidderClubID string) (int, error)
+	GetPriceBounds(ctx context.Context, playerID string) (PriceBounds, error)
+	UpsertPriceBounds(ctx context.Context, playerID string, bounds PriceBounds) error
+	DeletePriceBounds(ctx context.Context, playerID string) (bool, error)
+	TradePriceStats(ctx context.Context, playerID string, since time.Time) (median, count int64, err error)
+	RelistListing(ctx context.Context, listing Listing) error
+	ListRelistableListingIDs(ctx context.Context, sellerClubID string, limit int) ([]string, error)
 }
 
 // NewServer collega logger, repo e client del club-svc.
-func NewServer(logger *slog.Logger, repo ListingRepo, club clubv1.ClubServiceClient, locker lock.Manager) *Server {
-	return &Server{logger: logger, repo: repo, club: club, locker: locker}
+func NewServer(logger *slog.Logger, repo ListingRepo, club clubv1.ClubServiceClient, locker lock.Manager, opts ...Option) *Server {
+	s := &Server{logger: logger, repo: repo, club: club, locker: locker, sagas: noopSagaLog{}}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
 }
 
 // CreateListing valida la richiesta, blocca la carta in club-svc e inserisce il listing.
+// Con idempotency key un retry ritorna lo stesso listing_id.
 func (s *Server) CreateListing(ctx context.Context, req *marketv1.CreateListingRequest) (*marketv1.CreateListingResponse, error) {
 	if req == nil {
 		return nil, status.Error(codes.InvalidArgument, "request is required")
 	}
+	return idempotent(ctx, s, "CreateListing", req.SellerUserId, req, &marketv1.CreateListingResponse{}, func() (*marketv1.CreateListingResponse, error) {
+		return s.createListing(ctx, req)
+	})
+}
+
+func (s *Server) createListing(ctx context.Context, req *marketv1.CreateListingRequest) (*marketv1.CreateListingResponse, error) {
 	if err := validateCreateListing(req); err != nil {
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
+	if len(req.BundleUserCardIds) > 0 {
+		return s.createBundleListing(ctx, req)
+	}
 
 	// 1) Evita piu' listing attivi per la stessa carta.
 	existingID, err := s.repo.ActiveListingByCard(ctx, req.UserCardId)
@@ -63,57 +148,77 @@ func (s *Server) CreateListing(ctx context.Context, req *marketv1.CreateListingR
 		return nil, status.Error(codes.AlreadyExists, "active listing already exists for card")
 	}
 
-	// 2) Risolve seller_club_id via club-svc.
-	sellerClubID, err := s.clubIDForUser(ctx, req.SellerUserId)
+	// 2) Risolve seller_club_id e player della carta via club-svc.
+	sellerClub, err := s.clubForUser(ctx, req.SellerUserId)
+	if err != nil {
+		return nil, err
+	}
+	sellerClubID := sellerClub.ClubId
+	playerID := playerIDForCard(sellerClub, req.UserCardId)
+	if err := s.checkActiveListingsLimit(ctx, sellerClubID); err != nil {
+		return nil, err
+	}
+	if err := s.checkPriceBounds(ctx, playerID, "listing price", req.StartPrice, req.BuyNowPrice); err != nil {
+		return nil, err
+	}
+
+	// 3) Registra la saga prima di toccare club-svc.
+	listingID := uuid.NewString()
+	saga, err := s.startSaga(ctx, sagaKindCreateListing, listingID, req.SellerUserId)
 	if err != nil {
 		return nil, err
 	}
 
 	// Il lock in club-svc vale come verifica di ownership/disponibilità.
-	// 3) Lock carta in club-svc (ownership/disponibilita').
-	lockResp, err := s.club.LockCard(ctx, &clubv1.LockCardRequest{
-		UserId:     req.SellerUserId,
-		UserCardId: req.UserCardId,
-		Reason:     "market_listing",
-	})
+	// 4) Lock carta in club-svc (ownership/disponibilita'), con lo step registrato prima.
+	lockID, err := s.beginSagaStep(ctx, saga, sagaStepCardLock, compensationReleaseCardLock)
 	if err != nil {
-		if grpcStatus, ok := status.FromError(err); ok {
-			s.logger.Warn("lock carta rifiutato da club-svc", "code", grpcStatus.Code(), "error", grpcStatus.Message())
-			return nil, grpcStatus.Err()
-		}
-		s.logger.Error("errore lock carta in club-svc", "error", err)
-		return nil, status.Error(codes.Internal, "failed to lock card")
+		return nil, err
+	}
+	if err := s.lockCard(ctx, req.SellerUserId, req.UserCardId, lockID); err != nil {
+		s.failSagaStep(ctx, saga, err)
+		return nil, err
 	}
 
-	// 4) Inserisce il listing nel DB market.
-	listingID := uuid.NewString()
+	// 5) Inserisce il listing nel DB market.
 	expiresAt := time.Unix(req.ExpiresAtUnix, 0)
 	listing := Listing{
-		ID:           listingID,
-		SellerClubID: sellerClubID,
-		UserCardID:   req.UserCardId,
-		StartPrice:   req.StartPrice,
-		BuyNowPrice:  optionalPrice(req.BuyNowPrice),
-		Status:       listingStatusActive,
+		ID:            listingID,
+		SellerClubID:  sellerClubID,
+		UserCardID:    req.UserCardId,
+		StartPrice:    req.StartPrice,
+		BuyNowPrice:   optionalPrice(req.BuyNowPrice),
+		ReservePrice:  optionalPrice(req.ReservePrice),
+		Status:        listingStatusActive,
 		ExpiresAtUnix: expiresAt.Unix(),
+		LockID:        lockID,
+		PlayerID:      playerID,
 	}
 
 	if err := s.repo.CreateListing(ctx, listing); err != nil {
-		// TODO: decidere come gestire il retry/idempotenza quando il lock e' preso ma l'insert fallisce.
-		// Sblocco best-effort per evitare di lasciare la carta bloccata.
+		// Compensa subito il lock carta; se fallisce la saga resta STARTED per il recovery loop.
 		s.logger.Error("errore creazione listing nel db", "error", err, "listing_id", listingID)
-		_, _ = s.club.ReleaseCardLock(ctx, &clubv1.ReleaseCardLockRequest{LockId: lockResp.LockId})
+		_ = s.compensateSaga(ctx, saga)
 		return nil, status.Error(codes.Internal, "failed to create listing")
 	}
+	s.finishSaga(ctx, saga, sagaStatusCompleted)
 
 	s.logger.Info("listing creato", "listing_id", listingID, "user_card_id", req.UserCardId)
 	return &marketv1.CreateListingResponse{ListingId: listingID}, nil
 }
 
+// PlaceBid rilancia su un listing ACTIVE con hold crediti in club-svc.
+// Con idempotency key un retry non crea un secondo hold.
 func (s *Server) PlaceBid(ctx context.Context, req *marketv1.PlaceBidRequest) (*marketv1.PlaceBidResponse, error) {
 	if req == nil {
 		return nil, status.Error(codes.InvalidArgument, "request is required")
 	}
+	return idempotent(ctx, s, "PlaceBid", req.BidderUserId, req, &marketv1.PlaceBidResponse{}, func() (*marketv1.PlaceBidResponse, error) {
+		return s.placeBid(ctx, req)
+	})
+}
+
+func (s *Server) placeBid(ctx context.Context, req *marketv1.PlaceBidRequest) (*marketv1.PlaceBidResponse, error) {
 	if strings.TrimSpace(req.ListingId) == "" {
 		return nil, status.Error(codes.InvalidArgument, "listing_id is required")
 	}
@@ -126,12 +231,21 @@ func (s *Server) PlaceBid(ctx context.Context, req *marketv1.PlaceBidRequest) (*
 	if !isUUID(req.BidderUserId) {
 		return nil, status.Error(codes.InvalidArgument, "bidder_user_id must be a valid UUID")
 	}
-	if req.BidAmount <= 0 {
+	if req.BidAmount < 0 || req.MaxBid < 0 {
+		return nil, status.Error(codes.InvalidArgument, "bid_amount and max_bid must not be negative")
+	}
+	if req.BidAmount == 0 && req.MaxBid == 0 {
 		return nil, status.Error(codes.InvalidArgument, "bid_amount must be positive")
 	}
+	if req.MaxBid > 0 && req.BidAmount > req.MaxBid {
+		return nil, status.Error(codes.InvalidArgument, "bid_amount must be <= max_bid")
+	}
 	if s.locker == nil {
 		return nil, status.Error(codes.Internal, "redis lock not configured")
 	}
+	if err := s.checkBidRate(ctx, req.BidderUserId); err != nil {
+		return nil, err
+	}
 
 	// 1) Risolve bidder_club_id via club-svc.
 	bidderClubID, err := s.clubIDForUser(ctx, req.BidderUserId)
@@ -139,30 +253,180 @@ func (s *Server) PlaceBid(ctx context.Context, req *marketv1.PlaceBidRequest) (*
 		return nil, err
 	}
 
-	// 2) Acquisisce lock Redis per serializzare i bid.
-	lockKey := "lock:listing:" + req.ListingId
-	token, ok, err := s.locker.Acquire(ctx, lockKey)
+	// 2) Acquisisce lock Redis per serializzare i bid (inclusi i rilanci automatici).
+	unlock, err := s.acquireListingLock(ctx, req.ListingId)
 	if err != nil {
-		s.logger.Error("errore acquisizione lock redis", "error", err, "listing_id", req.ListingId)
-		return nil, status.Error(codes.Internal, "failed to acquire listing lock")
-	}
-	if !ok {
-		return nil, status.Error(codes.FailedPrecondition, "listing is locked")
+		return nil, err
 	}
-	defer func() {
-		if err := s.locker.Release(context.Background(), lockKey, token); err != nil {
-			s.logger.Warn("errore rilascio lock redis", "error", err, "listing_id", req.ListingId)
-		}
-	}()
+	defer unlock()
 
 	// 3) Carica listing e valida lo stato.
-	listing, err := s.repo.GetListing(ctx, req.ListingId)
+	listing, err := s.loadListing(ctx, req.ListingId)
 	if err != nil {
-		if errors.Is(err, ErrNotFound) {
-			return nil, status.Error(codes.NotFound, "listing not found")
+		return nil, err
+	}
+	if listing.Status != listingStatusActive {
+		return nil, status.Error(codes.FailedPrecondition, "listing not active")
+	}
+	if listing.ExpiresAtUnix <= time.Now().Unix() {
+		return nil, status.Error(codes.FailedPrecondition, "listing expired")
+	}
+	if listing.SellerClubID == bidderClubID {
+		return nil, status.Error(codes.FailedPrecondition, "cannot bid on own listing")
+	}
+	if err := s.checkWinningBidsLimit(ctx, listing, bidderClubID); err != nil {
+		return nil, err
+	}
+
+	// L'hold va dimensionato sul tetto: con proxy e' max_bid, altrimenti l'importo del bid.
+	ceiling := req.BidAmount
+	if req.MaxBid > 0 {
+		ceiling = req.MaxBid
+	}
+	if err := s.checkListingPriceBounds(ctx, listing, "bid", ceiling); err != nil {
+		return nil, err
+	}
+	// Validazione anticipata (rilancio minimo, tetto del best bidder) prima di creare l'hold.
+	if _, err := s.resolveBid(listing, bidderClubID, "", "", req.BidAmount, req.MaxBid); err != nil {
+		return nil, err
+	}
+	// Hold del best bidder letto prima dell'insert: serve per i suoi rilanci automatici
+	// e per rilasciarlo se viene superato.
+	leaderHoldID, err := s.bestBidHoldID(ctx, listing)
+	if err != nil {
+		return nil, err
+	}
+
+	// 4) Crea hold crediti nel club-svc, tracciato dalla saga.
+	saga, err := s.startSaga(ctx, sagaKindPlaceBid, listing.ID, req.BidderUserId)
+	if err != nil {
+		return nil, err
+	}
+	holdID, err := s.beginSagaStep(ctx, saga, sagaStepCreditHold, compensationReleaseCreditHold)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.createCreditHold(ctx, req.BidderUserId, holdID, ceiling, "market_bid"); err != nil {
+		s.failSagaStep(ctx, saga, err)
+		return nil, err
+	}
+
+	// 5) Applica le regole di proxy bidding e registra bid e rilanci automatici in DB.
+	// L'eventuale estensione soft-close e' applicata nello stesso update.
+	outcome, err := s.resolveBid(listing, bidderClubID, holdID, leaderHoldID, req.BidAmount, req.MaxBid)
+	if err != nil {
+		_ = s.compensateSaga(ctx, saga)
+		return nil, err
+	}
+	// Il rilascio dell'hold che esce di testa e' registrato prima dell'insert: quello del
+	// best bidder superato (o il suo tetto precedente), oppure quello del richiedente
+	// superato dal proxy, che resta sul bid perdente. Dopo un crash il recovery lo esegue
+	// se il bid esiste.
+	releaseHoldID := holdID
+	if outcome.requesterWins {
+		releaseHoldID = leaderHoldID
+	}
+	if err := s.recordReleaseSteps(ctx, saga, sagaStepHoldRelease, releaseHoldID); err != nil {
+		return nil, err
+	}
+	bid, err := s.repo.InsertBidAndUpdateListing(ctx, BidPlacement{
+		ListingID:        listing.ID,
+		Bids:             outcome.rows,
+		BestBid:          outcome.bestBid,
+		BestBidderClubID: outcome.bestBidderClubID,
+		BestMaxBid:       outcome.bestMaxBid,
+		SoftClose:        s.softClose,
+	})
+	if err != nil {
+		_ = s.compensateSaga(ctx, saga)
+		if errors.Is(err, ErrListingNotActive) {
+			return nil, status.Error(codes.FailedPrecondition, "listing not active or expired")
+		}
+		s.logger.Error("errore inserimento bid", "error", err, "listing_id", req.ListingId)
+		return nil, status.Error(codes.Internal, "failed to place bid")
+	}
+	extended := bid.ExpiresAtUnix > listing.ExpiresAtUnix
+
+	// 6) Rilascia l'hold di chi non e' piu' in testa: il best bidder precedente se superato
+	// (o se ha alzato il proprio tetto con un nuovo hold), altrimenti quello del richiedente.
+	// Un rilascio fallito lascia la saga STARTED per il recovery.
+	_ = s.completeSaga(ctx, saga)
+	bestBidderUserID := req.BidderUserId
+	if !outcome.requesterWins {
+		if bestBidderUserID, err = s.userIDForClub(ctx, outcome.bestBidderClubID); err != nil {
+			s.logger.Warn("best bidder non risolto", "error", err, "listing_id", listing.ID)
 		}
-		s.logger.Error("errore lettura listing", "error", err, "listing_id", req.ListingId)
-		return nil, status.Error(codes.Internal, "failed to load listing")
+	}
+
+	// 7) Notifica i watcher: nuovo prezzo, bidder superato ed eventuale estensione.
+	previous := listing
+	listing.ExpiresAtUnix = bid.ExpiresAtUnix
+	s.publishEvent(ctx, listing, marketv1.ListingEventType_LISTING_EVENT_TYPE_BID, outcome.bestBid, bestBidderUserID)
+	switch {
+	case outcome.previousLeaderOutbid:
+		previous.ExpiresAtUnix = bid.ExpiresAtUnix
+		s.publishOutbid(ctx, previous, outcome.bestBid)
+	case !outcome.requesterWins:
+		s.publishEvent(ctx, listing, marketv1.ListingEventType_LISTING_EVENT_TYPE_OUTBID, outcome.bestBid, req.BidderUserId)
+	}
+	if extended {
+		s.publishEvent(ctx, listing, marketv1.ListingEventType_LISTING_EVENT_TYPE_EXTENDED, outcome.bestBid, bestBidderUserID)
+		s.logger.Info("asta estesa per bid finale", "listing_id", listing.ID, "expires_at_unix", bid.ExpiresAtUnix, "extension_count", bid.ExtensionCount)
+	}
+
+	s.logger.Info("bid inserito", "listing_id", listing.ID, "bid_ids", bid.BidIDs, "best_bid", outcome.bestBid, "winning", outcome.requesterWins)
+	return &marketv1.PlaceBidResponse{
+		BestBid:          outcome.bestBid,
+		BestBidderUserId: bestBidderUserID,
+		Winning:          outcome.requesterWins,
+	}, nil
+}
+
+// BuyNow acquista subito il listing al buy_now_price e regola il trade in club-svc.
+// Con idempotency key un retry dopo il successo ritorna purchased senza nuovi hold.
+func (s *Server) BuyNow(ctx context.Context, req *marketv1.BuyNowRequest) (*marketv1.BuyNowResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "request is required")
+	}
+	return idempotent(ctx, s, "BuyNow", req.BuyerUserId, req, &marketv1.BuyNowResponse{}, func() (*marketv1.BuyNowResponse, error) {
+		return s.buyNow(ctx, req)
+	})
+}
+
+func (s *Server) buyNow(ctx context.Context, req *marketv1.BuyNowRequest) (*marketv1.BuyNowResponse, error) {
+	if strings.TrimSpace(req.ListingId) == "" {
+		return nil, status.Error(codes.InvalidArgument, "listing_id is required")
+	}
+	if strings.TrimSpace(req.BuyerUserId) == "" {
+		return nil, status.Error(codes.InvalidArgument, "buyer_user_id is required")
+	}
+	if !isUUID(req.ListingId) {
+		return nil, status.Error(codes.InvalidArgument, "listing_id must be a valid UUID")
+	}
+	if !isUUID(req.BuyerUserId) {
+		return nil, status.Error(codes.InvalidArgument, "buyer_user_id must be a valid UUID")
+	}
+	if s.locker == nil {
+		return nil, status.Error(codes.Internal, "redis lock not configured")
+	}
+
+	// 1) Risolve buyer_club_id via club-svc.
+	buyerClubID, err := s.clubIDForUser(ctx, req.BuyerUserId)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2) Acquisisce lock Redis: BuyNow e PlaceBid sono mutuamente esclusivi.
+	unlock, err := s.acquireListingLock(ctx, req.ListingId)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	// 3) Carica listing e valida stato e prezzo.
+	listing, err := s.loadListing(ctx, req.ListingId)
+	if err != nil {
+		return nil, err
 	}
 	if listing.Status != listingStatusActive {
 		return nil, status.Error(codes.FailedPrecondition, "listing not active")
@@ -170,61 +434,291 @@ func (s *Server) PlaceBid(ctx context.Context, req *marketv1.PlaceBidRequest) (*
 	if listing.ExpiresAtUnix <= time.Now().Unix() {
 		return nil, status.Error(codes.FailedPrecondition, "listing expired")
 	}
+	if listing.BuyNowPrice == nil {
+		return nil, status.Error(codes.FailedPrecondition, "listing has no buy_now_price")
+	}
+	if listing.SellerClubID == buyerClubID {
+		return nil, status.Error(codes.FailedPrecondition, "cannot buy own listing")
+	}
+	price := *listing.BuyNowPrice
+	// Il range puo' essere cambiato dopo la creazione del listing.
+	if err := s.checkListingPriceBounds(ctx, listing, "buy_now_price", price); err != nil {
+		return nil, err
+	}
+
+	// 4) Risolve il seller_user_id richiesto da SettleTrade.
+	sellerUserID, err := s.userIDForClub(ctx, listing.SellerClubID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Hold del best bidder, da rilasciare a vendita avvenuta.
+	leaderHoldID, err := s.bestBidHoldID(ctx, listing)
+	if err != nil {
+		return nil, err
+	}
+
+	// 5) Crea hold crediti sul buyer per l'intero prezzo, tracciato dalla saga.
+	saga, err := s.startSaga(ctx, sagaKindBuyNow, listing.ID, req.BuyerUserId)
+	if err != nil {
+		return nil, err
+	}
+	holdID, err := s.beginSagaStep(ctx, saga, sagaStepCreditHold, compensationReleaseCreditHold)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.createCreditHold(ctx, req.BuyerUserId, holdID, price, "market_buy_now"); err != nil {
+		s.failSagaStep(ctx, saga, err)
+		return nil, err
+	}
+
+	// 6) Regola il trade in club-svc (crediti + carta).
+	// Il rilascio dell'hold del best bidder e lo step SETTLE sono registrati prima della
+	// chiamata: dopo un crash il recovery ripete il settlement e poi il rilascio.
+	// Solo FailedPrecondition e' un rifiuto definitivo (si rilascia l'hold); su errori
+	// transitori il settlement potrebbe essere gia' applicato, quindi la saga resta STARTED
+	// e il recovery ripete SettleTrade (idempotente sul trade_id), come in recoverBuyNow.
+	if err := s.recordReleaseSteps(ctx, saga, sagaStepHoldRelease, leaderHoldID); err != nil {
+		return nil, err
+	}
+	if err := s.recordSagaStep(ctx, saga, sagaStepSettle, listing.ID, compensationNone); err != nil {
+		_ = s.compensateSaga(ctx, saga)
+		return nil, status.Error(codes.Internal, "failed to record saga step")
+	}
+	settle := s.tradeSettlement(listing, sellerUserID, req.BuyerUserId, holdID, price)
+	if _, err = s.club.SettleTrade(ctx, settle); err != nil {
+		if grpcStatus, ok := status.FromError(err); ok && grpcStatus.Code() == codes.FailedPrecondition {
+			s.logger.Warn("settlement rifiutato da club-svc", "error", grpcStatus.Message(), "listing_id", listing.ID)
+			s.markStepCompensated(ctx, saga, sagaStepSettle)
+			_ = s.compensateSaga(ctx, saga)
+			return nil, grpcStatus.Err()
+		}
+		s.logger.Error("errore settlement trade, saga lasciata al recovery", "error", err, "listing_id", listing.ID, "saga_id", saga.ID)
+		return nil, status.Error(codes.Unavailable, "trade settlement pending, retry later")
+	}
+
+	// 7) Marca il listing SOLD. Il trade e' gia' regolato: in errore la saga resta
+	// STARTED e il recovery loop completa l'update.
+	if err := s.repo.MarkListingSold(ctx, tradeRecord(settle, listing, buyerClubID)); err != nil {
+		s.logger.Error("errore aggiornamento listing a SOLD dopo settlement", "error", err, "listing_id", listing.ID)
+		return nil, status.Error(codes.Internal, "failed to mark listing sold")
+	}
+
+	// 8) Rilascia l'hold del best bidder (se presente) e notifica i watcher.
+	// Un rilascio fallito lascia la saga STARTED per il recovery.
+	_ = s.completeSaga(ctx, saga)
+	s.publishEvent(ctx, listing, marketv1.ListingEventType_LISTING_EVENT_TYPE_SOLD, price, req.BuyerUserId)
+
+	s.logger.Info("listing acquistato", "listing_id", listing.ID, "buyer_club_id", buyerClubID, "price", price, "tax", settle.TaxAmount)
+	return &marketv1.BuyNowResponse{Purchased: true}, nil
+}
+
+// GetListing ritorna lo stato del listing risolvendo i club in user_id via club-svc.
+func (s *Server) GetListing(ctx context.Context, req *marketv1.GetListingRequest) (*marketv1.GetListingResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "request is required")
+	}
+	if strings.TrimSpace(req.ListingId) == "" {
+		return nil, status.Error(codes.InvalidArgument, "listing_id is required")
+	}
+	if !isUUID(req.ListingId) {
+		return nil, status.Error(codes.InvalidArgument, "listing_id must be a valid UUID")
+	}
 
+	// 1) Carica il listing (lettura senza lock Redis).
+	listing, err := s.loadListing(ctx, req.ListingId)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2) Risolve seller e best bidder da club_id a user_id.
+	sellerUserID, err := s.userIDForClub(ctx, listing.SellerClubID)
+	if err != nil {
+		return nil, err
+	}
+	bestBidderUserID := ""
+	if listing.BestBidderClubID != nil {
+		bestBidderUserID, err = s.userIDForClub(ctx, *listing.BestBidderClubID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	resp := &marketv1.GetListingResponse{
+		ListingId:         listing.ID,
+		SellerUserId:      sellerUserID,
+		UserCardId:        listing.UserCardID,
+		StartPrice:        listing.StartPrice,
+		BestBidderUserId:  bestBidderUserID,
+		ExpiresAtUnix:     listing.ExpiresAtUnix,
+		Status:            listingStatusToProto(listing, time.Now()),
+		ExtensionCount:    int32(listing.ExtensionCount),
+		PreviousListingId: listing.PreviousListingID,
+		Kind:              listingKindToProto(listing),
+		UserCardIds:       listingCardIDs(listing),
+	}
+	if listing.BuyNowPrice != nil {
+		resp.BuyNowPrice = *listing.BuyNowPrice
+	}
 	if listing.BestBid != nil {
-		if req.BidAmount <= *listing.BestBid {
-			return nil, status.Error(codes.FailedPrecondition, "bid must be higher than best_bid")
+		resp.BestBid = *listing.BestBid
+	}
+	// La riserva resta nascosta a tutti tranne al seller, identificato dalle metadata
+	// gRPC autenticate: seller_user_id e' pubblico, quindi non basta un campo del body.
+	if viewer := viewerUserIDFromContext(ctx); listing.ReservePrice != nil && viewer != "" && viewer == sellerUserID {
+		resp.ReservePrice = *listing.ReservePrice
+	}
+	return resp, nil
+}
+
+// viewerUserIDFromContext legge l'user_id autenticato dai metadata gRPC in ingresso
+// (impostato dal gateway dopo la verifica del token).
+func viewerUserIDFromContext(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	values := md.Get(grpcx.UserIDMetadataKey)
+	if len(values) == 0 {
+		return ""
+	}
+	return strings.TrimSpace(values[0])
+}
+
+// lockCard blocca la carta del seller in club-svc per un listing con il lock_id
+// gia' registrato nella saga (club-svc lo riusa, quindi la chiamata e' ripetibile).
+func (s *Server) lockCard(ctx context.Context, sellerUserID, userCardID, lockID string) error {
+	_, err := s.club.LockCard(ctx, &clubv1.LockCardRequest{
+		UserId:     sellerUserID,
+		UserCardId: userCardID,
+		Reason:     "market_listing",
+		LockId:     lockID,
+	})
+	if err != nil {
+		if grpcStatus, ok := status.FromError(err); ok {
+			s.logger.Warn("lock carta rifiutato da club-svc", "code", grpcStatus.Code(), "error", grpcStatus.Message())
+			return grpcStatus.Err()
 		}
-	} else if req.BidAmount < listing.StartPrice {
-		return nil, status.Error(codes.FailedPrecondition, "bid must be >= start_price")
+		s.logger.Error("errore lock carta in club-svc", "error", err)
+		return status.Error(codes.Internal, "failed to lock card")
 	}
+	return nil
+}
 
-	// 4) Crea hold crediti nel club-svc.
-	holdResp, err := s.club.CreateCreditHold(ctx, &clubv1.CreateCreditHoldRequest{
-		UserId: req.BidderUserId,
-		Amount: req.BidAmount,
-		Reason: "market_bid",
+// createCreditHold blocca crediti dell'utente in club-svc con l'hold_id gia' registrato
+// nella saga (club-svc lo riusa, quindi la chiamata e' ripetibile).
+func (s *Server) createCreditHold(ctx context.Context, userID, holdID string, amount int64, reason string) error {
+	_, err := s.club.CreateCreditHold(ctx, &clubv1.CreateCreditHoldRequest{
+		UserId: userID,
+		Amount: amount,
+		Reason: reason,
+		HoldId: holdID,
 	})
 	if err != nil {
 		if grpcStatus, ok := status.FromError(err); ok {
 			s.logger.Warn("hold crediti rifiutato da club-svc", "code", grpcStatus.Code(), "error", grpcStatus.Message())
-			return nil, grpcStatus.Err()
+			return grpcStatus.Err()
 		}
 		s.logger.Error("errore creazione hold crediti", "error", err)
-		return nil, status.Error(codes.Internal, "failed to create credit hold")
+		return status.Error(codes.Internal, "failed to create credit hold")
 	}
+	return nil
+}
 
-	// 5) Inserisce bid e aggiorna best_bid in DB.
-	bidID, err := s.repo.InsertBidAndUpdateListing(ctx, listing.ID, bidderClubID, holdResp.HoldId, req.BidAmount)
+// acquireListingLock prende il lock Redis del listing e ritorna la funzione di rilascio.
+func (s *Server) acquireListingLock(ctx context.Context, listingID string) (func(), error) {
+	lockKey := "lock:listing:" + listingID
+	token, ok, err := s.locker.Acquire(ctx, lockKey)
 	if err != nil {
-		s.logger.Error("errore inserimento bid", "error", err, "listing_id", req.ListingId)
-		_, _ = s.club.ReleaseCreditHold(ctx, &clubv1.ReleaseCreditHoldRequest{HoldId: holdResp.HoldId})
-		return nil, status.Error(codes.Internal, "failed to place bid")
+		s.logger.Error("errore acquisizione lock redis", "error", err, "listing_id", listingID)
+		return nil, status.Error(codes.Internal, "failed to acquire listing lock")
+	}
+	if !ok {
+		return nil, status.Error(codes.FailedPrecondition, "listing is locked")
 	}
+	return func() {
+		if err := s.locker.Release(context.Background(), lockKey, token); err != nil {
+			s.logger.Warn("errore rilascio lock redis", "error", err, "listing_id", listingID)
+		}
+	}, nil
+}
 
-	// 6) Rilascia l'hold precedente (se presente).
-	if listing.BestBid != nil && listing.BestBidderClubID != nil {
-		holdID, err := s.repo.GetHoldIDForBid(ctx, listing.ID, *listing.BestBidderClubID, *listing.BestBid)
-		if err != nil {
-			s.logger.Warn("errore lettura hold precedente", "error", err, "listing_id", listing.ID)
-		} else if holdID != "" {
-			if _, err := s.club.ReleaseCreditHold(ctx, &clubv1.ReleaseCreditHoldRequest{HoldId: holdID}); err != nil {
-				s.logger.Warn("errore rilascio hold precedente", "error", err, "hold_id", holdID)
-			}
+// loadListing legge il listing e mappa gli errori in codici gRPC.
+func (s *Server) loadListing(ctx context.Context, listingID string) (Listing, error) {
+	listing, err := s.repo.GetListing(ctx, listingID)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return Listing{}, status.Error(codes.NotFound, "listing not found")
 		}
+		s.logger.Error("errore lettura listing", "error", err, "listing_id", listingID)
+		return Listing{}, status.Error(codes.Internal, "failed to load listing")
 	}
+	return listing, nil
+}
 
-	s.logger.Info("bid inserito", "listing_id", listing.ID, "bid_id", bidID, "amount", req.BidAmount)
-	return &marketv1.PlaceBidResponse{
-		BestBid:          req.BidAmount,
-		BestBidderUserId: req.BidderUserId,
-	}, nil
+// bestBidHoldID ritorna l'hold del best bidder corrente (vuoto senza offerte).
+// Un errore di lettura blocca il flusso: senza l'id l'hold non verrebbe piu' rilasciato.
+func (s *Server) bestBidHoldID(ctx context.Context, listing Listing) (string, error) {
+	if listing.BestBid == nil || listing.BestBidderClubID == nil {
+		return "", nil
+	}
+	holdID, err := s.repo.GetHoldIDForBid(ctx, listing.ID, *listing.BestBidderClubID, *listing.BestBid)
+	if err != nil {
+		s.logger.Error("errore lettura hold del best bidder", "error", err, "listing_id", listing.ID)
+		return "", status.Error(codes.Internal, "failed to load best bid hold")
+	}
+	return holdID, nil
+}
+
+// userIDForClub risolve l'user_id proprietario di un club via club-svc.
+func (s *Server) userIDForClub(ctx context.Context, clubID string) (string, error) {
+	if s.club == nil {
+		return "", status.Error(codes.Internal, "club client not configured")
+	}
+	resp, err := s.club.GetClubByID(ctx, &clubv1.GetClubByIDRequest{ClubId: clubID})
+	if err != nil {
+		if grpcStatus, ok := status.FromError(err); ok && grpcStatus.Code() == codes.NotFound {
+			return "", grpcStatus.Err()
+		}
+		s.logger.Error("errore risoluzione user del club", "error", err, "club_id", clubID)
+		return "", status.Error(codes.Internal, "failed to resolve club owner")
+	}
+	if strings.TrimSpace(resp.UserId) == "" {
+		return "", status.Error(codes.Internal, "user_id missing")
+	}
+	return resp.UserId, nil
+}
+
+// clubUserResolver risolve club_id in user_id memorizzando i risultati:
+// le RPC di lista fanno una sola chiamata a club-svc per club.
+func (s *Server) clubUserResolver(ctx context.Context) func(clubID string) (string, error) {
+	users := map[string]string{}
+	return func(clubID string) (string, error) {
+		if userID, ok := users[clubID]; ok {
+			return userID, nil
+		}
+		userID, err := s.userIDForClub(ctx, clubID)
+		if err != nil {
+			return "", err
+		}
+		users[clubID] = userID
+		return userID, nil
+	}
 }
 
 // clubIDForUser chiama GetMyClub e legge club_id passando user_id via metadata gRPC.
 func (s *Server) clubIDForUser(ctx context.Context, userID string) (string, error) {
+	club, err := s.clubForUser(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+	return club.ClubId, nil
+}
+
+// clubForUser ritorna il club completo (carte incluse) passando user_id via metadata gRPC.
+func (s *Server) clubForUser(ctx context.Context, userID string) (*clubv1.GetMyClubResponse, error) {
 	if s.club == nil {
-		return "", status.Error(codes.Internal, "club client not configured")
+		return nil, status.Error(codes.Internal, "club client not configured")
 	}
 	ctxWithUser := metadata.AppendToOutgoingContext(ctx, grpcx.UserIDMetadataKey, userID)
 	resp, err := s.club.GetMyClub(ctxWithUser, &clubv1.GetMyClubRequest{})
@@ -232,17 +726,27 @@ func (s *Server) clubIDForUser(ctx context.Context, userID string) (string, erro
 		if grpcStatus, ok := status.FromError(err); ok {
 			switch grpcStatus.Code() {
 			case codes.Unauthenticated, codes.NotFound:
-				return "", grpcStatus.Err()
+				return nil, grpcStatus.Err()
 			default:
-				return "", status.Error(codes.Internal, "failed to resolve club")
+				return nil, status.Error(codes.Internal, "failed to resolve club")
 			}
 		}
-		return "", status.Error(codes.Internal, "failed to resolve club")
+		return nil, status.Error(codes.Internal, "failed to resolve club")
 	}
 	if strings.TrimSpace(resp.ClubId) == "" {
-		return "", status.Error(codes.Internal, "club_id missing")
+		return nil, status.Error(codes.Internal, "club_id missing")
 	}
-	return resp.ClubId, nil
+	return resp, nil
+}
+
+// playerIDForCard cerca il player della carta tra quelle del club (vuoto se assente).
+func playerIDForCard(club *clubv1.GetMyClubResponse, userCardID string) string {
+	for _, card := range club.Cards {
+		if card.Id == userCardID {
+			return card.PlayerId
+		}
+	}
+	return ""
 }
 
 // validateCreateListing applica le invarianti di base della request.
@@ -250,14 +754,20 @@ func validateCreateListing(req *marketv1.CreateListingRequest) error {
 	if strings.TrimSpace(req.SellerUserId) == "" {
 		return errors.New("seller_user_id is required")
 	}
-	if strings.TrimSpace(req.UserCardId) == "" {
-		return errors.New("user_card_id is required")
-	}
 	if !isUUID(req.SellerUserId) {
 		return errors.New("seller_user_id must be a valid UUID")
 	}
-	if !isUUID(req.UserCardId) {
-		return errors.New("user_card_id must be a valid UUID")
+	if len(req.BundleUserCardIds) > 0 {
+		if err := validateBundleCards(req); err != nil {
+			return err
+		}
+	} else {
+		if strings.TrimSpace(req.UserCardId) == "" {
+			return errors.New("user_card_id is required")
+		}
+		if !isUUID(req.UserCardId) {
+			return errors.New("user_card_id must be a valid UUID")
+		}
 	}
 	if req.StartPrice <= 0 {
 		return errors.New("start_price must be positive")
@@ -268,12 +778,41 @@ func validateCreateListing(req *marketv1.CreateListingRequest) error {
 	if req.BuyNowPrice > 0 && req.BuyNowPrice < req.StartPrice {
 		return errors.New("buy_now_price must be >= start_price")
 	}
+	if req.ReservePrice < 0 {
+		return errors.New("reserve_price cannot be negative")
+	}
+	if req.ReservePrice > 0 && req.ReservePrice < req.StartPrice {
+		return errors.New("reserve_price must be >= start_price")
+	}
+	if req.ReservePrice > 0 && req.BuyNowPrice > 0 && req.ReservePrice > req.BuyNowPrice {
+		return errors.New("reserve_price must be <= buy_now_price")
+	}
 	if req.ExpiresAtUnix <= time.Now().Unix() {
 		return errors.New("expires_at must be in the future")
 	}
 	return nil
 }
 
+// listingStatusToProto mappa lo stato DB nell'enum gRPC.
+// Un listing ACTIVE gia' scaduto viene riportato EXPIRED anche prima dello sweeper.
+func listingStatusToProto(listing Listing, now time.Time) marketv1.ListingStatus {
+	switch listing.Status {
+	case listingStatusActive:
+		if listing.ExpiresAtUnix <= now.Unix() {
+			return marketv1.ListingStatus_LISTING_STATUS_EXPIRED
+		}
+		return marketv1.ListingStatus_LISTING_STATUS_ACTIVE
+	case listingStatusSold:
+		return marketv1.ListingStatus_LISTING_STATUS_SOLD
+	case listingStatusExpired:
+		return marketv1.ListingStatus_LISTING_STATUS_EXPIRED
+	case listingStatusCancelled:
+		return marketv1.ListingStatus_LISTING_STATUS_CANCELLED
+	default:
+		return marketv1.ListingStatus_LISTING_STATUS_UNSPECIFIED
+	}
+}
+
 // optionalPrice converte un prezzo non positivo in nil per SQL NULL.
 func optionalPrice(value int64) *int64 {
 	if value <= 0 {
@@ -286,4 +825,3 @@ func isUUID(value string) bool {
 	_, err := uuid.Parse(value)
 	return err == nil
 }
-
@@ -16,7 +16,7 @@ import (
 	"google.golang.org/grpc/status"
 )
 
-// Test suite per i flussi CreateListing e PlaceBid.
+// Test suite per i flussi CreateListing, PlaceBid, BuyNow e GetListing.
 
 type fakeRepo struct {
 	activeListingID string
@@ -27,15 +27,56 @@ type fakeRepo struct {
 	listing         Listing
 	getListingErr   error
 	insertErr       error
-	insertBidID     string
+	insertExpiresAt int64
+	lastPlacement   BidPlacement
 	lastInsert      struct {
 		listingID    string
 		bidderClubID string
 		holdID       string
 		amount       int64
+		softClose    SoftClose
+	}
+	holdIDForBid   string
+	holdIDErr      error
+	bidHoldIDs     []string
+	soldErr        error
+	soldCalls      int
+	expiredIDs     []string
+	expiredCalls   int
+	cancelledCalls int
+	cancelledErr   error
+	bidHolds       map[string]bool
+	searchResult   []Listing
+	searchTotal    int64
+	lastFilter     ListingFilter
+	bids           []Bid
+	bidsTotal      int64
+	lastBidsQuery  struct {
+		listingID    string
+		bidderClubID string
+		limit        int
+		offset       int
+	}
+	priceBuckets    []PriceBucket
+	activeListings  int
+	priceBounds     map[string]PriceBounds
+	tradeMedian     int64
+	tradeCount      int64
+	winningListings int
+	relisted        []Listing
+	relistErr       error
+	relistableIDs   []string
+	lastPriceQuery  struct {
+		playerID string
+		since    time.Time
+		bucket   time.Duration
+	}
+	lastSold struct {
+		listingID   string
+		buyerClubID string
+		price       int64
+		tax         int64
 	}
-	holdIDForBid string
-	holdIDErr    error
 }
 
 func (r *fakeRepo) ActiveListingByCard(_ context.Context, _ string) (string, error) {
@@ -55,18 +96,27 @@ func (r *fakeRepo) GetListing(_ context.Context, _ string) (Listing, error) {
 	return r.listing, nil
 }
 
-func (r *fakeRepo) InsertBidAndUpdateListing(_ context.Context, listingID, bidderClubID, holdID string, amount int64) (string, error) {
-	r.lastInsert.listingID = listingID
-	r.lastInsert.bidderClubID = bidderClubID
-	r.lastInsert.holdID = holdID
-	r.lastInsert.amount = amount
+func (r *fakeRepo) InsertBidAndUpdateListing(_ context.Context, placement BidPlacement) (BidResult, error) {
+	r.lastPlacement = placement
+	r.lastInsert.listingID = placement.ListingID
+	r.lastInsert.bidderClubID = placement.BestBidderClubID
+	r.lastInsert.amount = placement.BestBid
+	r.lastInsert.softClose = placement.SoftClose
+	if n := len(placement.Bids); n > 0 {
+		r.lastInsert.holdID = placement.Bids[n-1].HoldID
+	}
 	if r.insertErr != nil {
-		return "", r.insertErr
+		return BidResult{}, r.insertErr
+	}
+	result := BidResult{ExpiresAtUnix: r.listing.ExpiresAtUnix}
+	for range placement.Bids {
+		result.BidIDs = append(result.BidIDs, "bid-1")
 	}
-	if r.insertBidID != "" {
-		return r.insertBidID, nil
+	if r.insertExpiresAt != 0 {
+		result.ExpiresAtUnix = r.insertExpiresAt
+		result.ExtensionCount = 1
 	}
-	return "bid-1", nil
+	return result, nil
 }
 
 func (r *fakeRepo) GetHoldIDForBid(_ context.Context, _, _ string, _ int64) (string, error) {
@@ -76,30 +126,144 @@ func (r *fakeRepo) GetHoldIDForBid(_ context.Context, _, _ string, _ int64) (str
 	return r.holdIDForBid, nil
 }
 
+func (r *fakeRepo) ListBidHoldIDs(_ context.Context, _ string) ([]string, error) {
+	return r.bidHoldIDs, nil
+}
+
+func (r *fakeRepo) MarkListingSold(_ context.Context, trade Trade) error {
+	r.soldCalls++
+	r.lastSold.listingID = trade.ListingID
+	r.lastSold.buyerClubID = trade.BuyerClubID
+	r.lastSold.price = trade.Price
+	r.lastSold.tax = trade.Tax
+	return r.soldErr
+}
+
+func (r *fakeRepo) MarkListingExpired(_ context.Context, _ string) error {
+	r.expiredCalls++
+	return nil
+}
+
+func (r *fakeRepo) MarkListingCancelled(_ context.Context, _ string) error {
+	r.cancelledCalls++
+	return r.cancelledErr
+}
+
+func (r *fakeRepo) HasBidWithHold(_ context.Context, holdID string) (bool, error) {
+	return r.bidHolds[holdID], nil
+}
+
+func (r *fakeRepo) SearchListings(_ context.Context, filter ListingFilter) ([]Listing, int64, error) {
+	r.lastFilter = filter
+	return r.searchResult, r.searchTotal, nil
+}
+
+func (r *fakeRepo) PriceHistory(_ context.Context, playerID string, since time.Time, bucket time.Duration) ([]PriceBucket, error) {
+	r.lastPriceQuery.playerID = playerID
+	r.lastPriceQuery.since = since
+	r.lastPriceQuery.bucket = bucket
+	return r.priceBuckets, nil
+}
+
+func (r *fakeRepo) CountActiveListingsBySeller(_ context.Context, _ string) (int, error) {
+	return r.activeListings, nil
+}
+
+func (r *fakeRepo) CountWinningListingsByBidder(_ context.Context, _ string) (int, error) {
+	return r.winningListings, nil
+}
+
+func (r *fakeRepo) GetPriceBounds(_ context.Context, playerID string) (PriceBounds, error) {
+	bounds, ok := r.priceBounds[playerID]
+	if !ok {
+		return PriceBounds{}, ErrNotFound
+	}
+	return bounds, nil
+}
+
+func (r *fakeRepo) UpsertPriceBounds(_ context.Context, playerID string, bounds PriceBounds) error {
+	if r.priceBounds == nil {
+		r.priceBounds = map[string]PriceBounds{}
+	}
+	r.priceBounds[playerID] = bounds
+	return nil
+}
+
+func (r *fakeRepo) DeletePriceBounds(_ context.Context, playerID string) (bool, error) {
+	_, ok := r.priceBounds[playerID]
+	delete(r.priceBounds, playerID)
+	return ok, nil
+}
+
+func (r *fakeRepo) TradePriceStats(_ context.Context, _ string, _ time.Time) (int64, int64, error) {
+	return r.tradeMedian, r.tradeCount, nil
+}
+
+func (r *fakeRepo) RelistListing(_ context.Context, listing Listing) error {
+	if r.relistErr != nil {
+		return r.relistErr
+	}
+	r.relisted = append(r.relisted, listing)
+	return nil
+}
+
+func (r *fakeRepo) ListRelistableListingIDs(_ context.Context, _ string, _ int) ([]string, error) {
+	return r.relistableIDs, nil
+}
+
+func (r *fakeRepo) ListBidsByListing(_ context.Context, listingID string, limit, offset int) ([]Bid, int64, error) {
+	r.lastBidsQuery.listingID = listingID
+	r.lastBidsQuery.limit = limit
+	r.lastBidsQuery.offset = offset
+	return r.bids, r.bidsTotal, nil
+}
+
+func (r *fakeRepo) ListBidsByBidder(_ context.Context, bidderClubID string, limit, offset int) ([]Bid, int64, error) {
+	r.lastBidsQuery.bidderClubID = bidderClubID
+	r.lastBidsQuery.limit = limit
+	r.lastBidsQuery.offset = offset
+	return r.bids, r.bidsTotal, nil
+}
+
+func (r *fakeRepo) ListExpiredListingIDs(_ context.Context, _ int) ([]string, error) {
+	return r.expiredIDs, nil
+}
+
 // fakeClub simula il client gRPC di club-svc.
 type fakeClub struct {
 	getMyClubResp     *clubv1.GetMyClubResponse
 	getMyClubErr      error
 	getMyClubCalls    int
 	getMyClubUserID   string
-	lockResp          *clubv1.LockCardResponse
 	lockErr           error
+	lockFailOnCall    int
+	lockCalls         int
+	lockIDs           []string
 	releaseCalls      int
 	releaseLastLockID string
-	holdResp          *clubv1.CreateCreditHoldResponse
 	holdErr           error
+	holdIDs           []string
 	releaseHoldCalls  int
 	releaseHoldID     string
+	releaseHoldErr    error
+	releasedHoldIDs   []string
+	clubOwnerUserID   string
+	clubOwners        map[string]string
+	settleErr         error
+	settleCalls       int
+	lastSettle        *clubv1.SettleTradeRequest
+	debitErr          error
+	lastDebit         *clubv1.DebitCreditsRequest
 }
 
-func (c *fakeClub) LockCard(_ context.Context, _ *clubv1.LockCardRequest, _ ...grpc.CallOption) (*clubv1.LockCardResponse, error) {
-	if c.lockErr != nil {
+// LockCard riusa il lock_id scelto dal market, come club-svc.
+func (c *fakeClub) LockCard(_ context.Context, req *clubv1.LockCardRequest, _ ...grpc.CallOption) (*clubv1.LockCardResponse, error) {
+	c.lockCalls++
+	c.lockIDs = append(c.lockIDs, req.LockId)
+	if c.lockErr != nil && (c.lockFailOnCall == 0 || c.lockFailOnCall == c.lockCalls) {
 		return nil, c.lockErr
 	}
-	if c.lockResp != nil {
-		return c.lockResp, nil
-	}
-	return &clubv1.LockCardResponse{LockId: "lock-1"}, nil
+	return &clubv1.LockCardResponse{LockId: req.LockId}, nil
 }
 
 func (c *fakeClub) ReleaseCardLock(_ context.Context, req *clubv1.ReleaseCardLockRequest, _ ...grpc.CallOption) (*clubv1.ReleaseCardLockResponse, error) {
@@ -131,28 +295,55 @@ func (c *fakeClub) GetMyClub(ctx context.Context, _ *clubv1.GetMyClubRequest, _
 	return &clubv1.GetMyClubResponse{ClubId: "club-1"}, nil
 }
 
+func (c *fakeClub) GetClubByID(_ context.Context, req *clubv1.GetClubByIDRequest, _ ...grpc.CallOption) (*clubv1.GetClubByIDResponse, error) {
+	userID := c.clubOwners[req.ClubId]
+	if userID == "" {
+		userID = c.clubOwnerUserID
+	}
+	if userID == "" {
+		userID = "33333333-3333-3333-3333-333333333333"
+	}
+	return &clubv1.GetClubByIDResponse{ClubId: req.ClubId, UserId: userID}, nil
+}
+
 func (c *fakeClub) GetClub(_ context.Context, _ *clubv1.GetClubRequest, _ ...grpc.CallOption) (*clubv1.GetClubResponse, error) {
 	return nil, errors.New("not implemented")
 }
 
-func (c *fakeClub) CreateCreditHold(_ context.Context, _ *clubv1.CreateCreditHoldRequest, _ ...grpc.CallOption) (*clubv1.CreateCreditHoldResponse, error) {
+// CreateCreditHold riusa l'hold_id scelto dal market, come club-svc.
+func (c *fakeClub) CreateCreditHold(_ context.Context, req *clubv1.CreateCreditHoldRequest, _ ...grpc.CallOption) (*clubv1.CreateCreditHoldResponse, error) {
+	c.holdIDs = append(c.holdIDs, req.HoldId)
 	if c.holdErr != nil {
 		return nil, c.holdErr
 	}
-	if c.holdResp != nil {
-		return c.holdResp, nil
-	}
-	return &clubv1.CreateCreditHoldResponse{HoldId: "hold-1"}, nil
+	return &clubv1.CreateCreditHoldResponse{HoldId: req.HoldId}, nil
 }
 
 func (c *fakeClub) ReleaseCreditHold(_ context.Context, req *clubv1.ReleaseCreditHoldRequest, _ ...grpc.CallOption) (*clubv1.ReleaseCreditHoldResponse, error) {
 	c.releaseHoldCalls++
 	c.releaseHoldID = req.HoldId
+	if c.releaseHoldErr != nil {
+		return nil, c.releaseHoldErr
+	}
+	c.releasedHoldIDs = append(c.releasedHoldIDs, req.HoldId)
 	return &clubv1.ReleaseCreditHoldResponse{Released: true}, nil
 }
 
-func (c *fakeClub) SettleTrade(_ context.Context, _ *clubv1.SettleTradeRequest, _ ...grpc.CallOption) (*clubv1.SettleTradeResponse, error) {
-	return nil, errors.New("not implemented")
+func (c *fakeClub) SettleTrade(_ context.Context, req *clubv1.SettleTradeRequest, _ ...grpc.CallOption) (*clubv1.SettleTradeResponse, error) {
+	c.settleCalls++
+	c.lastSettle = req
+	if c.settleErr != nil {
+		return nil, c.settleErr
+	}
+	return &clubv1.SettleTradeResponse{Settled: true}, nil
+}
+
+func (c *fakeClub) DebitCredits(_ context.Context, req *clubv1.DebitCreditsRequest, _ ...grpc.CallOption) (*clubv1.DebitCreditsResponse, error) {
+	c.lastDebit = req
+	if c.debitErr != nil {
+		return nil, c.debitErr
+	}
+	return &clubv1.DebitCreditsResponse{Debited: true}, nil
 }
 
 // fakeLock simula un lock Redis.
@@ -255,6 +446,9 @@ func TestCreateListingSuccess(t *testing.T) {
 	if repo.createdListing.BuyNowPrice == nil || *repo.createdListing.BuyNowPrice != req.BuyNowPrice {
 		t.Fatalf("unexpected buy_now_price")
 	}
+	if club.lockCalls != 1 || repo.createdListing.LockID != club.lockIDs[0] {
+		t.Fatalf("expected lock_id from LockCard to be stored, got %q", repo.createdListing.LockID)
+	}
 	if club.getMyClubUserID != req.SellerUserId {
 		t.Fatalf("expected GetMyClub to use seller user_id")
 	}
@@ -305,7 +499,7 @@ func TestCreateListingLockErrorPropagates(t *testing.T) {
 
 func TestCreateListingCreateErrorReleasesLock(t *testing.T) {
 	repo := &fakeRepo{createErr: errors.New("db down")}
-	club := &fakeClub{lockResp: &clubv1.LockCardResponse{LockId: "lock-2"}}
+	club := &fakeClub{}
 	server := NewServer(slog.Default(), repo, club, nil)
 
 	req := &marketv1.CreateListingRequest{
@@ -319,8 +513,8 @@ func TestCreateListingCreateErrorReleasesLock(t *testing.T) {
 	if status.Code(err) != codes.Internal {
 		t.Fatalf("expected Internal, got %v", err)
 	}
-	if club.releaseCalls != 1 || club.releaseLastLockID != "lock-2" {
-		t.Fatalf("expected ReleaseCardLock to be called with lock-2")
+	if club.releaseCalls != 1 || club.releaseLastLockID != club.lockIDs[0] {
+		t.Fatalf("expected ReleaseCardLock to be called with the saga lock_id")
 	}
 }
 
@@ -335,6 +529,27 @@ func TestCreateListingValidation(t *testing.T) {
 	}
 }
 
+func TestCreateListingReserveValidation(t *testing.T) {
+	request := func(reserve int64) *marketv1.CreateListingRequest {
+		return &marketv1.CreateListingRequest{
+			SellerUserId:  "11111111-1111-1111-1111-111111111111",
+			UserCardId:    "22222222-2222-2222-2222-222222222222",
+			StartPrice:    1000,
+			BuyNowPrice:   5000,
+			ExpiresAtUnix: time.Now().Add(time.Hour).Unix(),
+			ReservePrice:  reserve,
+		}
+	}
+	for _, reserve := range []int64{-1, 500, 6000} {
+		if err := validateCreateListing(request(reserve)); err == nil {
+			t.Fatalf("expected error for reserve_price %d", reserve)
+		}
+	}
+	if err := validateCreateListing(request(3000)); err != nil {
+		t.Fatalf("expected valid reserve_price, got %v", err)
+	}
+}
+
 func TestPlaceBidSuccess(t *testing.T) {
 	repo := &fakeRepo{
 		listing: Listing{
@@ -346,7 +561,6 @@ func TestPlaceBidSuccess(t *testing.T) {
 	}
 	club := &fakeClub{
 		getMyClubResp: &clubv1.GetMyClubResponse{ClubId: "club-bidder"},
-		holdResp:      &clubv1.CreateCreditHoldResponse{HoldId: "hold-1"},
 	}
 	locker := &fakeLock{token: "token", ok: true}
 	server := NewServer(slog.Default(), repo, club, locker)
@@ -367,7 +581,7 @@ func TestPlaceBidSuccess(t *testing.T) {
 	if repo.lastInsert.bidderClubID != "club-bidder" {
 		t.Fatalf("expected bidder_club_id to be used")
 	}
-	if repo.lastInsert.holdID != "hold-1" {
+	if len(club.holdIDs) != 1 || repo.lastInsert.holdID != club.holdIDs[0] {
 		t.Fatalf("expected hold_id to be used")
 	}
 }
@@ -406,7 +620,6 @@ func TestPlaceBidReleasesPreviousHold(t *testing.T) {
 	}
 	club := &fakeClub{
 		getMyClubResp: &clubv1.GetMyClubResponse{ClubId: "club-bidder"},
-		holdResp:      &clubv1.CreateCreditHoldResponse{HoldId: "hold-new"},
 	}
 	locker := &fakeLock{token: "token", ok: true}
 	server := NewServer(slog.Default(), repo, club, locker)
@@ -426,6 +639,36 @@ func TestPlaceBidReleasesPreviousHold(t *testing.T) {
 	}
 }
 
+// Caso: il listing scade durante il round-trip dell'hold, l'update del bid non trova
+// righe ACTIVE non scadute e l'hold appena creato viene rilasciato.
+func TestPlaceBidExpiredBeforeInsert(t *testing.T) {
+	repo := &fakeRepo{
+		listing: Listing{
+			ID:            "listing-1",
+			Status:        listingStatusActive,
+			StartPrice:    1000,
+			ExpiresAtUnix: time.Now().Add(time.Hour).Unix(),
+		},
+		insertErr: ErrListingNotActive,
+	}
+	club := &fakeClub{
+		getMyClubResp: &clubv1.GetMyClubResponse{ClubId: "club-bidder"},
+	}
+	server := NewServer(slog.Default(), repo, club, &fakeLock{token: "token", ok: true})
+
+	_, err := server.PlaceBid(context.Background(), &marketv1.PlaceBidRequest{
+		ListingId:    "11111111-1111-1111-1111-111111111111",
+		BidderUserId: "22222222-2222-2222-2222-222222222222",
+		BidAmount:    1500,
+	})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("expected FailedPrecondition, got %v", err)
+	}
+	if club.releaseHoldCalls != 1 || club.releaseHoldID != club.holdIDs[0] {
+		t.Fatalf("expected new hold to be released, got %d calls (%s)", club.releaseHoldCalls, club.releaseHoldID)
+	}
+}
+
 func TestPlaceBidConcurrentLock(t *testing.T) {
 	repo := &fakeRepo{
 		listing: Listing{
@@ -435,7 +678,7 @@ func TestPlaceBidConcurrentLock(t *testing.T) {
 			ExpiresAtUnix: time.Now().Add(time.Hour).Unix(),
 		},
 	}
-	club := &fakeClub{holdResp: &clubv1.CreateCreditHoldResponse{HoldId: "hold-1"}}
+	club := &fakeClub{}
 	locker := &oneShotLock{}
 	server := NewServer(slog.Default(), repo, club, locker)
 
@@ -474,3 +717,325 @@ func TestPlaceBidConcurrentLock(t *testing.T) {
 		t.Fatalf("expected 1 success and 1 FailedPrecondition, got ok=%d fail=%d", okCount, failCount)
 	}
 }
+
+func TestBuyNowSuccess(t *testing.T) {
+	buyNow := int64(2000)
+	prevBid := int64(1200)
+	prevBidder := "club-prev"
+	repo := &fakeRepo{
+		listing: Listing{
+			ID:               "listing-1",
+			SellerClubID:     "club-seller",
+			UserCardID:       "card-1",
+			Status:           listingStatusActive,
+			StartPrice:       1000,
+			BuyNowPrice:      &buyNow,
+			ExpiresAtUnix:    time.Now().Add(time.Hour).Unix(),
+			BestBid:          &prevBid,
+			BestBidderClubID: &prevBidder,
+			LockID:           "lock-card",
+		},
+		holdIDForBid: "hold-prev",
+	}
+	club := &fakeClub{
+		getMyClubResp:   &clubv1.GetMyClubResponse{ClubId: "club-buyer"},
+		clubOwnerUserID: "44444444-4444-4444-4444-444444444444",
+	}
+	locker := &fakeLock{token: "token", ok: true}
+	server := NewServer(slog.Default(), repo, club, locker)
+
+	req := &marketv1.BuyNowRequest{
+		ListingId:   "11111111-1111-1111-1111-111111111111",
+		BuyerUserId: "22222222-2222-2222-2222-222222222222",
+	}
+
+	resp, err := server.BuyNow(context.Background(), req)
+	if err != nil {
+		t.Fatalf("expected success, got error: %v", err)
+	}
+	if !resp.Purchased {
+		t.Fatalf("expected purchased=true")
+	}
+	if club.settleCalls != 1 {
+		t.Fatalf("expected SettleTrade to be called once, got %d", club.settleCalls)
+	}
+	if club.lastSettle.SellerUserId != "44444444-4444-4444-4444-444444444444" || club.lastSettle.BuyerUserId != req.BuyerUserId {
+		t.Fatalf("unexpected settle users: %+v", club.lastSettle)
+	}
+	if club.lastSettle.Amount != buyNow || club.lastSettle.HoldId != club.holdIDs[0] || club.lastSettle.UserCardId != "card-1" || club.lastSettle.CardLockId != "lock-card" {
+		t.Fatalf("unexpected settle payload: %+v", club.lastSettle)
+	}
+	if repo.soldCalls != 1 || repo.lastSold.buyerClubID != "club-buyer" || repo.lastSold.price != buyNow {
+		t.Fatalf("expected listing to be marked sold to club-buyer")
+	}
+	if club.releaseHoldCalls != 1 || club.releaseHoldID != "hold-prev" {
+		t.Fatalf("expected release of best bidder hold")
+	}
+}
+
+func TestBuyNowAppliesSellerTax(t *testing.T) {
+	buyNow := int64(2001)
+	repo := &fakeRepo{
+		listing: Listing{
+			ID:            "listing-1",
+			SellerClubID:  "club-seller",
+			UserCardID:    "card-1",
+			Status:        listingStatusActive,
+			StartPrice:    1000,
+			BuyNowPrice:   &buyNow,
+			ExpiresAtUnix: time.Now().Add(time.Hour).Unix(),
+		},
+	}
+	club := &fakeClub{getMyClubResp: &clubv1.GetMyClubResponse{ClubId: "club-buyer"}}
+	server := NewServer(slog.Default(), repo, club, &fakeLock{token: "token", ok: true}, WithSellerTaxBps(500))
+
+	if _, err := server.BuyNow(context.Background(), &marketv1.BuyNowRequest{
+		ListingId:   "11111111-1111-1111-1111-111111111111",
+		BuyerUserId: "22222222-2222-2222-2222-222222222222",
+	}); err != nil {
+		t.Fatalf("expected success, got error: %v", err)
+	}
+	if club.lastSettle.Amount != buyNow || club.lastSettle.TaxBps != 500 || club.lastSettle.TaxAmount != 100 || club.lastSettle.SellerNetAmount != 1901 {
+		t.Fatalf("unexpected tax breakdown: %+v", club.lastSettle)
+	}
+	if repo.lastSold.price != buyNow || repo.lastSold.tax != 100 {
+		t.Fatalf("expected trade with price %d and tax 100, got %+v", buyNow, repo.lastSold)
+	}
+}
+
+func TestBuyNowWithoutBuyNowPrice(t *testing.T) {
+	repo := &fakeRepo{
+		listing: Listing{
+			ID:            "listing-1",
+			SellerClubID:  "club-seller",
+			Status:        listingStatusActive,
+			StartPrice:    1000,
+			ExpiresAtUnix: time.Now().Add(time.Hour).Unix(),
+		},
+	}
+	club := &fakeClub{getMyClubResp: &clubv1.GetMyClubResponse{ClubId: "club-buyer"}}
+	locker := &fakeLock{token: "token", ok: true}
+	server := NewServer(slog.Default(), repo, club, locker)
+
+	_, err := server.BuyNow(context.Background(), &marketv1.BuyNowRequest{
+		ListingId:   "11111111-1111-1111-1111-111111111111",
+		BuyerUserId: "22222222-2222-2222-2222-222222222222",
+	})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("expected FailedPrecondition, got %v", err)
+	}
+	if club.settleCalls != 0 {
+		t.Fatalf("did not expect SettleTrade to be called")
+	}
+}
+
+func buyNowSettleServer(settleErr error) (*fakeRepo, *fakeClub, *fakeSagaLog, *Server) {
+	buyNow := int64(2000)
+	repo := &fakeRepo{
+		listing: Listing{
+			ID:            "listing-1",
+			SellerClubID:  "club-seller",
+			Status:        listingStatusActive,
+			StartPrice:    1000,
+			BuyNowPrice:   &buyNow,
+			ExpiresAtUnix: time.Now().Add(time.Hour).Unix(),
+		},
+	}
+	club := &fakeClub{
+		getMyClubResp: &clubv1.GetMyClubResponse{ClubId: "club-buyer"},
+		settleErr:     settleErr,
+	}
+	sagas := newFakeSagaLog()
+	server := NewServer(slog.Default(), repo, club, &fakeLock{token: "token", ok: true}, WithSagaLog(sagas))
+	return repo, club, sagas, server
+}
+
+// Caso: club-svc rifiuta il settlement, l'hold del buyer viene rilasciato.
+func TestBuyNowSettleRejectedReleasesHold(t *testing.T) {
+	repo, club, sagas, server := buyNowSettleServer(status.Error(codes.FailedPrecondition, "buyer already owns this player"))
+
+	_, err := server.BuyNow(context.Background(), &marketv1.BuyNowRequest{
+		ListingId:   "11111111-1111-1111-1111-111111111111",
+		BuyerUserId: "22222222-2222-2222-2222-222222222222",
+	})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("expected FailedPrecondition, got %v", err)
+	}
+	if club.releaseHoldCalls != 1 || club.releaseHoldID != club.holdIDs[0] {
+		t.Fatalf("expected buyer hold to be released")
+	}
+	if repo.soldCalls != 0 {
+		t.Fatalf("did not expect listing to be marked sold")
+	}
+	if saga := sagas.only(t); saga.Status != sagaStatusCompensated {
+		t.Fatalf("expected compensated saga, got %s", saga.Status)
+	}
+}
+
+// Caso: errore transitorio, il settlement potrebbe essere gia' applicato: l'hold resta
+// e la saga resta STARTED per il recovery.
+func TestBuyNowSettleTransientErrorLeavesSagaStarted(t *testing.T) {
+	repo, club, sagas, server := buyNowSettleServer(status.Error(codes.Unavailable, "club down"))
+
+	_, err := server.BuyNow(context.Background(), &marketv1.BuyNowRequest{
+		ListingId:   "11111111-1111-1111-1111-111111111111",
+		BuyerUserId: "22222222-2222-2222-2222-222222222222",
+	})
+	if status.Code(err) != codes.Unavailable {
+		t.Fatalf("expected Unavailable, got %v", err)
+	}
+	if club.releaseHoldCalls != 0 {
+		t.Fatalf("did not expect hold release, got %d calls", club.releaseHoldCalls)
+	}
+	if repo.soldCalls != 0 {
+		t.Fatalf("did not expect listing to be marked sold")
+	}
+	saga := sagas.only(t)
+	if saga.Status != sagaStatusStarted {
+		t.Fatalf("expected saga to stay STARTED, got %s", saga.Status)
+	}
+	if settle := saga.stepRef(sagaStepSettle); settle == nil || settle.Compensated {
+		t.Fatalf("expected pending settle step, got %+v", saga.Steps)
+	}
+}
+
+func TestGetListingResolvesUsers(t *testing.T) {
+	buyNow := int64(2000)
+	bestBid := int64(1500)
+	bestBidder := "club-bidder"
+	repo := &fakeRepo{
+		listing: Listing{
+			ID:               "listing-1",
+			SellerClubID:     "club-seller",
+			UserCardID:       "card-1",
+			Status:           listingStatusActive,
+			StartPrice:       1000,
+			BuyNowPrice:      &buyNow,
+			ExpiresAtUnix:    time.Now().Add(time.Hour).Unix(),
+			BestBid:          &bestBid,
+			BestBidderClubID: &bestBidder,
+		},
+	}
+	club := &fakeClub{clubOwners: map[string]string{
+		"club-seller": "seller-user",
+		"club-bidder": "bidder-user",
+	}}
+	server := NewServer(slog.Default(), repo, club, nil)
+
+	resp, err := server.GetListing(context.Background(), &marketv1.GetListingRequest{ListingId: "11111111-1111-1111-1111-111111111111"})
+	if err != nil {
+		t.Fatalf("expected success, got error: %v", err)
+	}
+	if resp.SellerUserId != "seller-user" || resp.BestBidderUserId != "bidder-user" {
+		t.Fatalf("unexpected users: seller=%s bidder=%s", resp.SellerUserId, resp.BestBidderUserId)
+	}
+	if resp.BestBid != bestBid || resp.BuyNowPrice != buyNow || resp.StartPrice != 1000 {
+		t.Fatalf("unexpected prices: %+v", resp)
+	}
+	if resp.Status != marketv1.ListingStatus_LISTING_STATUS_ACTIVE {
+		t.Fatalf("expected ACTIVE, got %v", resp.Status)
+	}
+}
+
+func TestGetListingReportsExpiredBeforeSweeper(t *testing.T) {
+	repo := &fakeRepo{
+		listing: Listing{
+			ID:            "listing-1",
+			SellerClubID:  "club-seller",
+			Status:        listingStatusActive,
+			StartPrice:    1000,
+			ExpiresAtUnix: time.Now().Add(-time.Minute).Unix(),
+		},
+	}
+	server := NewServer(slog.Default(), repo, &fakeClub{}, nil)
+
+	resp, err := server.GetListing(context.Background(), &marketv1.GetListingRequest{ListingId: "11111111-1111-1111-1111-111111111111"})
+	if err != nil {
+		t.Fatalf("expected success, got error: %v", err)
+	}
+	if resp.Status != marketv1.ListingStatus_LISTING_STATUS_EXPIRED {
+		t.Fatalf("expected EXPIRED, got %v", resp.Status)
+	}
+	if resp.BestBidderUserId != "" {
+		t.Fatalf("expected no best bidder")
+	}
+}
+
+func TestGetListingHidesReserveFromNonSeller(t *testing.T) {
+	reserve := int64(3000)
+	repo := &fakeRepo{
+		listing: Listing{
+			ID:            "listing-1",
+			SellerClubID:  "club-seller",
+			Status:        listingStatusActive,
+			StartPrice:    1000,
+			ReservePrice:  &reserve,
+			ExpiresAtUnix: time.Now().Add(time.Hour).Unix(),
+		},
+	}
+	club := &fakeClub{clubOwners: map[string]string{"club-seller": "44444444-4444-4444-4444-444444444444"}}
+	server := NewServer(slog.Default(), repo, club, nil)
+
+	for viewer, want := range map[string]int64{
+		"":                                     0,
+		"55555555-5555-5555-5555-555555555555": 0,
+		"44444444-4444-4444-4444-444444444444": reserve,
+	} {
+		ctx := context.Background()
+		if viewer != "" {
+			ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(grpcx.UserIDMetadataKey, viewer))
+		}
+		resp, err := server.GetListing(ctx, &marketv1.GetListingRequest{
+			ListingId: "11111111-1111-1111-1111-111111111111",
+		})
+		if err != nil {
+			t.Fatalf("expected success, got error: %v", err)
+		}
+		if resp.ReservePrice != want {
+			t.Fatalf("viewer %q: expected reserve_price %d, got %d", viewer, want, resp.ReservePrice)
+		}
+	}
+}
+
+// Caso: un non-seller conosce seller_user_id (pubblico in GetListing) ma le sue metadata
+// portano il proprio user_id: la riserva resta nascosta.
+func TestGetListingReserveNotLeakedToNonSeller(t *testing.T) {
+	reserve := int64(3000)
+	repo := &fakeRepo{
+		listing: Listing{
+			ID:            "listing-1",
+			SellerClubID:  "club-seller",
+			Status:        listingStatusActive,
+			StartPrice:    1000,
+			ReservePrice:  &reserve,
+			ExpiresAtUnix: time.Now().Add(time.Hour).Unix(),
+		},
+	}
+	club := &fakeClub{clubOwners: map[string]string{"club-seller": "44444444-4444-4444-4444-444444444444"}}
+	server := NewServer(slog.Default(), repo, club, nil)
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(grpcx.UserIDMetadataKey, "55555555-5555-5555-5555-555555555555"))
+	first, err := server.GetListing(ctx, &marketv1.GetListingRequest{ListingId: "11111111-1111-1111-1111-111111111111"})
+	if err != nil {
+		t.Fatalf("expected success, got error: %v", err)
+	}
+	// Il seller_user_id letto dalla prima risposta non da' accesso alla riserva.
+	ctx = metadata.NewIncomingContext(context.Background(), metadata.Pairs(grpcx.UserIDMetadataKey, "55555555-5555-5555-5555-555555555555", "viewer_user_id", first.SellerUserId))
+	second, err := server.GetListing(ctx, &marketv1.GetListingRequest{ListingId: "11111111-1111-1111-1111-111111111111"})
+	if err != nil {
+		t.Fatalf("expected success, got error: %v", err)
+	}
+	if second.ReservePrice != 0 {
+		t.Fatalf("expected hidden reserve for non-seller, got %d", second.ReservePrice)
+	}
+}
+
+func TestGetListingNotFound(t *testing.T) {
+	repo := &fakeRepo{getListingErr: ErrNotFound}
+	server := NewServer(slog.Default(), repo, &fakeClub{}, nil)
+
+	_, err := server.GetListing(context.Background(), &marketv1.GetListingRequest{ListingId: "11111111-1111-1111-1111-111111111111"})
+	if status.Code(err) != codes.NotFound {
+		t.Fatalf("expected NotFound, got %v", err)
+	}
+}
@@ -0,0 +1,57 @@
+package market
+
+import (
+	clubv1 "UltimateTeamX/proto/club/v1"
+)
+
+// WithSellerTaxBps applica una tassa al seller (basis point del prezzo) su ogni vendita.
+// La tassa esce dall'economia: il buyer paga il lordo, il seller riceve il netto.
+func WithSellerTaxBps(bps int64) Option {
+	return func(s *Server) {
+		s.sellerTaxBps = bps
+	}
+}
+
+// sellerTax calcola la tassa in basis point del prezzo, arrotondata per difetto.
+func sellerTax(price, bps int64) int64 {
+	if bps <= 0 {
+		return 0
+	}
+	return price * bps / 10000
+}
+
+// tradeSettlement prepara la richiesta SettleTrade con il dettaglio della tassa.
+// trade_id = listing_id rende il settlement idempotente sui retry.
+// Per i bundle card_lock_id resta vuoto: i lock sono in cards, uno per carta.
+func (s *Server) tradeSettlement(listing Listing, sellerUserID, buyerUserID, holdID string, price int64) *clubv1.SettleTradeRequest {
+	tax := sellerTax(price, s.sellerTaxBps)
+	settle := &clubv1.SettleTradeRequest{
+		SellerUserId:    sellerUserID,
+		BuyerUserId:     buyerUserID,
+		UserCardId:      listing.UserCardID,
+		Amount:          price,
+		HoldId:          holdID,
+		TradeId:         listing.ID,
+		CardLockId:      listing.LockID,
+		TaxBps:          s.sellerTaxBps,
+		TaxAmount:       tax,
+		SellerNetAmount: price - tax,
+	}
+	// Bundle: tutte le carte del lotto in un solo settlement.
+	for _, item := range listing.Items {
+		settle.Cards = append(settle.Cards, &clubv1.TradeCard{UserCardId: item.UserCardID, CardLockId: item.LockID})
+	}
+	return settle
+}
+
+// tradeRecord costruisce la riga trades registrata insieme al passaggio a SOLD.
+func tradeRecord(settle *clubv1.SettleTradeRequest, listing Listing, buyerClubID string) Trade {
+	return Trade{
+		ListingID:    settle.TradeId,
+		SellerClubID: listing.SellerClubID,
+		BuyerClubID:  buyerClubID,
+		PlayerID:     listing.PlayerID,
+		Price:        settle.Amount,
+		Tax:          settle.TaxAmount,
+	}
+}
@@ -0,0 +1,49 @@
+package ratelimit
+
+import (
+	"context"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Limiter limita il numero di operazioni per chiave in una finestra di tempo.
+type Limiter interface {
+	// Allow registra un tentativo; se il limite e' superato ritorna false e l'attesa
+	// prima della finestra successiva.
+	Allow(ctx context.Context, key string) (allowed bool, retryAfter time.Duration, err error)
+}
+
+// RedisLimiter implementa Limiter a finestra fissa su Redis (INCR + PEXPIRE),
+// condiviso tra tutte le repliche.
+type RedisLimiter struct {
+	client *redis.Client
+	limit  int
+	window time.Duration
+}
+
+// NewRedisLimiter ammette al massimo limit operazioni per chiave ogni window.
+func NewRedisLimiter(client *redis.Client, limit int, window time.Duration) *RedisLimiter {
+	return &RedisLimiter{client: client, limit: limit, window: window}
+}
+
+func (l *RedisLimiter) Allow(ctx context.Context, key string) (bool, time.Duration, error) {
+	values, err := allowLua.Run(ctx, l.client, []string{key}, l.window.Milliseconds()).Int64Slice()
+	if err != nil {
+		return false, 0, err
+	}
+	count, ttl := values[0], values[1]
+	if count > int64(l.limit) {
+		return false, time.Duration(ttl) * time.Millisecond, nil
+	}
+	return true, 0, nil
+}
+
+// allowLua incrementa il contatore e imposta la scadenza al primo tentativo della finestra.
+var allowLua = redis.NewScript(`
+local count = redis.call("incr", KEYS[1])
+if count == 1 then
+	redis.call("pexpire", KEYS[1], ARGV[1])
+end
+return {count, redis.call("pttl", KEYS[1])}
+`)